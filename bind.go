@@ -0,0 +1,83 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi/bind"
+)
+
+// runBind - disgo bind, generates the Go binding of a contract from its ABI and optional bytecode, returns the exit code
+func runBind(args []string) int {
+	flags := flag.NewFlagSet("bind", flag.ContinueOnError)
+	abiFile := flags.String("abi", "", "Path to the contract ABI JSON (required)")
+	binFile := flags.String("bin", "", "Path to the contract bytecode hex, generates a deploy function")
+	typeName := flags.String("type", "", "Go type name of the binding (default the ABI file name)")
+	pkg := flags.String("pkg", "", "Go package name of the generated file (required)")
+	out := flags.String("out", "", "Output file (default stdout)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: disgo bind -abi <file> -pkg <package> [-bin <file>] [-type <name>] [-out <file>]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *abiFile == "" || *pkg == "" {
+		flags.Usage()
+		return 2
+	}
+
+	abi, err := ioutil.ReadFile(*abiFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var bytecode []byte
+	if *binFile != "" {
+		bytecode, err = ioutil.ReadFile(*binFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if *typeName == "" {
+		*typeName = strings.TrimSuffix(filepath.Base(*abiFile), filepath.Ext(*abiFile))
+	}
+
+	code, err := bind.Bind([]string{*typeName}, []string{string(abi)}, []string{string(bytecode)}, *pkg, bind.LangGo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to generate the binding: %v\n", err)
+		return 1
+	}
+	if *out == "" {
+		fmt.Print(code)
+		return 0
+	}
+	err = ioutil.WriteFile(*out, []byte(code), 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
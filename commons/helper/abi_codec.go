@@ -0,0 +1,342 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	"github.com/pkg/errors"
+)
+
+// The JSON form of ABI values:
+//   int<N>, uint<N>     a number or, for values JSON numbers can't hold exactly, a decimal or 0x hex string
+//   bool                true or false
+//   string              a string
+//   address             20 bytes of hex, the 0x prefix is optional
+//   bytes               0x prefixed hex, a string without the prefix is read as base64
+//   bytes<N>, function  N (24 for function) bytes of hex, the 0x prefix is optional
+//   T[], T[k]           an array of k values for a fixed size array
+//   tuple               an object keyed by component name or an array of the components in order
+
+var maxExactFloat = math.Pow(2, 53)
+
+// ToAbiValues - Converts the JSON values of arguments into the Go values abi packs, errors name the argument
+func ToAbiValues(arguments abi.Arguments, values []interface{}) ([]interface{}, error) {
+	if len(values) != len(arguments) {
+		return nil, errors.Errorf("%d arguments are required and %d are provided", len(arguments), len(values))
+	}
+	result := make([]interface{}, len(arguments))
+	for i, argument := range arguments {
+		path := argument.Name
+		if path == "" {
+			path = fmt.Sprintf("argument %d", i)
+		}
+		value, err := toAbiValue(argument.Type, values[i], path)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = value
+	}
+	return result, nil
+}
+
+// ToAbiValue - Converts the JSON value of t into the Go value abi packs
+func ToAbiValue(t abi.Type, value interface{}) (interface{}, error) {
+	return toAbiValue(t, value, "value")
+}
+
+// ToJsonValues - Converts values abi unpacked for arguments into their JSON values
+func ToJsonValues(arguments abi.Arguments, values []interface{}) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		if i < len(arguments) {
+			result[i] = ToJsonValue(arguments[i].Type, value)
+		} else {
+			result[i] = value
+		}
+	}
+	return result
+}
+
+// ToJsonValue - Converts a value abi unpacked for t into its JSON value
+func ToJsonValue(t abi.Type, value interface{}) interface{} {
+	reflectValue := reflect.ValueOf(value)
+	if !reflectValue.IsValid() {
+		return nil
+	}
+	switch t.T {
+	case abi.AddressTy:
+		return hex.EncodeToString(toByteSlice(reflectValue))
+	case abi.BytesTy, abi.FixedBytesTy, abi.FunctionTy, abi.HashTy:
+		return "0x" + hex.EncodeToString(toByteSlice(reflectValue))
+	case abi.SliceTy, abi.ArrayTy:
+		if reflectValue.Kind() != reflect.Slice && reflectValue.Kind() != reflect.Array {
+			return value
+		}
+		values := make([]interface{}, reflectValue.Len())
+		for i := 0; i < reflectValue.Len(); i++ {
+			values[i] = ToJsonValue(*t.Elem, reflectValue.Index(i).Interface())
+		}
+		return values
+	case abi.TupleTy:
+		if reflectValue.Kind() != reflect.Struct {
+			return value
+		}
+		values := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			values[tupleFieldName(t, i)] = ToJsonValue(*elem, reflectValue.Field(i).Interface())
+		}
+		return values
+	}
+	return value
+}
+
+// toAbiValue - Converts the JSON value at path into the Go value of t
+func toAbiValue(t abi.Type, value interface{}, path string) (interface{}, error) {
+	if value == nil {
+		return nil, errors.Errorf("%s: a %s value is required", path, t.String())
+	}
+
+	// Go values of the right type are used as is
+	if t.T != abi.IntTy && t.T != abi.UintTy && reflect.TypeOf(value) == t.Type {
+		return value, nil
+	}
+
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return toAbiInteger(t, value, path)
+	case abi.BoolTy:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.Errorf("%s: expected a %s, got %s", path, t.String(), describe(value))
+		}
+		return b, nil
+	case abi.StringTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("%s: expected a %s, got %s", path, t.String(), describe(value))
+		}
+		return s, nil
+	case abi.AddressTy, abi.FixedBytesTy, abi.FunctionTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("%s: expected %d bytes of hex for %s, got %s", path, t.Size, t.String(), describe(value))
+		}
+		bytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+		if err != nil {
+			return nil, errors.Errorf("%s: expected %d bytes of hex for %s, %s is not hex", path, t.Size, t.String(), strconv.Quote(s))
+		}
+		if len(bytes) != t.Size {
+			return nil, errors.Errorf("%s: expected %d bytes for %s, got %d", path, t.Size, t.String(), len(bytes))
+		}
+		array := reflect.New(t.Type).Elem()
+		reflect.Copy(array, reflect.ValueOf(bytes))
+		return array.Interface(), nil
+	case abi.BytesTy:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("%s: expected 0x prefixed hex or base64 for bytes, got %s", path, describe(value))
+		}
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			bytes, err := hex.DecodeString(s[2:])
+			if err != nil {
+				return nil, errors.Errorf("%s: %s is not hex", path, strconv.Quote(s))
+			}
+			return bytes, nil
+		}
+		bytes, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Errorf("%s: %s is neither 0x prefixed hex nor base64", path, strconv.Quote(s))
+		}
+		return bytes, nil
+	case abi.SliceTy, abi.ArrayTy:
+		values := reflect.ValueOf(value)
+		if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+			return nil, errors.Errorf("%s: expected an array for %s, got %s", path, t.String(), describe(value))
+		}
+		var result reflect.Value
+		if t.T == abi.ArrayTy {
+			if values.Len() != t.Size {
+				return nil, errors.Errorf("%s: expected %d values for %s, got %d", path, t.Size, t.String(), values.Len())
+			}
+			result = reflect.New(t.Type).Elem()
+		} else {
+			result = reflect.MakeSlice(t.Type, values.Len(), values.Len())
+		}
+		for i := 0; i < values.Len(); i++ {
+			elem, err := toAbiValue(*t.Elem, values.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			result.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return result.Interface(), nil
+	case abi.TupleTy:
+		return toAbiTuple(t, value, path)
+	}
+	return nil, errors.Errorf("%s: type %s is not supported", path, t.String())
+}
+
+// toAbiInteger - Converts a number or a decimal or hex string into the Go integer type of t, checking its range
+func toAbiInteger(t abi.Type, value interface{}, path string) (interface{}, error) {
+	var n *big.Int
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return nil, errors.Errorf("%s: expected an integer for %s, got %v", path, t.String(), v)
+		}
+		if math.Abs(v) > maxExactFloat {
+			return nil, errors.Errorf("%s: %v is too large to be exact as a number, give it as a decimal string", path, v)
+		}
+		n, _ = new(big.Float).SetFloat64(v).Int(nil)
+	case json.Number:
+		n, _ = new(big.Int).SetString(v.String(), 10)
+	case string:
+		s := strings.TrimSpace(v)
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			n, _ = new(big.Int).SetString(s[2:], 16)
+		} else {
+			n, _ = new(big.Int).SetString(s, 10)
+		}
+	case *big.Int:
+		if v != nil {
+			n = new(big.Int).Set(v)
+		}
+	case big.Int:
+		n = new(big.Int).Set(&v)
+	default:
+		reflectValue := reflect.ValueOf(value)
+		switch reflectValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = big.NewInt(reflectValue.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = new(big.Int).SetUint64(reflectValue.Uint())
+		}
+	}
+	if n == nil {
+		return nil, errors.Errorf("%s: expected an integer for %s, got %s", path, t.String(), describe(value))
+	}
+
+	// In range?
+	if t.T == abi.UintTy {
+		if n.Sign() < 0 || n.BitLen() > t.Size {
+			return nil, errors.Errorf("%s: %s is out of range for %s", path, n.String(), t.String())
+		}
+	} else {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, errors.Errorf("%s: %s is out of range for %s", path, n.String(), t.String())
+		}
+	}
+
+	if t.Kind == reflect.Ptr {
+		return n, nil
+	}
+	result := reflect.New(t.Type).Elem()
+	if t.T == abi.UintTy {
+		result.SetUint(n.Uint64())
+	} else {
+		result.SetInt(n.Int64())
+	}
+	return result.Interface(), nil
+}
+
+// toAbiTuple - Converts an object keyed by component name or an array of the components into the struct of t
+func toAbiTuple(t abi.Type, value interface{}, path string) (interface{}, error) {
+	result := reflect.New(t.Type).Elem()
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			found := false
+			for i := range t.TupleElems {
+				if tupleFieldName(t, i) == key {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, errors.Errorf("%s: %s has no component %s", path, t.String(), strconv.Quote(key))
+			}
+		}
+		for i, elem := range t.TupleElems {
+			name := tupleFieldName(t, i)
+			component, ok := v[name]
+			if !ok {
+				return nil, errors.Errorf("%s: component %s is missing", path, strconv.Quote(name))
+			}
+			converted, err := toAbiValue(*elem, component, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			result.Field(i).Set(reflect.ValueOf(converted))
+		}
+	case []interface{}:
+		if len(v) != len(t.TupleElems) {
+			return nil, errors.Errorf("%s: expected %d components for %s, got %d", path, len(t.TupleElems), t.String(), len(v))
+		}
+		for i, elem := range t.TupleElems {
+			converted, err := toAbiValue(*elem, v[i], path+"."+tupleFieldName(t, i))
+			if err != nil {
+				return nil, err
+			}
+			result.Field(i).Set(reflect.ValueOf(converted))
+		}
+	default:
+		return nil, errors.Errorf("%s: expected an object or an array for %s, got %s", path, t.String(), describe(value))
+	}
+	return result.Interface(), nil
+}
+
+// tupleFieldName - The JSON name of a tuple component, its index when the component is unnamed
+func tupleFieldName(t abi.Type, index int) string {
+	if t.TupleRawNames[index] == "" {
+		return strconv.Itoa(index)
+	}
+	return t.TupleRawNames[index]
+}
+
+// toByteSlice - The bytes of a byte slice or array
+func toByteSlice(value reflect.Value) []byte {
+	if value.Kind() == reflect.Slice {
+		return value.Bytes()
+	}
+	bytes := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(bytes), value)
+	return bytes
+}
+
+// describe - A JSON value as it is quoted in errors
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,201 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+)
+
+func mustType(t *testing.T, typ string, components ...abi.ArgumentMarshaling) abi.Type {
+	abiType, err := abi.NewTypeWithComponents(typ, components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return abiType
+}
+
+func bigFromString(value string) *big.Int {
+	n, _ := new(big.Int).SetString(value, 10)
+	return n
+}
+
+var personComponents = []abi.ArgumentMarshaling{{Name: "age", Type: "uint8"}, {Name: "wallet", Type: "address"}}
+
+// TestToAbiValue
+func TestToAbiValue(t *testing.T) {
+	address := crypto.GetAddressBytes("3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c")
+	tests := []struct {
+		typ        string
+		components []abi.ArgumentMarshaling
+		json       string
+		expected   interface{}
+		err        string
+	}{
+		{typ: "uint8", json: `255`, expected: uint8(255)},
+		{typ: "uint8", json: `256`, err: "256 is out of range for uint8"},
+		{typ: "uint8", json: `-1`, err: "-1 is out of range for uint8"},
+		{typ: "int8", json: `-128`, expected: int8(-128)},
+		{typ: "int8", json: `128`, err: "128 is out of range for int8"},
+		{typ: "int64", json: `"-9223372036854775808"`, expected: int64(-9223372036854775808)},
+		{typ: "uint32", json: `1.5`, err: "expected an integer for uint32, got 1.5"},
+		{typ: "uint256", json: `42`, expected: big.NewInt(42)},
+		{typ: "uint256", json: `"115792089237316195423570985008687907853269984665640564039457584007913129639935"`, expected: bigFromString("115792089237316195423570985008687907853269984665640564039457584007913129639935")},
+		{typ: "uint256", json: `"115792089237316195423570985008687907853269984665640564039457584007913129639936"`, err: "out of range for uint256"},
+		{typ: "uint256", json: `"0xff"`, expected: big.NewInt(255)},
+		{typ: "uint256", json: `1e20`, err: "give it as a decimal string"},
+		{typ: "int256", json: `"-5"`, expected: big.NewInt(-5)},
+		{typ: "uint256", json: `"abc"`, err: `expected an integer for uint256, got "abc"`},
+		{typ: "bool", json: `true`, expected: true},
+		{typ: "bool", json: `"true"`, err: `expected a bool, got "true"`},
+		{typ: "string", json: `"hello"`, expected: "hello"},
+		{typ: "address", json: `"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"`, expected: address},
+		{typ: "address", json: `"0x3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"`, expected: address},
+		{typ: "address", json: `"0x3ed2"`, err: "expected 20 bytes for address, got 2"},
+		{typ: "address", json: `"zz"`, err: `"zz" is not hex`},
+		{typ: "bytes", json: `"0x0102"`, expected: []byte{1, 2}},
+		{typ: "bytes", json: `"AQI="`, expected: []byte{1, 2}},
+		{typ: "bytes", json: `"0xzz"`, err: `"0xzz" is not hex`},
+		{typ: "bytes2", json: `"0x0102"`, expected: [2]byte{1, 2}},
+		{typ: "bytes2", json: `"010203"`, err: "expected 2 bytes for bytes2, got 3"},
+		{typ: "uint16[]", json: `[1, 2, 3]`, expected: []uint16{1, 2, 3}},
+		{typ: "uint16[]", json: `[1, "x"]`, err: `value[1]: expected an integer for uint16, got "x"`},
+		{typ: "uint256[2]", json: `["1", 2]`, expected: [2]*big.Int{big.NewInt(1), big.NewInt(2)}},
+		{typ: "uint256[2]", json: `[1]`, err: "expected 2 values for uint256[2], got 1"},
+		{typ: "string[][]", json: `[["a"], [], ["b", "c"]]`, expected: [][]string{{"a"}, {}, {"b", "c"}}},
+		{typ: "tuple", components: personComponents, json: `{"age": 30, "wallet": "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"}`},
+		{typ: "tuple", components: personComponents, json: `[30, "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"]`},
+		{typ: "tuple", components: personComponents, json: `{"age": 30}`, err: `component "wallet" is missing`},
+		{typ: "tuple", components: personComponents, json: `{"age": 30, "wallet": "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "name": "x"}`, err: `has no component "name"`},
+		{typ: "tuple[]", components: personComponents, json: `[{"age": 300, "wallet": "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"}]`, err: "value[0].age: 300 is out of range for uint8"},
+		{typ: "uint8", json: `null`, err: "a uint8 value is required"},
+	}
+	for _, test := range tests {
+		abiType := mustType(t, test.typ, test.components...)
+		var value interface{}
+		if err := json.Unmarshal([]byte(test.json), &value); err != nil {
+			t.Fatal(err)
+		}
+		result, err := ToAbiValue(abiType, value)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s %s: expected error containing %q, got %v", test.typ, test.json, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: unexpected error %v", test.typ, test.json, err)
+			continue
+		}
+		if test.expected != nil && !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("%s %s: expected %#v, got %#v", test.typ, test.json, test.expected, result)
+		}
+
+		// Round trip through the ABI encoding and back to JSON.
+		arguments := abi.Arguments{{Type: abiType}}
+		packed, err := arguments.Pack(result)
+		if err != nil {
+			t.Errorf("%s %s: pack failed %v", test.typ, test.json, err)
+			continue
+		}
+		unpacked, err := arguments.UnpackValues(packed)
+		if err != nil {
+			t.Errorf("%s %s: unpack failed %v", test.typ, test.json, err)
+			continue
+		}
+		again, err := ToAbiValue(abiType, roundTrip(t, ToJsonValue(abiType, unpacked[0])))
+		if err != nil {
+			t.Errorf("%s %s: decoded value does not convert back %v", test.typ, test.json, err)
+			continue
+		}
+		if !reflect.DeepEqual(again, result) {
+			t.Errorf("%s %s: round trip expected %#v, got %#v", test.typ, test.json, result, again)
+		}
+	}
+}
+
+// roundTrip - Marshals and unmarshals a value the way it travels over HTTP
+func roundTrip(t *testing.T, value interface{}) interface{} {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// TestToJsonValue
+func TestToJsonValue(t *testing.T) {
+	address := crypto.GetAddressBytes("3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c")
+	tests := []struct {
+		typ        string
+		components []abi.ArgumentMarshaling
+		value      interface{}
+		expected   string
+	}{
+		{typ: "uint256", value: big.NewInt(7), expected: `7`},
+		{typ: "address", value: address, expected: `"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"`},
+		{typ: "bytes", value: []byte{1, 2}, expected: `"0x0102"`},
+		{typ: "bytes2", value: [2]byte{1, 2}, expected: `"0x0102"`},
+		{typ: "bool[]", value: []bool{true, false}, expected: `[true,false]`},
+		{typ: "tuple", components: personComponents, value: struct {
+			Age    uint8
+			Wallet crypto.AddressBytes
+		}{30, address}, expected: `{"age":30,"wallet":"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"}`},
+	}
+	for _, test := range tests {
+		abiType := mustType(t, test.typ, test.components...)
+		bytes, err := json.Marshal(ToJsonValue(abiType, test.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bytes) != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.typ, test.expected, bytes)
+		}
+	}
+}
+
+// TestToAbiValues
+func TestToAbiValues(t *testing.T) {
+	arguments := abi.Arguments{
+		{Name: "to", Type: mustType(t, "address")},
+		{Name: "", Type: mustType(t, "uint256")},
+	}
+	_, err := ToAbiValues(arguments, []interface{}{"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"})
+	if err == nil || err.Error() != "2 arguments are required and 1 are provided" {
+		t.Errorf("unexpected error %v", err)
+	}
+	_, err = ToAbiValues(arguments, []interface{}{"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "x"})
+	if err == nil || !strings.HasPrefix(err.Error(), "argument 1: ") {
+		t.Errorf("unexpected error %v", err)
+	}
+	_, err = ToAbiValues(arguments, []interface{}{true, 1.0})
+	if err == nil || !strings.HasPrefix(err.Error(), "to: ") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
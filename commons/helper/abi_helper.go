@@ -1,235 +1,59 @@
 package helper
 
 import (
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
-	"math/big"
-	"reflect"
+	"sort"
 	"strings"
 
-	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
 	"github.com/pkg/errors"
 )
 
+// GetConvertedParams - Converts the JSON params of a transaction into the Go values its method's inputs are packed from
 func GetConvertedParams(tx *types.Transaction) ([]interface{}, error) {
 	utils.Info("GetConvertedParams --> ", tx.Params)
 	theABI, err := GetABI(tx.Abi)
 	if err != nil {
 		return nil, err
 	}
-	var result []interface{}
-	found := false
-	for k, v := range theABI.Methods {
-		if k == tx.Method {
-			found = true
-			if tx.Params == nil || len(tx.Params) == 0 {
-				return tx.Params, nil
-			}
-			if len(v.Inputs) != len(tx.Params) {
-				return nil, errors.New(fmt.Sprintf("The method %s, requires %d parameters and %d are provided", tx.Method, len(v.Inputs), len(tx.Params)))
-			}
-			for i := 0; i < len(v.Inputs); i++ {
-				arg := v.Inputs[i]
-				if arg.Type.T == abi.SliceTy || arg.Type.T == abi.ArrayTy {
-					value, valErr := getValues(arg, tx.Params[i].([]interface{}))
-					if valErr != nil {
-						msg := fmt.Sprintf("Invalid value provided for method %s: %v", tx.Method, valErr.Error())
-						return nil, errors.New(msg)
-					}
-					result = append(result, value)
-				} else if arg.Type.T == abi.AddressTy {
-					addressAsString, valErr := getValue(arg, tx.Params[i])
-					addressAsByteArray := crypto.GetAddressBytes(addressAsString.(string))
-					if len(addressAsByteArray) < 0 {
-						msg := fmt.Sprintf("Invalid value provided for method %s: %v", tx.Method, valErr.Error())
-						return nil, errors.New(msg)
-					}
-					result = append(result, addressAsByteArray)
-				} else if arg.Type.T == abi.BytesTy{
-					params, valErr := base64.StdEncoding.DecodeString(tx.Params[i].(string))
-					if err != nil{
-						msg := fmt.Sprintf("Invalid value provided for method %s: %v", tx.Method, valErr.Error())
-						return nil, errors.New(msg)
-					}
-					result = append(result, params)
-				} else {
-					value, valErr := getValue(arg, tx.Params[i])
-					if valErr != nil {
-						msg := fmt.Sprintf("Invalid value provided for method %s: %v", tx.Method, valErr.Error())
-						return nil, errors.New(msg)
-					}
-					result = append(result, value)
-				}
-			}
-		}
-	}
-	if !found {
+	method, ok := theABI.Methods[tx.Method]
+	if !ok {
 		return nil, errors.New(fmt.Sprintf("This method '%s' is not valid for this contract", tx.Method))
 	}
-	return result, nil
-}
-
-func getValues(arg abi.Argument, values []interface{}) (interface{}, error) {
-	var result interface{}
-	dataTypeString := arg.Type.String()[0 : len(arg.Type.String())-2]
-	if strings.HasPrefix(dataTypeString, "int") || strings.HasPrefix(dataTypeString, "uint") {
-		for _, value := range values {
-			_, isNumber := value.(float64)
-			if !isNumber {
-				return nil, errors.Errorf("only number value required in input array, a provided value is '%v'", value)
-			}
-		}
+	if len(method.Inputs) != len(tx.Params) {
+		return nil, errors.New(fmt.Sprintf("The method %s, requires %d parameters and %d are provided", tx.Method, len(method.Inputs), len(tx.Params)))
 	}
-	switch dataTypeString {
-	case "int256", "uint256", "int", "uint":
-		dynarrin := make([]*big.Int, 0)
-		for _, value := range values {
-			dynarrin = append(dynarrin, big.NewInt(int64(value.(float64))))
-		}
-		result = dynarrin
-		break
-	case "int8":
-		array := make([]int8, 0)
-		for _, value := range values {
-			array = append(array, int8(value.(float64)))
-		}
-		result = array
-		break
-	case "int16":
-		array := make([]int16, 0)
-		for _, value := range values {
-			array = append(array, int16(value.(float64)))
-		}
-		result = array
-		break
-	case "int32":
-		array := make([]int32, 0)
-		for _, value := range values {
-			array = append(array, int32(value.(float64)))
-		}
-		result = array
-		break
-	case "int64":
-		array := make([]int64, 0)
-		for _, value := range values {
-			array = append(array, int64(value.(float64)))
-		}
-		result = array
-		break
-	case "uint8":
-		array := make([]uint8, 0)
-		for _, value := range values {
-			array = append(array, uint8(value.(float64)))
-		}
-		result = array
-		break
-	case "uint16":
-		array := make([]uint16, 0)
-		for _, value := range values {
-			array = append(array, uint16(value.(float64)))
-		}
-		result = array
-		break
-	case "uint32":
-		array := make([]uint32, 0)
-		for _, value := range values {
-			array = append(array, uint32(value.(float64)))
-		}
-		result = array
-		break
-	case "uint64":
-		array := make([]uint64, 0)
-		for _, value := range values {
-			array = append(array, uint64(value.(float64)))
-		}
-		result = array
-		break
-	default:
-		array := make([]interface{}, 0)
-		for _, value := range values {
-			array = append(array, value)
-		}
-		result = array
+	if len(tx.Params) == 0 {
+		return tx.Params, nil
+	}
+	result, err := ToAbiValues(method.Inputs, tx.Params)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Invalid value provided for method %s: %v", tx.Method, err))
 	}
 	return result, nil
 }
 
-//numerics from json are always serialized as float64
-func getValue(arg abi.Argument, value interface{}) (interface{}, error) {
-	nbrValue, isNumber := value.(float64)
-	if arg.Type.String() == "int256" || arg.Type.String() == "uint256" {
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return big.NewInt(int64(value.(float64))), nil
+// GetConvertedConstructorParams - Converts the JSON params of a deploy transaction into the Go values its constructor's
+// inputs are packed from
+func GetConvertedConstructorParams(tx *types.Transaction) ([]interface{}, error) {
+	if len(tx.Params) == 0 {
+		return tx.Params, nil
 	}
-	switch arg.Type.Kind {
-	case reflect.Int:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return big.NewInt(int64(nbrValue)), nil
-	case reflect.Int8:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return int8(nbrValue), nil
-	case reflect.Int16:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return int16(nbrValue), nil
-	case reflect.Int32:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return int32(nbrValue), nil
-	case reflect.Int64:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return int64(nbrValue), nil
-	case reflect.Uint:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return big.NewInt(int64(nbrValue)), nil
-	case reflect.Uint8:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return uint8(nbrValue), nil
-	case reflect.Uint16:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return uint16(nbrValue), nil
-	case reflect.Uint32:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return uint32(nbrValue), nil
-	case reflect.Uint64:
-		if !isNumber {
-			return nil, errors.Errorf("number value required, provided value is '%v'", value)
-		}
-		return uint64(nbrValue), nil
-	case reflect.Bool:
-		val, ok := value.(bool)
-		if !ok {
-			return nil, errors.Errorf("boolean value required, provided value is %v", value)
-		}
-		if val == true || val == false {
-			return val, nil
-		}
-	default:
-		return value, nil
+	theABI, err := GetABI(tx.Abi)
+	if err != nil {
+		return nil, err
 	}
-	return value, nil
+	if len(theABI.Constructor.Inputs) != len(tx.Params) {
+		return nil, errors.New(fmt.Sprintf("The constructor requires %d parameters and %d are provided", len(theABI.Constructor.Inputs), len(tx.Params)))
+	}
+	result, err := ToAbiValues(theABI.Constructor.Inputs, tx.Params)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Invalid value provided for the constructor: %v", err))
+	}
+	return result, nil
 }
 
 func GetABI(data string) (*abi.ABI, error) {
@@ -247,3 +71,70 @@ func GetABI(data string) (*abi.ABI, error) {
 	return &abi, nil
 }
 
+
+// GetDecodedOutputs - Unpacks a method's return data into JSON friendly values
+func GetDecodedOutputs(theABI *abi.ABI, method string, output []byte) ([]interface{}, error) {
+	abiMethod, ok := theABI.Methods[method]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("This method '%s' is not valid for this contract", method))
+	}
+	values, err := abiMethod.Outputs.UnpackValues(output)
+	if err != nil {
+		return nil, err
+	}
+	return ToJsonValues(abiMethod.Outputs, values), nil
+}
+
+// GetRevertReason - Decodes what a contract reverted with, a revert("...") reason, a Panic(uint256) code or one of the
+// custom errors of the contract's ABI (theABI may be nil)
+func GetRevertReason(theABI *abi.ABI, data []byte) string {
+	if len(data) == 0 {
+		return "execution reverted"
+	}
+	reason, err := abi.UnpackRevert(data)
+	if err == nil {
+		return reason
+	}
+	if theABI != nil {
+		if abiError, err := theABI.ErrorById(data); err == nil {
+			values, err := abiError.Inputs.UnpackValues(data[4:])
+			if err == nil {
+				args := make([]string, len(values))
+				for i, value := range values {
+					args[i] = fmt.Sprintf("%v", ToJsonValue(abiError.Inputs[i].Type, value))
+					if abiError.Inputs[i].Name != "" {
+						args[i] = abiError.Inputs[i].Name + "=" + args[i]
+					}
+				}
+				return fmt.Sprintf("%s(%s)", abiError.Name, strings.Join(args, ", "))
+			}
+		}
+	}
+	return "execution reverted: 0x" + hex.EncodeToString(data)
+}
+
+// GetMethodCatalog - Lists the methods of an ABI with their signatures and selectors, sorted by signature
+func GetMethodCatalog(theABI *abi.ABI) []*types.ContractMethod {
+	methods := make([]*types.ContractMethod, 0, len(theABI.Methods))
+	for _, method := range theABI.Methods {
+		methods = append(methods, &types.ContractMethod{
+			Name:      method.Name,
+			Signature: method.Sig(),
+			Selector:  hex.EncodeToString(method.Id()),
+			Constant:  method.Const,
+			Inputs:    toContractArguments(method.Inputs),
+			Outputs:   toContractArguments(method.Outputs),
+		})
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Signature < methods[j].Signature })
+	return methods
+}
+
+// toContractArguments
+func toContractArguments(arguments abi.Arguments) []*types.ContractArgument {
+	contractArguments := make([]*types.ContractArgument, len(arguments))
+	for i, argument := range arguments {
+		contractArguments[i] = &types.ContractArgument{Name: argument.Name, Type: argument.Type.String()}
+	}
+	return contractArguments
+}
@@ -0,0 +1,87 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+)
+
+const testOutputsAbi = `[{"constant":true,"inputs":[],"name":"var6","outputs":[{"name":"var1","type":"uint256"},{"name":"var2","type":"bool"},{"name":"var3","type":"address"},{"name":"var4","type":"string"}],"payable":false,"stateMutability":"view","type":"function"}]`
+
+// TestGetDecodedOutputs
+func TestGetDecodedOutputs(t *testing.T) {
+	theABI, err := abi.JSON(strings.NewReader(testOutputsAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.GetAddressBytes("3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c")
+	output, err := theABI.Methods["var6"].Outputs.Pack(big.NewInt(42), true, address, "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := GetDecodedOutputs(&theABI, "var6", output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 4 {
+		t.Fatalf("expected 4 outputs, got %d", len(outputs))
+	}
+	if outputs[0].(*big.Int).Int64() != 42 {
+		t.Errorf("unexpected var1 %v", outputs[0])
+	}
+	if outputs[1].(bool) != true {
+		t.Errorf("unexpected var2 %v", outputs[1])
+	}
+	if outputs[2].(string) != hex.EncodeToString(address[:]) {
+		t.Errorf("unexpected var3 %v", outputs[2])
+	}
+	if outputs[3].(string) != "hello" {
+		t.Errorf("unexpected var4 %v", outputs[3])
+	}
+	_, err = GetDecodedOutputs(&theABI, "missing", output)
+	if err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
+
+// TestGetMethodCatalog
+func TestGetMethodCatalog(t *testing.T) {
+	theABI, err := abi.JSON(strings.NewReader(`[{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},` + testOutputsAbi[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := GetMethodCatalog(&theABI)
+	if len(methods) != 2 {
+		t.Fatalf("expected 2 methods, got %d", len(methods))
+	}
+	transfer := methods[0]
+	if transfer.Signature != "transfer(address,uint256)" || transfer.Selector != "a9059cbb" || transfer.Constant {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+	if len(transfer.Inputs) != 2 || transfer.Inputs[1].Name != "value" || transfer.Inputs[1].Type != "uint256" {
+		t.Errorf("unexpected transfer inputs %+v", transfer.Inputs)
+	}
+	if methods[1].Signature != "var6()" || !methods[1].Constant || len(methods[1].Outputs) != 4 {
+		t.Errorf("unexpected var6 %+v", methods[1])
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/pkg/errors"
+)
+
+// Artifact - A compiled contract read from compiler output, what a deploy needs without a compiler on the node
+type Artifact struct {
+	Name     string // Fully qualified as source:name when the output has the source
+	Abi      string // ABI JSON
+	Code     string // Hex creation code with its libraries linked
+	Source   string // Contract source, Truffle artifacts carry it
+	Metadata string // Compiler metadata JSON
+}
+
+// linkReference - Where the address of a library goes in the code, in bytes
+type linkReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// compiledContract - A contract of compiler output, in any of the formats ReadArtifact reads
+type compiledContract struct {
+	name           string
+	abi            json.RawMessage
+	code           string
+	source         string
+	metadata       string
+	linkReferences map[string]map[string][]linkReference
+}
+
+// ReadArtifact - Reads the contract called name out of solc standard-JSON or --combined-json output, or out of a Truffle
+// or Hardhat artifact, and links the libraries it uses. name is the contract name or source:name, it can be empty when
+// there is one contract with code. libraries maps a library, by name or source:name, to its address.
+func ReadArtifact(output []byte, name string, libraries map[string]string) (*Artifact, error) {
+	contracts, err := toCompiledContracts(output)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := findCompiledContract(contracts, name)
+	if err != nil {
+		return nil, err
+	}
+
+	code := strings.TrimPrefix(contract.code, "0x")
+	if code == "" {
+		return nil, errors.Errorf("contract %s has no code, it is abstract or an interface", contract.name)
+	}
+	code, err = linkLibraries(code, contract, contracts, libraries)
+	if err != nil {
+		return nil, err
+	}
+
+	abi := string(contract.abi)
+	var abiString string
+	if json.Unmarshal(contract.abi, &abiString) == nil {
+		abi = abiString
+	}
+	return &Artifact{Name: contract.name, Abi: abi, Code: code, Source: contract.source, Metadata: contract.metadata}, nil
+}
+
+// toCompiledContracts - The contracts of compiler output, sorted by name
+func toCompiledContracts(output []byte) ([]*compiledContract, error) {
+	var document struct {
+		Contracts map[string]json.RawMessage `json:"contracts"`
+		Errors    []struct {
+			Severity         string `json:"severity"`
+			FormattedMessage string `json:"formattedMessage"`
+		} `json:"errors"`
+
+		// Truffle and Hardhat artifacts.
+		ContractName   string                                `json:"contractName"`
+		SourceName     string                                `json:"sourceName"`
+		Abi            json.RawMessage                       `json:"abi"`
+		Bytecode       *string                               `json:"bytecode"`
+		Source         string                                `json:"source"`
+		Metadata       string                                `json:"metadata"`
+		LinkReferences map[string]map[string][]linkReference `json:"linkReferences"`
+	}
+	err := json.Unmarshal(output, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, "compiler output is not JSON")
+	}
+	for _, compilerError := range document.Errors {
+		if compilerError.Severity == "error" {
+			return nil, errors.Errorf("compiler output has errors: %s", compilerError.FormattedMessage)
+		}
+	}
+
+	contracts := make([]*compiledContract, 0)
+	switch {
+	case document.Bytecode != nil:
+		name := document.ContractName
+		if document.SourceName != "" {
+			name = document.SourceName + ":" + name
+		}
+		contracts = append(contracts, &compiledContract{name: name, abi: document.Abi, code: *document.Bytecode, source: document.Source, metadata: document.Metadata, linkReferences: document.LinkReferences})
+	case document.Contracts != nil:
+		for key, raw := range document.Contracts {
+
+			// --combined-json is keyed by source:name, standard-JSON by source and then name.
+			var combined struct {
+				Abi      json.RawMessage `json:"abi"`
+				Bin      *string         `json:"bin"`
+				Metadata string          `json:"metadata"`
+			}
+			if json.Unmarshal(raw, &combined) == nil && combined.Bin != nil {
+				contracts = append(contracts, &compiledContract{name: key, abi: combined.Abi, code: *combined.Bin, metadata: combined.Metadata})
+				continue
+			}
+			var standard map[string]struct {
+				Abi      json.RawMessage `json:"abi"`
+				Metadata string          `json:"metadata"`
+				Evm      struct {
+					Bytecode struct {
+						Object         string                                `json:"object"`
+						LinkReferences map[string]map[string][]linkReference `json:"linkReferences"`
+					} `json:"bytecode"`
+				} `json:"evm"`
+			}
+			err = json.Unmarshal(raw, &standard)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to read contracts of %s", key)
+			}
+			for name, contract := range standard {
+				contracts = append(contracts, &compiledContract{name: key + ":" + name, abi: contract.Abi, code: contract.Evm.Bytecode.Object, metadata: contract.Metadata, linkReferences: contract.Evm.Bytecode.LinkReferences})
+			}
+		}
+	default:
+		return nil, errors.New("compiler output is not solc standard-JSON or --combined-json output, nor a Truffle or Hardhat artifact")
+	}
+	sort.Slice(contracts, func(i, j int) bool { return contracts[i].name < contracts[j].name })
+	return contracts, nil
+}
+
+// findCompiledContract - The contract called name, by name or source:name, the only one with code when name is empty
+func findCompiledContract(contracts []*compiledContract, name string) (*compiledContract, error) {
+	matches := make([]*compiledContract, 0)
+	names := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		names = append(names, contract.name)
+		if name == "" {
+			if strings.TrimPrefix(contract.code, "0x") != "" {
+				matches = append(matches, contract)
+			}
+			continue
+		}
+		if contract.name == name || shortName(contract.name) == name {
+			matches = append(matches, contract)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case name == "" && len(matches) == 0:
+		return nil, errors.New("compiler output has no contract with code")
+	case name == "":
+		return nil, errors.Errorf("compiler output has more than one contract, name one of %s", strings.Join(names, ", "))
+	case len(matches) == 0:
+		return nil, errors.Errorf("compiler output has no contract %s, it has %s", name, strings.Join(names, ", "))
+	}
+	return nil, errors.Errorf("more than one contract is called %s, name it as source:name", name)
+}
+
+// linkLibraries - Puts the address of each library in code, at the positions of its link references and over the
+// placeholders solc and Truffle leave for it
+func linkLibraries(code string, contract *compiledContract, contracts []*compiledContract, libraries map[string]string) (string, error) {
+	addresses := make(map[string]string)
+	for library, address := range libraries {
+		address = strings.TrimPrefix(address, "0x")
+		if _, err := hex.DecodeString(address); err != nil || len(address) != crypto.AddressLength*2 {
+			return "", errors.Errorf("invalid address %s of library %s", address, library)
+		}
+		addresses[library] = strings.ToLower(address)
+	}
+
+	for source, references := range contract.linkReferences {
+		for library, positions := range references {
+			address, ok := addresses[source+":"+library]
+			if !ok {
+				address, ok = addresses[library]
+			}
+			if !ok {
+				return "", errors.Errorf("no address for library %s:%s", source, library)
+			}
+			for _, position := range positions {
+				if position.Length != crypto.AddressLength || (position.Start+position.Length)*2 > len(code) {
+					return "", errors.Errorf("invalid link reference of library %s:%s", source, library)
+				}
+				code = code[:position.Start*2] + address + code[(position.Start+position.Length)*2:]
+			}
+		}
+	}
+
+	// Placeholders are derived from the fully qualified library name, a library named without its source is looked up
+	// in the compiler output.
+	for library, address := range addresses {
+		qualifiedNames := []string{library}
+		if !strings.Contains(library, ":") {
+			for _, other := range contracts {
+				if shortName(other.name) == library {
+					qualifiedNames = append(qualifiedNames, other.name)
+				}
+			}
+		}
+		for _, qualifiedName := range qualifiedNames {
+			hash := crypto.NewHash([]byte(qualifiedName))
+			code = strings.Replace(code, "__$"+hex.EncodeToString(hash[:])[:34]+"$__", address, -1)
+			code = strings.Replace(code, legacyPlaceholder(qualifiedName), address, -1)
+			code = strings.Replace(code, legacyPlaceholder(shortName(qualifiedName)), address, -1)
+		}
+	}
+
+	if index := strings.Index(code, "__"); index >= 0 {
+		end := index + crypto.AddressLength*2
+		if end > len(code) {
+			end = len(code)
+		}
+		return "", errors.Errorf("library placeholder %s is not linked", code[index:end])
+	}
+	if _, err := hex.DecodeString(code); err != nil {
+		return "", errors.Wrap(err, "code is not valid hex")
+	}
+	return code, nil
+}
+
+// legacyPlaceholder - The placeholder of a library before solc 0.5, its name between underscores in 40 characters
+func legacyPlaceholder(library string) string {
+	if len(library) > 36 {
+		library = library[:36]
+	}
+	return fmt.Sprintf("__%s%s", library, strings.Repeat("_", 38-len(library)))
+}
+
+// shortName - A contract name without its source
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ":")+1:]
+}
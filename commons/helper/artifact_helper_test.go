@@ -0,0 +1,124 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+const testLibraryAddress = "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e"
+
+// testPlaceholder - The placeholder solc leaves for a library from 0.5 on
+func testPlaceholder(qualifiedName string) string {
+	hash := crypto.NewHash([]byte(qualifiedName))
+	return "__$" + hex.EncodeToString(hash[:])[:34] + "$__"
+}
+
+// TestReadArtifactCombinedJson
+func TestReadArtifactCombinedJson(t *testing.T) {
+	output := fmt.Sprintf(`{"contracts":{
+		"contracts/Token.sol:Token":{"abi":"[{\"type\":\"constructor\",\"inputs\":[]}]","bin":"6080604052%s00","metadata":"{\"version\":1}"},
+		"contracts/Math.sol:Math":{"abi":[],"bin":"6001"},
+		"contracts/IToken.sol:IToken":{"abi":[],"bin":""}
+	},"version":"0.5.17"}`, testPlaceholder("contracts/Math.sol:Math"))
+
+	artifact, err := ReadArtifact([]byte(output), "Token", map[string]string{"Math": "0x" + testLibraryAddress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Name != "contracts/Token.sol:Token" || artifact.Metadata != `{"version":1}` {
+		t.Errorf("unexpected artifact %+v", artifact)
+	}
+	if artifact.Abi != `[{"type":"constructor","inputs":[]}]` {
+		t.Errorf("unexpected abi %s", artifact.Abi)
+	}
+	if artifact.Code != "6080604052"+testLibraryAddress+"00" {
+		t.Errorf("library not linked %s", artifact.Code)
+	}
+
+	_, err = ReadArtifact([]byte(output), "Token", nil)
+	if err == nil || !strings.Contains(err.Error(), "not linked") {
+		t.Errorf("unlinked library not reported: %v", err)
+	}
+	_, err = ReadArtifact([]byte(output), "", nil)
+	if err == nil {
+		t.Error("no error for an output with more than one contract and no name")
+	}
+	_, err = ReadArtifact([]byte(output), "IToken", nil)
+	if err == nil {
+		t.Error("no error for a contract without code")
+	}
+}
+
+// TestReadArtifactStandardJson
+func TestReadArtifactStandardJson(t *testing.T) {
+	output := `{"contracts":{"Token.sol":{"Token":{
+		"abi":[{"type":"function","name":"total","inputs":[],"outputs":[{"name":"","type":"uint256"}]}],
+		"metadata":"{\"compiler\":{}}",
+		"evm":{"bytecode":{"object":"608060405273000000000000000000000000000000000000000000",
+			"linkReferences":{"Math.sol":{"Math":[{"start":6,"length":20}]}}}}}}},
+		"sources":{"Token.sol":{"id":0}}}`
+
+	artifact, err := ReadArtifact([]byte(output), "Token.sol:Token", map[string]string{"Math.sol:Math": testLibraryAddress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Code != "6080604052"+"73"+testLibraryAddress+"00" {
+		t.Errorf("library not linked %s", artifact.Code)
+	}
+	if artifact.Metadata != `{"compiler":{}}` {
+		t.Errorf("unexpected metadata %s", artifact.Metadata)
+	}
+
+	_, err = ReadArtifact([]byte(`{"contracts":{},"errors":[{"severity":"error","formattedMessage":"ParserError"}]}`), "", nil)
+	if err == nil || !strings.Contains(err.Error(), "ParserError") {
+		t.Errorf("compiler error not reported: %v", err)
+	}
+}
+
+// TestReadArtifactTruffleHardhat
+func TestReadArtifactTruffleHardhat(t *testing.T) {
+	truffle := `{"contractName":"Token","abi":[],"bytecode":"0x6080604052__Math__________________________________00","source":"contract Token {}","metadata":"{}"}`
+	artifact, err := ReadArtifact([]byte(truffle), "", map[string]string{"Math": testLibraryAddress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Name != "Token" || artifact.Source != "contract Token {}" || artifact.Abi != "[]" {
+		t.Errorf("unexpected artifact %+v", artifact)
+	}
+	if artifact.Code != "6080604052"+testLibraryAddress+"00" {
+		t.Errorf("library not linked %s", artifact.Code)
+	}
+
+	hardhat := `{"_format":"hh-sol-artifact-1","contractName":"Token","sourceName":"contracts/Token.sol","abi":[],"bytecode":"0x6080604052","linkReferences":{}}`
+	artifact, err = ReadArtifact([]byte(hardhat), "Token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Name != "contracts/Token.sol:Token" || artifact.Code != "6080604052" {
+		t.Errorf("unexpected artifact %+v", artifact)
+	}
+	_, err = ReadArtifact([]byte(hardhat), "Other", nil)
+	if err == nil {
+		t.Error("no error for a contract the artifact does not have")
+	}
+}
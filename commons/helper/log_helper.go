@@ -0,0 +1,138 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+// GetLogBloomTest - Tests whether a transaction's log bloom may contain the filter's address and topics
+func GetLogBloomTest(filter *types.LogFilter) func(bloom []byte) bool {
+	return func(bloom []byte) bool {
+		if len(bloom) != ethTypes.BloomByteLength {
+			return true
+		}
+		logBloom := ethTypes.BytesToBloom(bloom)
+		if filter.Address != "" && !ethTypes.BloomLookup(logBloom, crypto.GetAddressBytes(filter.Address)) {
+			return false
+		}
+		for _, topic := range filter.Topics {
+			if topic == "" {
+				continue
+			}
+			topicBytes, err := hex.DecodeString(topic)
+			if err != nil {
+				return false
+			}
+			if !ethTypes.BloomLookup(logBloom, crypto.BytesToHash(topicBytes)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// GetDecodedEventLog - Sets the event name and values of a log from the contract's ABI, logs of unknown events are left as is
+func GetDecodedEventLog(theABI *abi.ABI, eventLog *types.EventLog) error {
+	if len(eventLog.Topics) == 0 {
+		return nil
+	}
+	for name, event := range theABI.Events {
+		if event.Anonymous {
+			continue
+		}
+		id := event.Id()
+		if hex.EncodeToString(id[:]) != eventLog.Topics[0] {
+			continue
+		}
+
+		// Non indexed inputs are ABI encoded in the data.
+		data, err := hex.DecodeString(eventLog.Data)
+		if err != nil {
+			return err
+		}
+		nonIndexed, err := event.Inputs.NonIndexed().UnpackValues(data)
+		if err != nil {
+			return err
+		}
+
+		// Indexed inputs are topics, dynamic types are only there as their hash.
+		values := map[string]interface{}{}
+		topicIndex, valueIndex := 1, 0
+		for i, input := range event.Inputs {
+			inputName := input.Name
+			if inputName == "" {
+				inputName = fmt.Sprintf("%d", i)
+			}
+			if !input.Indexed {
+				values[inputName] = ToJsonValue(input.Type, nonIndexed[valueIndex])
+				valueIndex++
+				continue
+			}
+			if topicIndex >= len(eventLog.Topics) {
+				return errors.Errorf("event %s is missing the topic of its indexed input %s", name, inputName)
+			}
+			topic := eventLog.Topics[topicIndex]
+			topicIndex++
+			switch input.Type.T {
+			case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+				values[inputName] = topic
+			default:
+				topicBytes, err := hex.DecodeString(topic)
+				if err != nil {
+					return err
+				}
+				value, err := abi.Arguments{{Type: input.Type}}.UnpackValues(topicBytes)
+				if err != nil {
+					return err
+				}
+				values[inputName] = ToJsonValue(input.Type, value[0])
+			}
+		}
+		eventLog.Event = name
+		eventLog.Values = values
+		return nil
+	}
+	return nil
+}
+
+// ToEventLogs - Converts the logs a transaction emitted in the EVM, topics and data hex encoded
+func ToEventLogs(logs []*ethTypes.Log, transactionHash string, time int64) []*types.EventLog {
+	eventLogs := make([]*types.EventLog, 0, len(logs))
+	for i, log := range logs {
+		topics := make([]string, 0, len(log.Topics))
+		for _, topic := range log.Topics {
+			topics = append(topics, hex.EncodeToString(topic[:]))
+		}
+		eventLogs = append(eventLogs, &types.EventLog{
+			ContractAddress: hex.EncodeToString(log.Address[:]),
+			TransactionHash: transactionHash,
+			Index:           i,
+			Time:            time,
+			Topics:          topics,
+			Data:            hex.EncodeToString(log.Data),
+		})
+	}
+	return eventLogs
+}
@@ -0,0 +1,80 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package helper
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+)
+
+const testEventAbi = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"note","type":"string"}],"name":"Transfer","type":"event"}]`
+
+// TestGetDecodedEventLog
+func TestGetDecodedEventLog(t *testing.T) {
+	theABI, err := abi.JSON(strings.NewReader(testEventAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := theABI.Events["Transfer"]
+	id := event.Id()
+	from := crypto.GetAddressBytes("3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c")
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(7), "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTopic := crypto.BytesToHash(from[:])
+	eventLog := &types.EventLog{
+		ContractAddress: "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e",
+		Topics:          []string{hex.EncodeToString(id[:]), hex.EncodeToString(fromTopic[:])},
+		Data:            hex.EncodeToString(data),
+	}
+	err = GetDecodedEventLog(&theABI, eventLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eventLog.Event != "Transfer" {
+		t.Fatalf("unexpected event %s", eventLog.Event)
+	}
+	if eventLog.Values["from"] != hex.EncodeToString(from[:]) {
+		t.Errorf("unexpected from %v", eventLog.Values["from"])
+	}
+	if eventLog.Values["value"].(*big.Int).Int64() != 7 || eventLog.Values["note"] != "hi" {
+		t.Errorf("unexpected values %v", eventLog.Values)
+	}
+}
+
+// TestGetLogBloomTest
+func TestGetLogBloomTest(t *testing.T) {
+	contract := crypto.GetAddressBytes("95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e")
+	topic := crypto.NewHash([]byte("Transfer(address,uint256,string)"))
+	bloom := ethTypes.BytesToBloom(ethTypes.LogsBloom([]*ethTypes.Log{{Address: contract, Topics: []crypto.HashBytes{topic}}}).Bytes())
+	mayContain := GetLogBloomTest(&types.LogFilter{Address: hex.EncodeToString(contract[:]), Topics: []string{hex.EncodeToString(topic[:])}})
+	if !mayContain(bloom.Bytes()) {
+		t.Error("bloom test rejecting a matching bloom")
+	}
+	other := GetLogBloomTest(&types.LogFilter{Address: "e6098cc0d5c20c6c31c4d69f0201a02975264e94"})
+	if other(bloom.Bytes()) {
+		t.Error("bloom test accepting another address")
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/pkg/errors"
+)
+
+const migrationProgressKey = "schema-migration-progress"
+
+// migrationProgress
+type migrationProgress struct {
+	Version int       `json:"version"`
+	Cursor  string    `json:"cursor"`
+	Updated time.Time `json:"updated"`
+}
+
+// MigrationBatch - Lets a migration read a snapshot of the database and write its changes in as many badger
+// transactions as they need. The write transaction is committed whenever badger reports it full
+// (badger.ErrTxnTooBig), and the migration's last checkpoint is committed along with it so an interrupted
+// migration resumes from there on the next boot. A dry run discards each full transaction instead.
+type MigrationBatch struct {
+	db      *badger.DB
+	version int
+	dryRun  bool
+	reader  *badger.Txn
+	txn     *badger.Txn
+	resume  string
+	cursor  string
+	commits int
+}
+
+// newMigrationBatch
+func newMigrationBatch(db *badger.DB, version int, dryRun bool) (*MigrationBatch, error) {
+	reader := db.NewTransaction(false)
+	progress, err := getMigrationProgress(reader)
+	if err != nil {
+		reader.Discard()
+		return nil, err
+	}
+	batch := &MigrationBatch{db: db, version: version, dryRun: dryRun, reader: reader, txn: db.NewTransaction(true)}
+	if progress != nil && progress.Version == version {
+		batch.resume = progress.Cursor
+		batch.cursor = progress.Cursor
+	}
+	return batch, nil
+}
+
+// Reader - Returns the snapshot the migration reads from, it does not see the migration's own writes
+func (this *MigrationBatch) Reader() *badger.Txn {
+	return this.reader
+}
+
+// Resume - Returns the last cursor an interrupted run of this migration committed, empty on a fresh run
+func (this *MigrationBatch) Resume() string {
+	return this.resume
+}
+
+// Write - Runs write against the current transaction. If the transaction is full it is committed and write runs
+// again against a new one, so write must be safe to repeat.
+func (this *MigrationBatch) Write(write func(txn *badger.Txn) error) error {
+	err := write(this.txn)
+	if err != badger.ErrTxnTooBig {
+		return err
+	}
+	err = this.flush()
+	if err != nil {
+		return err
+	}
+	err = write(this.txn)
+	if err == badger.ErrTxnTooBig {
+		return errors.Wrapf(err, "migration write does not fit in an empty transaction [version=%d]", this.version)
+	}
+	return err
+}
+
+// Checkpoint - Records that everything up to and including cursor has been written. Cursors must increase.
+func (this *MigrationBatch) Checkpoint(cursor string) {
+	this.cursor = cursor
+}
+
+// flush - Commits the current transaction and starts the next one with the progress made so far. The progress is
+// written after the data it covers, so a crash can only lose progress and never claim writes that were lost.
+func (this *MigrationBatch) flush() error {
+	if this.dryRun {
+		this.txn.Discard()
+	} else {
+		err := this.txn.Commit(nil)
+		if err != nil {
+			return errors.Wrapf(err, "unable to commit migration batch [version=%d]", this.version)
+		}
+	}
+	this.commits++
+	this.txn = this.db.NewTransaction(true)
+	if this.cursor == "" {
+		return nil
+	}
+	return setMigrationProgress(this.txn, this.version, this.cursor)
+}
+
+// finish - Writes the schema version with the migration's last batch and clears its progress
+func (this *MigrationBatch) finish() error {
+	err := this.Write(func(txn *badger.Txn) error {
+		err := setSchemaVersion(txn, this.version)
+		if err != nil {
+			return err
+		}
+		return txn.Delete([]byte(migrationProgressKey))
+	})
+	if err != nil {
+		return err
+	}
+	if this.dryRun {
+		return nil
+	}
+	err = this.txn.Commit(nil)
+	if err != nil {
+		return errors.Wrapf(err, "unable to commit migration to schema version %d", this.version)
+	}
+	return nil
+}
+
+// discard
+func (this *MigrationBatch) discard() {
+	this.txn.Discard()
+	this.reader.Discard()
+}
+
+// getMigrationProgress - Returns the progress of an interrupted migration, nil if none is recorded
+func getMigrationProgress(txn *badger.Txn) (*migrationProgress, error) {
+	item, err := txn.Get([]byte(migrationProgressKey))
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	progress := &migrationProgress{}
+	err = json.Unmarshal(value, progress)
+	if err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// setMigrationProgress
+func setMigrationProgress(txn *badger.Txn, version int, cursor string) error {
+	bytes, err := json.Marshal(migrationProgress{Version: version, Cursor: cursor, Updated: time.Now()})
+	if err != nil {
+		return err
+	}
+	return txn.Set([]byte(migrationProgressKey), bytes)
+}
+
+// timeHashCursor - Orders transactions the way types.SortByTimeHash sorts them ascending
+func timeHashCursor(transaction *types.Transaction) string {
+	return fmt.Sprintf("%020d-%s", transaction.Time, transaction.Hash)
+}
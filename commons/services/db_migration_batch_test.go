@@ -0,0 +1,182 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+)
+
+// openTestDb - Opens a badger DB with small tables so a few thousand writes fill a transaction
+func openTestDb(t *testing.T) (*DbService, func()) {
+	dir, err := ioutil.TempDir("", "disgo-migration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	opts.MaxTableSize = 1 << 20
+	db, err := badger.Open(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &DbService{db: db}, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// testKeyMigration - Writes count keys in order, failing once it reaches failAt
+func testKeyMigration(version int, count int, failAt int, written map[string]bool) *Migration {
+	return &Migration{Version: version, Description: "test", Migrate: func(batch *MigrationBatch) error {
+		for i := 0; i < count; i++ {
+			key := fmt.Sprintf("key-%08d", i)
+			if key <= batch.Resume() {
+				continue
+			}
+			if i == failAt {
+				return errors.New("interrupted")
+			}
+			err := batch.Write(func(txn *badger.Txn) error {
+				return txn.Set([]byte(key), []byte(key))
+			})
+			if err != nil {
+				return err
+			}
+			written[key] = true
+			batch.Checkpoint(key)
+		}
+		return nil
+	}}
+}
+
+// countKeys
+func countKeys(t *testing.T, db *badger.DB) int {
+	count := 0
+	err := db.View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+		prefix := []byte("key-")
+		for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// TestMigrationBatchCommitsWhenFull
+func TestMigrationBatchCommitsWhenFull(t *testing.T) {
+	dbService, closeDb := openTestDb(t)
+	defer closeDb()
+
+	count := int(3*dbService.db.MaxBatchCount()) + 10
+	err := dbService.runMigration(testKeyMigration(2, count, -1, map[string]bool{}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written := countKeys(t, dbService.db); written != count {
+		t.Errorf("migration committed %d keys, expected %d", written, count)
+	}
+	err = dbService.db.View(func(txn *badger.Txn) error {
+		version, err := GetSchemaVersion(txn)
+		if err != nil {
+			return err
+		}
+		if version != 2 {
+			t.Errorf("schema version is %d after the migration, expected 2", version)
+		}
+		progress, err := getMigrationProgress(txn)
+		if err != nil {
+			return err
+		}
+		if progress != nil {
+			t.Errorf("migration progress %+v left behind after the migration", progress)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestMigrationBatchResumes
+func TestMigrationBatchResumes(t *testing.T) {
+	dbService, closeDb := openTestDb(t)
+	defer closeDb()
+
+	count := int(3*dbService.db.MaxBatchCount()) + 10
+	failAt := int(2*dbService.db.MaxBatchCount()) + 5
+	err := dbService.runMigration(testKeyMigration(2, count, failAt, map[string]bool{}), false)
+	if err == nil {
+		t.Fatal("interrupted migration returning no error")
+	}
+	var progress *migrationProgress
+	err = dbService.db.View(func(txn *badger.Txn) error {
+		progress, err = getMigrationProgress(txn)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress == nil || progress.Version != 2 || progress.Cursor == "" {
+		t.Fatalf("interrupted migration recorded progress %+v", progress)
+	}
+	committed := countKeys(t, dbService.db)
+	if committed == 0 || committed >= failAt {
+		t.Fatalf("interrupted migration committed %d keys, expected some of the %d it wrote", committed, failAt)
+	}
+
+	written := map[string]bool{}
+	err = dbService.runMigration(testKeyMigration(2, count, -1, written), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written[fmt.Sprintf("key-%08d", 0)] {
+		t.Error("resumed migration starting over")
+	}
+	if written[progress.Cursor] {
+		t.Errorf("resumed migration rewriting its checkpoint %s", progress.Cursor)
+	}
+	if total := countKeys(t, dbService.db); total != count {
+		t.Errorf("resumed migration left %d keys, expected %d", total, count)
+	}
+}
+
+// TestMigrationBatchDryRun
+func TestMigrationBatchDryRun(t *testing.T) {
+	dbService, closeDb := openTestDb(t)
+	defer closeDb()
+
+	count := int(2*dbService.db.MaxBatchCount()) + 10
+	err := dbService.runMigration(testKeyMigration(2, count, -1, map[string]bool{}), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written := countKeys(t, dbService.db); written != 0 {
+		t.Errorf("dry run committed %d keys", written)
+	}
+}
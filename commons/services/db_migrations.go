@@ -0,0 +1,398 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/helper"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/trie"
+	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterMigration(&Migration{
+		Version:     2,
+		Description: "backfill account balance deltas",
+		Migrate:     backfillBalanceDeltas,
+	})
+	RegisterMigration(&Migration{
+		Version:     3,
+		Description: "re-encode transactions, accounts, receipts and gossips as binary records",
+		Migrate:     encodeBinaryRecords,
+	})
+	RegisterMigration(&Migration{
+		Version:     4,
+		Description: "backfill contract event logs from the EVM receipts",
+		Migrate:     backfillEventLogs,
+	})
+	RegisterMigration(&Migration{
+		Version:     5,
+		Description: "merge the per contract state tries into the world state trie",
+		Migrate:     mergeContractStates,
+	})
+	RegisterMigration(&Migration{
+		Version:     6,
+		Description: "backfill the contract registry from the deploy transactions",
+		Migrate:     backfillContracts,
+	})
+}
+
+// backfillBalanceDeltas - Replays every executed transfer in time order to rebuild each account's balance history.
+// A resumed run replays from the start to rebuild the balances but only writes the deltas after its checkpoint.
+func backfillBalanceDeltas(batch *MigrationBatch) error {
+	genesisTransaction, err := types.ToTransactionFromJson([]byte(types.GetConfig().GenesisTransaction))
+	if err != nil {
+		return err
+	}
+	transactions, err := types.ToTransactions(batch.Reader())
+	if err != nil {
+		return err
+	}
+	types.SortByTimeHash(transactions, true)
+
+	balances := map[string]int64{}
+	sequences := map[string]int64{}
+	deltas := make([]*types.BalanceDelta, 0, 2)
+	apply := func(address string, transaction *types.Transaction, delta int64) {
+		balances[address] += delta
+		sequences[address]++
+		balanceDelta := types.NewBalanceDelta(address, transaction.Hash, transaction.Time, delta, balances[address])
+		balanceDelta.Sequence = sequences[address]
+		deltas = append(deltas, balanceDelta)
+	}
+	for _, transaction := range transactions {
+		deltas = deltas[:0]
+		if transaction.Hash == genesisTransaction.Hash {
+			apply(transaction.To, transaction, transaction.Value)
+		} else if transaction.Type == types.TypeTransferTokens && transaction.Receipt.Status == types.StatusOk && transaction.Value != 0 && transaction.From != transaction.To {
+			apply(transaction.From, transaction, -transaction.Value)
+			apply(transaction.To, transaction, transaction.Value)
+		}
+		cursor := timeHashCursor(transaction)
+		if len(deltas) == 0 || cursor <= batch.Resume() {
+			continue
+		}
+		err = batch.Write(func(txn *badger.Txn) error {
+			for _, balanceDelta := range deltas {
+				err := balanceDelta.Persist(txn)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		batch.Checkpoint(cursor)
+	}
+	return nil
+}
+
+// encodeBinaryRecords - Rewrites every JSON record in the binary encoding, keeping the expiry of receipts
+func encodeBinaryRecords(batch *MigrationBatch) error {
+	encoders := map[string]func(value []byte) ([]byte, error){
+		"table-transaction-": func(value []byte) ([]byte, error) {
+			transaction, err := types.ToTransactionFromJson(value)
+			if err != nil {
+				return nil, err
+			}
+			return transaction.MarshalBinary()
+		},
+		"table-account-": func(value []byte) ([]byte, error) {
+			account, err := types.ToAccountFromJson(value)
+			if err != nil {
+				return nil, err
+			}
+			return account.MarshalBinary()
+		},
+		"table-receipt-": func(value []byte) ([]byte, error) {
+			receipt, err := types.ToReceiptFromJson(value)
+			if err != nil {
+				return nil, err
+			}
+			return receipt.MarshalBinary()
+		},
+		"table-gossip-": func(value []byte) ([]byte, error) {
+			gossip, err := types.ToGossipFromJson(value)
+			if err != nil {
+				return nil, err
+			}
+			return gossip.MarshalBinary()
+		},
+	}
+
+	// Keys are rewritten in key order so the last key rewritten is the checkpoint.
+	prefixes := make([]string, 0, len(encoders))
+	for prefix := range encoders {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		err := encodeRecords(batch, prefix, encoders[prefix])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeRecords
+func encodeRecords(batch *MigrationBatch, prefix string, encode func(value []byte) ([]byte, error)) error {
+	seek := []byte(prefix)
+	if batch.Resume() > prefix {
+		seek = []byte(batch.Resume())
+	}
+	iterator := batch.Reader().NewIterator(badger.DefaultIteratorOptions)
+	defer iterator.Close()
+	for iterator.Seek(seek); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		item := iterator.Item()
+		key := item.KeyCopy(nil)
+		if string(key) == batch.Resume() {
+			continue
+		}
+		value, err := item.Value()
+		if err != nil {
+			return err
+		}
+		if types.IsBinaryRecord(value) {
+			continue
+		}
+		bytes, err := encode(value)
+		if err != nil {
+			return errors.Wrapf(err, "unable to re-encode %s", string(key))
+		}
+		entry := &badger.Entry{Key: key, Value: bytes, ExpiresAt: item.ExpiresAt()}
+		err = batch.Write(func(txn *badger.Txn) error {
+			return txn.SetEntry(entry)
+		})
+		if err != nil {
+			return err
+		}
+		batch.Checkpoint(string(key))
+	}
+	return nil
+}
+
+// backfillEventLogs - Persists and indexes the logs of contract transactions executed before event logs were persisted.
+// The logs are read back from the EVM receipts (receipts-<hash>), receipts already persisted are left without logs.
+func backfillEventLogs(batch *MigrationBatch) error {
+	transactions, err := types.ToTransactions(batch.Reader())
+	if err != nil {
+		return err
+	}
+	types.SortByTimeHash(transactions, true)
+	for _, transaction := range transactions {
+		if transaction.Type != types.TypeDeploySmartContract && transaction.Type != types.TypeExecuteSmartContract {
+			continue
+		}
+		cursor := timeHashCursor(transaction)
+		if cursor <= batch.Resume() {
+			continue
+		}
+		hashBytes, err := hex.DecodeString(transaction.Hash)
+		if err != nil {
+			continue
+		}
+		item, err := batch.Reader().Get(append([]byte("receipts-"), hashBytes...))
+		if err == badger.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		value, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var receipt ethTypes.ReceiptForStorage
+		err = rlp.DecodeBytes(value, &receipt)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to decode EVM receipt [hash=%s]: %v", transaction.Hash, err))
+			continue
+		}
+		eventLogs := helper.ToEventLogs(receipt.Logs, transaction.Hash, transaction.Time)
+		if len(eventLogs) == 0 {
+			continue
+		}
+		err = batch.Write(func(txn *badger.Txn) error {
+			for _, eventLog := range eventLogs {
+				err := eventLog.Persist(txn)
+				if err != nil {
+					return err
+				}
+			}
+			logBloom := &types.LogBloom{TransactionHash: transaction.Hash, Bloom: receipt.Bloom.Bytes()}
+			return logBloom.Persist(txn)
+		})
+		if err != nil {
+			return err
+		}
+		batch.Checkpoint(cursor)
+	}
+	return nil
+}
+
+// mergeContractStates - Copies every contract account, with its code hash and storage root, out of the contract's own
+// state trie (AccountState-<address>) into the world state trie. Trie nodes and code are stored by hash so only the
+// account entries are rewritten. Accounts a contract created in its own trie were never reachable and are dropped.
+// Copying an account again yields the same world state, so an interrupted run simply starts over.
+func mergeContractStates(batch *MigrationBatch) error {
+	trieDb := trie.NewDatabase(&migrationDatabase{batch: batch})
+	var root crypto.HashBytes
+	item, err := batch.Reader().Get([]byte("WorldState"))
+	if err == nil {
+		value, err := item.Value()
+		if err != nil {
+			return err
+		}
+		root = crypto.BytesToHash(value)
+	} else if err != badger.ErrKeyNotFound {
+		return err
+	}
+	worldState, err := trie.NewSecure(root, trieDb, 0)
+	if err != nil {
+		return err
+	}
+
+	prefix := []byte("AccountState-")
+	keys := make([][]byte, 0)
+	iterator := batch.Reader().NewIterator(badger.DefaultIteratorOptions)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		item := iterator.Item()
+		value, err := item.Value()
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+		key := item.KeyCopy(nil)
+		keys = append(keys, key)
+		address := crypto.GetAddressBytes(string(key[len(prefix):]))
+		contractState, err := trie.NewSecure(crypto.BytesToHash(value), trieDb, 0)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to open contract state [key=%s]: %v", string(key), err))
+			continue
+		}
+		account, err := contractState.TryGet(address[:])
+		if err != nil || len(account) == 0 {
+			utils.Warn(fmt.Sprintf("contract account missing from its state [key=%s]", string(key)))
+			continue
+		}
+		err = worldState.TryUpdate(address[:], account)
+		if err != nil {
+			iterator.Close()
+			return err
+		}
+	}
+	iterator.Close()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	root, err = worldState.Commit(nil)
+	if err != nil {
+		return err
+	}
+	err = trieDb.Commit(root, false)
+	if err != nil {
+		return err
+	}
+	err = batch.Write(func(txn *badger.Txn) error {
+		return txn.Set([]byte("WorldState"), root.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err = batch.Write(func(txn *badger.Txn) error {
+			return txn.Delete(key)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	utils.Info(fmt.Sprintf("merged %d contract states into the world state [root=%s]", len(keys), crypto.Encode(root[:])))
+	return nil
+}
+
+// backfillContracts - Registers every contract deployed before the registry existed. The code hash is read from the
+// contract's account in the world state trie, source and metadata were never published for these deploys.
+func backfillContracts(batch *MigrationBatch) error {
+	var worldState *trie.SecureTrie
+	item, err := batch.Reader().Get([]byte("WorldState"))
+	if err == nil {
+		value, err := item.Value()
+		if err != nil {
+			return err
+		}
+		worldState, err = trie.NewSecure(crypto.BytesToHash(value), trie.NewDatabase(&migrationDatabase{batch: batch}), 0)
+		if err != nil {
+			return err
+		}
+	} else if err != badger.ErrKeyNotFound {
+		return err
+	}
+
+	transactions, err := types.ToTransactionsByType(batch.Reader(), types.TypeDeploySmartContract)
+	if err != nil {
+		return err
+	}
+	types.SortByTimeHash(transactions, true)
+	count := 0
+	for _, transaction := range transactions {
+		if transaction.Receipt.Status != types.StatusOk || transaction.Receipt.ContractAddress == "" {
+			continue
+		}
+		cursor := timeHashCursor(transaction)
+		if cursor <= batch.Resume() {
+			continue
+		}
+		address := transaction.Receipt.ContractAddress
+		codeHash := ""
+		if worldState != nil {
+			addressBytes := crypto.GetAddressBytes(address)
+			enc, err := worldState.TryGet(addressBytes[:])
+			if err == nil && len(enc) > 0 {
+				var account types.Account
+				if err := rlp.DecodeBytes(enc, &account); err == nil {
+					codeHash = hex.EncodeToString(account.CodeHash)
+				}
+			}
+		}
+
+		// The transients hold the decoded ABI, the registry keeps it encoded as it was persisted.
+		transaction.Abi = hex.EncodeToString([]byte(transaction.Abi))
+		contract := types.NewContract(address, transaction, codeHash)
+		err = batch.Write(contract.Persist)
+		if err != nil {
+			return err
+		}
+		batch.Checkpoint(cursor)
+		count++
+	}
+	utils.Info(fmt.Sprintf("registered %d contracts", count))
+	return nil
+}
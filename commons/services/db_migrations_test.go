@@ -0,0 +1,150 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/trie"
+)
+
+// writeTrie - Writes the entries to a new trie through the batch and returns its root
+func writeTrie(t *testing.T, batch *MigrationBatch, entries map[string][]byte) crypto.HashBytes {
+	trieDb := trie.NewDatabase(&migrationDatabase{batch: batch})
+	secureTrie, err := trie.NewSecure(crypto.HashBytes{}, trieDb, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for address, account := range entries {
+		addressBytes := crypto.GetAddressBytes(address)
+		err = secureTrie.TryUpdate(addressBytes[:], account)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err := secureTrie.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = trieDb.Commit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// TestMergeContractStates
+func TestMergeContractStates(t *testing.T) {
+	dbService, closeDb := openTestDb(t)
+	defer closeDb()
+
+	contract := "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e"
+	other := "c296220327589dc04e6ee01bf16563f0f53895bb"
+	created := "d70613f93152c84050e7826c4e2b0cc02c1c3b99"
+	existing := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	missing := "0e4c3b56bd1a0f2f8e4e27e9c3bcf8be1d77f103"
+
+	// Each contract has its own state trie, an account it created is only in that trie.
+	err := dbService.runMigration(&Migration{Version: 2, Description: "test", Migrate: func(batch *MigrationBatch) error {
+		states := map[string]crypto.HashBytes{
+			contract: writeTrie(t, batch, map[string][]byte{contract: []byte("contract"), created: []byte("created")}),
+			other:    writeTrie(t, batch, map[string][]byte{other: []byte("other")}),
+			missing:  writeTrie(t, batch, map[string][]byte{created: []byte("created")}),
+		}
+		worldState := writeTrie(t, batch, map[string][]byte{existing: []byte("existing")})
+		return batch.Write(func(txn *badger.Txn) error {
+			for address, root := range states {
+				err := txn.Set([]byte("AccountState-"+address), root.Bytes())
+				if err != nil {
+					return err
+				}
+			}
+			return txn.Set([]byte("WorldState"), worldState.Bytes())
+		})
+	}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merge := &Migration{Version: 3, Description: "test", Migrate: mergeContractStates}
+	err = dbService.runMigration(merge, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := newMigrationBatch(dbService.db, 4, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer batch.discard()
+	item, err := batch.Reader().Get([]byte("WorldState"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := item.ValueCopy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worldState, err := trie.NewSecure(crypto.BytesToHash(root), trie.NewDatabase(&migrationDatabase{batch: batch}), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]byte{contract: []byte("contract"), other: []byte("other"), existing: []byte("existing"), created: nil, missing: nil}
+	for address, account := range expected {
+		addressBytes := crypto.GetAddressBytes(address)
+		value, err := worldState.TryGet(addressBytes[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(value, account) {
+			t.Errorf("world state holds %q for %s, expected %q", value, address, account)
+		}
+	}
+	prefix := []byte("AccountState-")
+	iterator := batch.Reader().NewIterator(badger.DefaultIteratorOptions)
+	iterator.Seek(prefix)
+	if iterator.ValidForPrefix(prefix) {
+		t.Errorf("contract state %s left behind", iterator.Item().Key())
+	}
+	iterator.Close()
+
+	// Nothing is left to merge the second time.
+	err = dbService.runMigration(merge, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dbService.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte("WorldState"))
+		if err != nil {
+			return err
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(value, root) {
+			t.Errorf("merging again moved the world state to %x, expected %x", value, root)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// SchemaVersion - The version of the key layout and record encodings this node reads and writes.
+//
+// Version 1 is the layout written by every release prior to schema versioning:
+//
+//	table-transaction-<hash>                    Transaction (JSON)
+//	key-transaction-type-<type>-<time>-<hash>   -> table-transaction-<hash>
+//	key-transaction-time-<time>-<hash>          -> table-transaction-<hash>
+//	key-transaction-from-<address>-<time>       -> table-transaction-<hash>
+//	key-transaction-to-<address>-<time>         -> table-transaction-<hash>
+//	table-account-<address>                     Account (JSON)
+//	key-account-name-<name>                     -> table-account-<address>
+//	table-receipt-<hash>                        Receipt (JSON)
+//	table-gossip-<hash>                         Gossip (JSON)
+//	Page-<number>                               Page (JSON)
+//	AccountState-<address>                      root hash of the contract's state trie
+//	receipts-<hash>                             EVM receipt (RLP)
+//
+// Version 2 adds account balance history:
+//
+//	table-balance-delta-<address>-<time>-<sequence>-<hash>       BalanceDelta (JSON), time and sequence zero padded to 20 digits
+//	key-balance-delta-transaction-<hash>-<address>               -> table-balance-delta-<address>-<time>-<sequence>-<hash>
+//	key-balance-delta-sequence-<address>                         last sequence given to the account's deltas
+//
+// Version 3 stores transactions, accounts, receipts and gossips in the binary record encoding (see
+// types.RecordEncodingVersion) instead of JSON. The keys are unchanged.
+//
+// Version 4 adds contract event logs, backfilled from the EVM receipts:
+//
+//	table-log-<time>-<hash>-<index>                      EventLog (binary), time zero padded to 20 digits, index to 4
+//	key-log-address-<address>-<time>-<hash>-<index>      -> table-log-<time>-<hash>-<index>
+//	key-log-topic-<topic>-<time>-<hash>-<index>          -> table-log-<time>-<hash>-<index>
+//	table-bloom-<hash>                                   bloom filter of the transaction's log addresses and topics
+//
+// Version 5 replaces the per contract state tries with a single world state trie shared by all contracts:
+//
+//	WorldState                                           root hash of the world state trie, replaces AccountState-<address>
+//	PreState-<hash>                                      root hash of the world state the transaction ran against, not backfilled
+//
+// Version 6 adds the contract registry, backfilled from the deploy transactions:
+//
+//	table-contract-<address>                             Contract (binary)
+//	key-contract-time-<time>-<address>                   -> table-contract-<address>, time zero padded to 20 digits
+//	key-contract-deployer-<deployer>-<time>-<address>    -> table-contract-<address>
+//
+// table-journal-<hash> holds gossips in flight on a delegate. It is drained on every boot so it is not versioned.
+//
+// Any change to one of these keys or encodings must bump SchemaVersion and register a Migration.
+const SchemaVersion = 6
+
+const schemaVersionKey = "schema-version"
+
+// Errors
+var (
+	ErrUnknownSchemaVersion = errors.New("database schema version is newer than this node supports")
+	ErrMissingMigration     = errors.New("no migration registered for database schema version")
+)
+
+// Migration - Upgrades the database from schema Version-1 to Version. Migrate reads from batch.Reader() and writes
+// through batch.Write, every write must be safe to repeat.
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(batch *MigrationBatch) error
+}
+
+var migrations = map[int]*Migration{}
+var migrationsMutex sync.Mutex
+
+// RegisterMigration - Adds a migration to the registry, migrations run in version order on boot
+func RegisterMigration(migration *Migration) {
+	migrationsMutex.Lock()
+	defer migrationsMutex.Unlock()
+	if _, ok := migrations[migration.Version]; ok {
+		panic(fmt.Sprintf("duplicate migration for schema version %d", migration.Version))
+	}
+	migrations[migration.Version] = migration
+}
+
+// schemaRecord
+type schemaRecord struct {
+	Version int       `json:"version"`
+	Updated time.Time `json:"updated"`
+}
+
+// GetSchemaVersion - Returns the schema version recorded in the database, 0 if none is recorded
+func GetSchemaVersion(txn *badger.Txn) (int, error) {
+	item, err := txn.Get([]byte(schemaVersionKey))
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return 0, err
+	}
+	var record schemaRecord
+	err = json.Unmarshal(value, &record)
+	if err != nil {
+		return 0, err
+	}
+	return record.Version, nil
+}
+
+// setSchemaVersion
+func setSchemaVersion(txn *badger.Txn, version int) error {
+	bytes, err := json.Marshal(schemaRecord{Version: version, Updated: time.Now()})
+	if err != nil {
+		return err
+	}
+	return txn.Set([]byte(schemaVersionKey), bytes)
+}
+
+// isEmpty
+func isEmpty(txn *badger.Txn) bool {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	iterator.Rewind()
+	return !iterator.Valid()
+}
+
+// checkSchemaVersion
+func checkSchemaVersion(version int) error {
+	if version > SchemaVersion {
+		return errors.Wrapf(ErrUnknownSchemaVersion, "[version=%d, supported=%d]", version, SchemaVersion)
+	}
+	return nil
+}
+
+// pendingMigrations - Returns the migrations needed to move from one version to another, in order
+func pendingMigrations(registry map[int]*Migration, from, to int) ([]*Migration, error) {
+	pending := make([]*Migration, 0)
+	for version := from + 1; version <= to; version++ {
+		migration, ok := registry[version]
+		if !ok {
+			return nil, errors.Wrapf(ErrMissingMigration, "[version=%d]", version)
+		}
+		pending = append(pending, migration)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+	return pending, nil
+}
+
+// migrate - Upgrades the database step by step to SchemaVersion. Each step writes through a MigrationBatch that
+// commits whenever badger's transaction is full, and commits its version record with its last batch. An interrupted
+// step resumes from its last checkpoint. A dry run discards every batch, so each step only sees the data committed
+// before the dry run started.
+func (this *DbService) migrate(dryRun bool) error {
+	txn := this.db.NewTransaction(true)
+	defer func() { txn.Discard() }()
+	version, err := GetSchemaVersion(txn)
+	if err != nil {
+		return err
+	}
+
+	// Unversioned?
+	if version == 0 {
+		if isEmpty(txn) {
+			utils.Info(fmt.Sprintf("new DB, using schema version %d", SchemaVersion))
+			version = SchemaVersion
+		} else {
+			utils.Info("unversioned DB, assuming schema version 1")
+			version = 1
+		}
+		if !dryRun {
+			err = setSchemaVersion(txn, version)
+			if err != nil {
+				return err
+			}
+			err = txn.Commit(nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = checkSchemaVersion(version)
+	if err != nil {
+		return err
+	}
+	migrationsMutex.Lock()
+	pending, err := pendingMigrations(migrations, version, SchemaVersion)
+	migrationsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		utils.Info(fmt.Sprintf("DB schema version %d is up to date", version))
+		return nil
+	}
+
+	for _, migration := range pending {
+		err = this.runMigration(migration, dryRun)
+		if err != nil {
+			return err
+		}
+	}
+	if dryRun {
+		utils.Info(fmt.Sprintf("dry run migrated DB schema from version %d to %d, no changes were committed", version, SchemaVersion))
+	}
+	return nil
+}
+
+// runMigration
+func (this *DbService) runMigration(migration *Migration, dryRun bool) error {
+	batch, err := newMigrationBatch(this.db, migration.Version, dryRun)
+	if err != nil {
+		return err
+	}
+	defer batch.discard()
+	if batch.Resume() != "" {
+		utils.Info(fmt.Sprintf("resuming migration to schema version %d [dryRun=%t, cursor=%s]: %s", migration.Version, dryRun, batch.Resume(), migration.Description))
+	} else {
+		utils.Info(fmt.Sprintf("migrating DB schema to version %d [dryRun=%t]: %s", migration.Version, dryRun, migration.Description))
+	}
+	err = migration.Migrate(batch)
+	if err != nil {
+		return errors.Wrapf(err, "migration to schema version %d failed", migration.Version)
+	}
+	err = batch.finish()
+	if err != nil {
+		return err
+	}
+	utils.Info(fmt.Sprintf("migrated DB schema to version %d in %d batches [dryRun=%t]", migration.Version, batch.commits+1, dryRun))
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func testMigration(version int) *Migration {
+	return &Migration{Version: version, Description: "test", Migrate: func(batch *MigrationBatch) error { return nil }}
+}
+
+// TestPendingMigrations
+func TestPendingMigrations(t *testing.T) {
+	registry := map[int]*Migration{3: testMigration(3), 2: testMigration(2), 4: testMigration(4)}
+	pending, err := pendingMigrations(registry, 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("pendingMigrations returning %d migrations, expected 3", len(pending))
+	}
+	for i, migration := range pending {
+		if migration.Version != i+2 {
+			t.Errorf("pendingMigrations returning version %d at index %d", migration.Version, i)
+		}
+	}
+
+	pending, err = pendingMigrations(registry, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pendingMigrations returning %d migrations for an up to date DB", len(pending))
+	}
+}
+
+// TestPendingMigrationsMissingStep
+func TestPendingMigrationsMissingStep(t *testing.T) {
+	registry := map[int]*Migration{2: testMigration(2), 4: testMigration(4)}
+	_, err := pendingMigrations(registry, 1, 4)
+	if errors.Cause(err) != ErrMissingMigration {
+		t.Errorf("pendingMigrations returning %v, expected ErrMissingMigration", err)
+	}
+}
+
+// TestCheckSchemaVersion
+func TestCheckSchemaVersion(t *testing.T) {
+	if err := checkSchemaVersion(SchemaVersion); err != nil {
+		t.Error(err)
+	}
+	if errors.Cause(checkSchemaVersion(SchemaVersion+1)) != ErrUnknownSchemaVersion {
+		t.Error("checkSchemaVersion accepting a future schema version")
+	}
+}
@@ -25,7 +25,6 @@ import (
 	badgerOptions "github.com/dgraph-io/badger/options"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
 )
 
 var dbServiceInstance *DbService
@@ -34,7 +33,7 @@ var dbServiceOnce sync.Once
 // GetDbService
 func GetDbService() *DbService {
 	dbServiceOnce.Do(func() {
-		dbServiceInstance = &DbService{running: false, kmutex: utils.NewKmutex(), cache: cache.New(types.CacheTTL, types.CacheTTL*2)}
+		dbServiceInstance = &DbService{running: false, kmutex: utils.NewKmutex(), cache: types.NewCache()}
 		dbServiceInstance.openDb()
 	})
 	return dbServiceInstance
@@ -45,7 +44,7 @@ type DbService struct {
 	running bool
 	db      *badger.DB
 	kmutex  *utils.Kmutex
-	cache   *cache.Cache
+	cache   *types.Cache
 }
 
 // IsRunning
@@ -84,10 +83,23 @@ func (this *DbService) openDb() {
 		utils.Fatal(err)
 	}
 	this.db = db
+
+	// Migrate?
+	dryRun := types.GetConfig().DbMigrationDryRun
+	err = this.migrate(dryRun)
+	if err != nil {
+		this.db.Close()
+		utils.Fatal("unable to migrate DB", err)
+	}
+	if dryRun {
+		utils.Info("DB migration dry run finished, set dbMigrationDryRun to false to start the node")
+		this.db.Close()
+		os.Exit(0)
+	}
 }
 
 // GetCache
-func GetCache() *cache.Cache {
+func GetCache() *types.Cache {
 	return GetDbService().cache
 }
 
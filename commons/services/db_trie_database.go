@@ -0,0 +1,118 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package services
+
+import (
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/ethdb"
+)
+
+// migrationDatabase - Lets a trie read a migration's snapshot and write through its batch
+type migrationDatabase struct {
+	batch *MigrationBatch
+}
+
+// Put
+func (this *migrationDatabase) Put(key []byte, value []byte) error {
+	key = append([]byte{}, key...)
+	value = append([]byte{}, value...)
+	return this.batch.Write(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+// Get
+func (this *migrationDatabase) Get(key []byte) ([]byte, error) {
+	item, err := this.batch.Reader().Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+// Has
+func (this *migrationDatabase) Has(key []byte) (bool, error) {
+	_, err := this.batch.Reader().Get(key)
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete
+func (this *migrationDatabase) Delete(key []byte) error {
+	return this.batch.Write(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// Close
+func (this *migrationDatabase) Close() {
+}
+
+// Dump
+func (this *migrationDatabase) Dump() {
+}
+
+// NewBatch
+func (this *migrationDatabase) NewBatch() ethdb.Batch {
+	return &migrationDatabaseBatch{db: this}
+}
+
+// migrationDatabaseBatch
+type migrationDatabaseBatch struct {
+	db     *migrationDatabase
+	keys   [][]byte
+	values [][]byte
+	size   int
+}
+
+// Put
+func (this *migrationDatabaseBatch) Put(key []byte, value []byte) error {
+	this.keys = append(this.keys, append([]byte{}, key...))
+	this.values = append(this.values, append([]byte{}, value...))
+	this.size += len(value)
+	return nil
+}
+
+// Delete
+func (this *migrationDatabaseBatch) Delete(key []byte) error {
+	return this.db.Delete(key)
+}
+
+// ValueSize
+func (this *migrationDatabaseBatch) ValueSize() int {
+	return this.size
+}
+
+// Write
+func (this *migrationDatabaseBatch) Write() error {
+	for i := range this.keys {
+		err := this.db.Put(this.keys[i], this.values[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset
+func (this *migrationDatabaseBatch) Reset() {
+	this.keys = nil
+	this.values = nil
+	this.size = 0
+}
@@ -31,12 +31,15 @@ import (
 	"github.com/processout/grpc-go-pool"
 	"time"
 	"github.com/pkg/errors"
-	"github.com/patrickmn/go-cache"
 )
 
 var grpcServiceInstance *GrpcService
 var grpcServiceOnce sync.Once
 
+// Connection pools by peer address, pools hold open connections so they are never evicted
+var grpcPools = map[string]*grpcpool.Pool{}
+var grpcPoolsMutex sync.Mutex
+
 // GetGrpcService
 func GetGrpcService() *GrpcService {
 	grpcServiceOnce.Do(func() {
@@ -83,16 +86,16 @@ func (this *GrpcService) Go() {
 
 func GetGrpcConnection(address string, host string, port int64) (*grpc.ClientConn, error) {
 
-	value, ok := GetCache().Get(fmt.Sprintf("dapos-grpc-pool-%s", address))
+	grpcPoolsMutex.Lock()
+	pool, ok := grpcPools[address]
 	// IF not found then setup one
 	if !ok {
-		setupConnectionPoolForPeer(address, host, port)
+		pool = setupConnectionPoolForPeer(address, host, port)
 	}
-	value, ok = GetCache().Get(fmt.Sprintf("dapos-grpc-pool-%s", address))
-	if !ok {
+	grpcPoolsMutex.Unlock()
+	if pool == nil {
 		return nil, errors.New(fmt.Sprintf("unable to find GRPC pool for this delegate [address=%s]", address))
 	}
-	pool := value.(*grpcpool.Pool)
 	clientConn, err := pool.Get(context.Background())
 	if err != nil {
 		utils.Error("Client connection error ", err)
@@ -103,7 +106,7 @@ func GetGrpcConnection(address string, host string, port int64) (*grpc.ClientCon
 
 }
 
-func setupConnectionPoolForPeer(address string, host string, port int64) {
+func setupConnectionPoolForPeer(address string, host string, port int64) *grpcpool.Pool {
 	factory := func() (*grpc.ClientConn, error) {
 		conn, err := grpc.Dial(fmt.Sprintf("%s:%d", host, port), grpc.WithInsecure())
 
@@ -116,6 +119,8 @@ func setupConnectionPoolForPeer(address string, host string, port int64) {
 	pool, err := grpcpool.New(factory, 5, 5, time.Second* 5)
 	if err != nil {
 		utils.Error(err.Error())
+		return nil
 	}
-	GetCache().Set(fmt.Sprintf("dapos-grpc-pool-%s", address), pool, cache.NoExpiration)
+	grpcPools[address] = pool
+	return pool
 }
@@ -26,7 +26,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/patrickmn/go-cache"
 
 	"math/big"
 
@@ -65,17 +64,26 @@ func (this Account) NameKey() string {
 }
 
 //Cache
-func (this *Account) Cache(cache *cache.Cache, time_optional ...time.Duration) {
+func (this *Account) Cache(cache *Cache, time_optional ...time.Duration) {
 	TTL := AccountTTL
 	if len(time_optional) > 0 {
 		TTL = time_optional[0]
 	}
-	cache.Set(this.Key(), this, TTL)
+	cache.Accounts.Set(this.Key(), this, TTL)
+}
+
+// Uncache
+func (this *Account) Uncache(cache *Cache) {
+	cache.Accounts.Delete(this.Key())
 }
 
 //Persist
 func (this *Account) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
 	if err != nil {
 		return err
 	}
@@ -87,7 +95,7 @@ func (this *Account) Persist(txn *badger.Txn) error {
 }
 
 // PersistAndCache
-func (this *Account) Set(txn *badger.Txn, cache *cache.Cache) error {
+func (this *Account) Set(txn *badger.Txn, cache *Cache) error {
 	this.Cache(cache)
 	err := this.Persist(txn)
 	if err != nil {
@@ -210,8 +218,8 @@ func ToAccountFromJson(payload []byte) (*Account, error) {
 }
 
 // ToAccountFromCache -
-func ToAccountFromCache(cache *cache.Cache, address string) (*Account, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-account-%s", address))
+func ToAccountFromCache(cache *Cache, address string) (*Account, error) {
+	value, ok := cache.Accounts.Get(fmt.Sprintf("table-account-%s", address))
 	if !ok {
 		return nil, ErrNotFound
 	}
@@ -229,7 +237,7 @@ func ToAccountByAddress(txn *badger.Txn, address string) (*Account, error) {
 	if err != nil {
 		return nil, err
 	}
-	account, err := ToAccountFromJson(value)
+	account, err := ToAccountFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +326,7 @@ func AccountPaging(txn *badger.Txn, startingHash string, page, pageSize int) ([]
 				utils.Error(err)
 				continue
 			}
-			Account, err := ToAccountFromJson(value)
+			Account, err := ToAccountFromBytes(value)
 			if err != nil {
 				utils.Error(err)
 				continue
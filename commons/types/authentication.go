@@ -10,7 +10,6 @@ import (
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"time"
 	"fmt"
-	"github.com/patrickmn/go-cache"
 )
 
 // Authentication
@@ -82,8 +81,8 @@ func (this Authentication) Key() string {
 }
 
 // Cache
-func (this *Authentication) Cache(cache *cache.Cache) {
-	cache.Set(this.Key(), this, AuthenticationCacheTTL)
+func (this *Authentication) Cache(cache *Cache) {
+	cache.Authentications.Set(this.Key(), this)
 }
 
 // NewHash
@@ -145,7 +144,7 @@ func (this Authentication) GetDerivedAddress() (string, error) {
 }
 
 // Verify
-func (this Authentication) Verify(cache *cache.Cache, address string) error {
+func (this Authentication) Verify(cache *Cache, address string) error {
 
 	// Is this a duplicate authentication?
 	_, err := ToAuthenticationFromCache(cache, address)
@@ -199,8 +198,8 @@ func (this Authentication) Verify(cache *cache.Cache, address string) error {
 }
 
 // ToAuthenticationFromCache -
-func ToAuthenticationFromCache(cache *cache.Cache, hash string) (*Authentication, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-authentication-%s", hash))
+func ToAuthenticationFromCache(cache *Cache, hash string) (*Authentication, error) {
+	value, ok := cache.Authentications.Get(fmt.Sprintf("table-authentication-%s", hash))
 	if !ok {
 		return nil, ErrNotFound
 	}
@@ -0,0 +1,235 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// BalanceDelta - The change a transaction made to an account's balance
+type BalanceDelta struct {
+	Address         string `json:"address"`
+	TransactionHash string `json:"transactionHash"`
+	Time            int64  `json:"time"`     // Milliseconds, the transaction's time
+	Sequence        int64  `json:"sequence"` // Position in the account's history, in execution order
+	Delta           int64  `json:"delta"`
+	Balance         int64  `json:"balance"` // Balance after the transaction
+}
+
+// AccountBalance - An account's balance at a point in time
+type AccountBalance struct {
+	Address string `json:"address"`
+	Time    int64  `json:"time"` // Milliseconds
+	Balance int64  `json:"balance"`
+}
+
+// Statement - The balance deltas of an account between two points in time
+type Statement struct {
+	Address        string          `json:"address"`
+	From           int64           `json:"from"` // Milliseconds
+	To             int64           `json:"to"`   // Milliseconds
+	OpeningBalance int64           `json:"openingBalance"`
+	ClosingBalance int64           `json:"closingBalance"`
+	Entries        []*BalanceDelta `json:"entries"`
+}
+
+// Key - Time and sequence are zero padded so deltas iterate in time order, and in execution order within a millisecond
+func (this BalanceDelta) Key() string {
+	return fmt.Sprintf("table-balance-delta-%s-%020d-%020d-%s", this.Address, this.Time, this.Sequence, this.TransactionHash)
+}
+
+// TransactionKey
+func (this BalanceDelta) TransactionKey() string {
+	return fmt.Sprintf("key-balance-delta-transaction-%s-%s", this.TransactionHash, this.Address)
+}
+
+// SequenceKey - Holds the last sequence given to the account's deltas
+func (this BalanceDelta) SequenceKey() string {
+	return fmt.Sprintf("key-balance-delta-sequence-%s", this.Address)
+}
+
+// Persist - A delta without a sequence is given the account's next one
+func (this *BalanceDelta) Persist(txn *badger.Txn) error {
+	if this.Sequence == 0 {
+		sequence, err := toBalanceDeltaSequence(txn, this.Address)
+		if err != nil {
+			return err
+		}
+		this.Sequence = sequence + 1
+	}
+	err := txn.Set([]byte(this.SequenceKey()), []byte(strconv.FormatInt(this.Sequence, 10)))
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), []byte(this.String()))
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.TransactionKey()), []byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// String
+func (this BalanceDelta) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal balance delta", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// NewBalanceDelta
+func NewBalanceDelta(address string, transactionHash string, time int64, delta int64, balance int64) *BalanceDelta {
+	return &BalanceDelta{Address: address, TransactionHash: transactionHash, Time: time, Delta: delta, Balance: balance}
+}
+
+// toBalanceDeltaSequence - Returns the last sequence given to an account's deltas, 0 if it has none
+func toBalanceDeltaSequence(txn *badger.Txn, address string) (int64, error) {
+	item, err := txn.Get([]byte(BalanceDelta{Address: address}.SequenceKey()))
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// ToBalanceDeltaFromJson -
+func ToBalanceDeltaFromJson(payload []byte) (*BalanceDelta, error) {
+	balanceDelta := &BalanceDelta{}
+	err := json.Unmarshal(payload, balanceDelta)
+	if err != nil {
+		return nil, err
+	}
+	return balanceDelta, nil
+}
+
+// ToBalanceDeltasByTransactionHash
+func ToBalanceDeltasByTransactionHash(txn *badger.Txn, transactionHash string) ([]*BalanceDelta, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte(fmt.Sprintf("key-balance-delta-transaction-%s-", transactionHash))
+	var balanceDeltas = make([]*BalanceDelta, 0)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		item, err := txn.Get(value)
+		if err != nil {
+			return nil, err
+		}
+		value, err = item.Value()
+		if err != nil {
+			return nil, err
+		}
+		balanceDelta, err := ToBalanceDeltaFromJson(value)
+		if err != nil {
+			return nil, err
+		}
+		balanceDeltas = append(balanceDeltas, balanceDelta)
+	}
+	return balanceDeltas, nil
+}
+
+// ToBalanceAt - Returns the balance of an account after every transaction up to and including time
+func ToBalanceAt(txn *badger.Txn, address string, time int64) (*AccountBalance, error) {
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = true
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte(fmt.Sprintf("table-balance-delta-%s-", address))
+
+	// Reverse seek lands on the last key <= seek, '~' sorts after every sequence.
+	seek := []byte(fmt.Sprintf("table-balance-delta-%s-%020d-~", address, time))
+	accountBalance := &AccountBalance{Address: address, Time: time, Balance: 0}
+	iterator.Seek(seek)
+	if !iterator.ValidForPrefix(prefix) {
+		return accountBalance, nil
+	}
+	value, err := iterator.Item().Value()
+	if err != nil {
+		return nil, err
+	}
+	balanceDelta, err := ToBalanceDeltaFromJson(value)
+	if err != nil {
+		return nil, err
+	}
+	accountBalance.Balance = balanceDelta.Balance
+	return accountBalance, nil
+}
+
+// ToBalanceDeltas - Returns the balance deltas of an account from time to time inclusive, oldest first
+func ToBalanceDeltas(txn *badger.Txn, address string, from int64, to int64) ([]*BalanceDelta, error) {
+	opts := badger.DefaultIteratorOptions
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte(fmt.Sprintf("table-balance-delta-%s-", address))
+	seek := []byte(fmt.Sprintf("table-balance-delta-%s-%020d-", address, from))
+	var balanceDeltas = make([]*BalanceDelta, 0)
+	for iterator.Seek(seek); iterator.ValidForPrefix(prefix); iterator.Next() {
+		value, err := iterator.Item().Value()
+		if err != nil {
+			return nil, err
+		}
+		balanceDelta, err := ToBalanceDeltaFromJson(value)
+		if err != nil {
+			return nil, err
+		}
+		if balanceDelta.Time > to {
+			break
+		}
+		balanceDeltas = append(balanceDeltas, balanceDelta)
+	}
+	return balanceDeltas, nil
+}
+
+// ToStatement - Returns the statement of an account from time to time inclusive
+func ToStatement(txn *badger.Txn, address string, from int64, to int64) (*Statement, error) {
+	if to < from {
+		return nil, ErrInvalidRequestTimeRange
+	}
+	opening, err := ToBalanceAt(txn, address, from-1)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ToBalanceDeltas(txn, address, from, to)
+	if err != nil {
+		return nil, err
+	}
+	statement := &Statement{Address: address, From: from, To: to, OpeningBalance: opening.Balance, ClosingBalance: opening.Balance, Entries: entries}
+	if len(entries) > 0 {
+		statement.ClosingBalance = entries[len(entries)-1].Balance
+	}
+	return statement, nil
+}
@@ -0,0 +1,113 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+)
+
+// TestBalanceDeltaStatement
+func TestBalanceDeltaStatement(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	address := "99022124e110f5a9567a334a2017bdbd41c475e3"
+	balanceDeltas := []*BalanceDelta{
+		NewBalanceDelta(address, "a1", 100, 1000, 1000),
+		NewBalanceDelta(address, "b2", 200, -300, 700),
+		NewBalanceDelta(address, "c3", 1000, 50, 750),
+		NewBalanceDelta("e6098cc0d5c20c6c31c4d69f0201a02975264e94", "b2", 200, 300, 300),
+	}
+	for _, balanceDelta := range balanceDeltas {
+		if err := balanceDelta.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for at, expected := range map[int64]int64{50: 0, 100: 1000, 199: 1000, 200: 700, 999: 700, 5000: 750} {
+		accountBalance, err := ToBalanceAt(txn, address, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if accountBalance.Balance != expected {
+			t.Errorf("ToBalanceAt(%d) returning %d, expected %d", at, accountBalance.Balance, expected)
+		}
+	}
+
+	statement, err := ToStatement(txn, address, 150, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statement.OpeningBalance != 1000 || statement.ClosingBalance != 750 {
+		t.Errorf("ToStatement returning opening %d closing %d", statement.OpeningBalance, statement.ClosingBalance)
+	}
+	if len(statement.Entries) != 2 || statement.Entries[0].TransactionHash != "b2" {
+		t.Errorf("ToStatement returning wrong entries %v", statement.Entries)
+	}
+	if _, err := ToStatement(txn, address, 10, 5); err != ErrInvalidRequestTimeRange {
+		t.Error("ToStatement accepting an inverted time range")
+	}
+
+	byHash, err := ToBalanceDeltasByTransactionHash(txn, "b2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byHash) != 2 {
+		t.Errorf("ToBalanceDeltasByTransactionHash returning %d deltas, expected 2", len(byHash))
+	}
+}
+
+// TestBalanceDeltaSameMillisecond - Deltas in one millisecond keep their execution order, not their hash order
+func TestBalanceDeltaSameMillisecond(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	address := "d70613f93152c84050e7826c4e2b0cc02c1c3b99"
+	balanceDeltas := []*BalanceDelta{
+		NewBalanceDelta(address, "f1", 100, 1000, 1000),
+		NewBalanceDelta(address, "e2", 300, -400, 600),
+		NewBalanceDelta(address, "a3", 300, 250, 850),
+	}
+	for _, balanceDelta := range balanceDeltas {
+		if err := balanceDelta.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, balanceDelta := range balanceDeltas {
+		if balanceDelta.Sequence != int64(i+1) {
+			t.Errorf("delta %s given sequence %d, expected %d", balanceDelta.TransactionHash, balanceDelta.Sequence, i+1)
+		}
+	}
+
+	accountBalance, err := ToBalanceAt(txn, address, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accountBalance.Balance != 850 {
+		t.Errorf("ToBalanceAt(300) returning %d, expected 850", accountBalance.Balance)
+	}
+	statement, err := ToStatement(txn, address, 200, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statement.Entries) != 2 || statement.Entries[0].TransactionHash != "e2" || statement.Entries[1].TransactionHash != "a3" {
+		t.Fatalf("ToStatement returning entries out of execution order %v", statement.Entries)
+	}
+	if statement.OpeningBalance != 1000 || statement.ClosingBalance != 850 {
+		t.Errorf("ToStatement returning opening %d closing %d", statement.OpeningBalance, statement.ClosingBalance)
+	}
+}
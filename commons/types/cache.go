@@ -0,0 +1,57 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+// Cache - The in memory caches, one per record kind, each with its own size bound and TTL
+type Cache struct {
+	Transactions    *LruCache
+	Receipts        *LruCache
+	Gossips         *LruCache
+	SentDelegates   *LruCache // Delegates a gossip has been sent to, by transaction hash
+	Authentications *LruCache
+	Nodes           *LruCache
+	Accounts        *LruCache
+	Pages           *LruCache
+}
+
+// NewCache
+func NewCache() *Cache {
+	return &Cache{
+		Transactions:    NewLruCache("transactions", TransactionCacheSize, TransactionCacheTTL),
+		Receipts:        NewLruCache("receipts", ReceiptCacheSize, ReceiptCacheTTL),
+		Gossips:         NewLruCache("gossips", GossipCacheSize, GossipCacheTTL),
+		SentDelegates:   NewLruCache("sentDelegates", SentDelegateCacheSize, GossipCacheTTL),
+		Authentications: NewLruCache("authentications", AuthenticationCacheSize, AuthenticationCacheTTL),
+		Nodes:           NewLruCache("nodes", NodeCacheSize, 0),
+		Accounts:        NewLruCache("accounts", AccountCacheSize, AccountTTL),
+		Pages:           NewLruCache("pages", PageCacheSize, PageTTL),
+	}
+}
+
+// Metrics
+func (this *Cache) Metrics() []CacheMetrics {
+	return []CacheMetrics{
+		this.Transactions.Metrics(),
+		this.Receipts.Metrics(),
+		this.Gossips.Metrics(),
+		this.SentDelegates.Metrics(),
+		this.Authentications.Metrics(),
+		this.Nodes.Metrics(),
+		this.Accounts.Metrics(),
+		this.Pages.Metrics(),
+	}
+}
@@ -36,15 +36,19 @@ var configOnce sync.Once
 
 // Config - Is the structure definition for the system properties
 type Config struct {
-	HttpEndpoint       *Endpoint `json:"httpEndpoint"`
-	GrpcEndpoint       *Endpoint `json:"grpcEndpoint"`
-	GrpcTimeout        int       `json:"grpcTimeout"`
-	LocalHttpApiPort   int       `json:"localHttpApiPort"`
-	Seeds              []*Node   `json:"seeds"`
-	DelegateAddresses  []string  `json:"delegateAddresses"`
-	UseQuantumEntropy  bool      `json:"useQuantumEntropy"`
-	IsBookkeeper       bool      `json:"isBookkeeper"`
-	GenesisTransaction string    `json:"genesisTransaction"`
+	HttpEndpoint       *Endpoint      `json:"httpEndpoint"`
+	GrpcEndpoint       *Endpoint      `json:"grpcEndpoint"`
+	GrpcTimeout        int            `json:"grpcTimeout"`
+	LocalHttpApiPort   int            `json:"localHttpApiPort"`
+	Seeds              []*Node        `json:"seeds"`
+	DelegateAddresses  []string       `json:"delegateAddresses"`
+	DelegateSets       []*DelegateSet `json:"delegateSets"`
+	UseQuantumEntropy  bool           `json:"useQuantumEntropy"`
+	IsBookkeeper       bool           `json:"isBookkeeper"`
+	GenesisTransaction string         `json:"genesisTransaction"`
+	DbMigrationDryRun  bool           `json:"dbMigrationDryRun"`
+	ChainId            int64          `json:"chainId"`
+	Forks              []*Fork        `json:"forks"`
 }
 
 // String - Implement the `fmt.Stringer` interface
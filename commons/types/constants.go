@@ -56,6 +56,11 @@ const (
 	StatusUnavailableFeature           = "UnavailableFeature"
 	StatusNodeUnavailable              = "NodeUnavailable"
 	StatusCouldNotReachConsensus       = "CouldNotReachConsensus"
+	StatusInvalidRequest               = "InvalidRequest"
+	StatusOutOfHertz                   = "OutOfHertz"
+	StatusPageHertzLimitExceeded       = "PageHertzLimitExceeded"
+	StatusContractReverted             = "ContractReverted"
+	StatusInvalidCode                  = "InvalidCode"
 )
 
 const (
@@ -87,6 +92,41 @@ const (
 	AuthenticationCacheTTL = time.Minute
 )
 
+// Cache sizes, the least recently used entry is evicted once a cache is full
+const (
+	TransactionCacheSize    = 10000
+	ReceiptCacheSize        = 10000
+	GossipCacheSize         = 10000
+	SentDelegateCacheSize   = 10000
+	AuthenticationCacheSize = 1000
+	NodeCacheSize           = 1000
+	AccountCacheSize        = 10000
+	PageCacheSize           = 100
+)
+
+// Limits
+const (
+	LogFilterLimit = 1000 // Most logs a log filter returns
+)
+
+// Hertz limits, Hertz is our version of Gas
+const (
+	DefaultHertzLimit = 10000000  // Limit of a contract transaction that does not set Hertz
+	PageHertzLimit    = 100000000 // Most Hertz the contract transactions of one Page may use
+	PageDuration      = 1000      // Milliseconds of transaction time covered by one Page
+)
+
+// EVM forks a network can activate, in activation order
+const (
+	ForkPetersburg = "petersburg"
+	ForkIstanbul   = "istanbul"
+	ForkBerlin     = "berlin"
+	ForkLondon     = "london"
+	ForkShanghai   = "shanghai"
+
+	ForkDispatch = "dispatch" // Dispatch precompiled contracts, independent of the EVM forks
+)
+
 // Errors
 var (
 	ErrNotFound               = errors.New("not found")
@@ -95,4 +135,6 @@ var (
 	ErrInvalidRequestPageSize = errors.New("invalid request Page Size")
 	ErrInvalidRequestStartingHash = errors.New("invalid request Starting Hash")
 	ErrInvalidRequestHash     = errors.New("invalid request Hash")
+	ErrInvalidRequestTimeRange = errors.New("invalid request Time Range")
+	ErrOutOfHertz = errors.New("out of hertz")
 )
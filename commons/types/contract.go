@@ -0,0 +1,266 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// Contract - The registry record of a deployed contract, written when its deploy transaction executes
+type Contract struct {
+	Address         string
+	Deployer        string
+	TransactionHash string            // The deploy transaction
+	Abi             string            // Hex encoded, as the deploy transaction stores it
+	CodeHash        string            // Of the runtime code in the world state
+	Source          string            // Published by the deployer, optional
+	Metadata        string            // Compiler metadata JSON published by the deployer, optional
+	Time            int64             // Milliseconds, the deploy transaction's time
+	Methods         []*ContractMethod // Transient, built from the ABI
+}
+
+// ContractMethod - A method of a contract's ABI
+type ContractMethod struct {
+	Name      string              `json:"name"`
+	Signature string              `json:"signature"`
+	Selector  string              `json:"selector"`
+	Constant  bool                `json:"constant"`
+	Inputs    []*ContractArgument `json:"inputs"`
+	Outputs   []*ContractArgument `json:"outputs"`
+}
+
+// ContractArgument
+type ContractArgument struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// NewContract - The registry record of the contract a deploy transaction created
+func NewContract(address string, transaction *Transaction, codeHash string) *Contract {
+	return &Contract{
+		Address:         address,
+		Deployer:        transaction.From,
+		TransactionHash: transaction.Hash,
+		Abi:             transaction.Abi,
+		CodeHash:        codeHash,
+		Source:          transaction.Source,
+		Metadata:        transaction.Metadata,
+		Time:            transaction.Time,
+	}
+}
+
+// NewCreatedContract - The registry record of a contract another contract created, its creator is the deployer. The
+// ABI is unknown, the transactions executing it send theirs.
+func NewCreatedContract(address string, creator string, transaction *Transaction, codeHash string) *Contract {
+	return &Contract{
+		Address:         address,
+		Deployer:        creator,
+		TransactionHash: transaction.Hash,
+		CodeHash:        codeHash,
+		Time:            transaction.Time,
+	}
+}
+
+// ExecuteAbi - The hex encoded ABI a transaction executes the contract with, the one the transaction sends when the
+// contract has none registered
+func (this Contract) ExecuteAbi(transaction *Transaction) string {
+	if len(this.Abi) > 0 {
+		return this.Abi
+	}
+	if _, err := hex.DecodeString(transaction.Abi); err == nil {
+		return transaction.Abi
+	}
+	return hex.EncodeToString([]byte(transaction.Abi))
+}
+
+// Key
+func (this Contract) Key() string {
+	return fmt.Sprintf("table-contract-%s", this.Address)
+}
+
+// TimeKey - Time is zero padded so contracts iterate in deploy order
+func (this Contract) TimeKey() string {
+	return fmt.Sprintf("key-contract-time-%020d-%s", this.Time, this.Address)
+}
+
+// DeployerKey
+func (this Contract) DeployerKey() string {
+	return fmt.Sprintf("key-contract-deployer-%s-%020d-%s", this.Deployer, this.Time, this.Address)
+}
+
+// Persist - Persists the contract along with its time and deployer indexes
+func (this *Contract) Persist(txn *badger.Txn) error {
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.TimeKey()), []byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.DeployerKey()), []byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// String
+func (this Contract) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal contract", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// MarshalJSON - The ABI and metadata are written as JSON rather than as strings
+func (this Contract) MarshalJSON() ([]byte, error) {
+	abi, err := hex.DecodeString(this.Abi)
+	if err != nil || !json.Valid(abi) {
+		abi = []byte("null")
+	}
+	var metadata json.RawMessage
+	if this.Metadata != "" && json.Valid([]byte(this.Metadata)) {
+		metadata = json.RawMessage(this.Metadata)
+	}
+	return json.Marshal(struct {
+		Address         string            `json:"address"`
+		Deployer        string            `json:"deployer"`
+		TransactionHash string            `json:"transactionHash"`
+		Abi             json.RawMessage   `json:"abi"`
+		CodeHash        string            `json:"codeHash,omitempty"`
+		Source          string            `json:"source,omitempty"`
+		Metadata        json.RawMessage   `json:"metadata,omitempty"`
+		Time            int64             `json:"time"`
+		Methods         []*ContractMethod `json:"methods,omitempty"`
+	}{
+		Address:         this.Address,
+		Deployer:        this.Deployer,
+		TransactionHash: this.TransactionHash,
+		Abi:             json.RawMessage(abi),
+		CodeHash:        this.CodeHash,
+		Source:          this.Source,
+		Metadata:        metadata,
+		Time:            this.Time,
+		Methods:         this.Methods,
+	})
+}
+
+// ToContractByAddress - Contracts deployed before the registry existed are read from their deploy transaction, without a code hash
+func ToContractByAddress(txn *badger.Txn, address string) (*Contract, error) {
+	item, err := txn.Get([]byte(fmt.Sprintf("table-contract-%s", address)))
+	if err == badger.ErrKeyNotFound {
+		transaction, err := ToTransactionByAddress(txn, address)
+		if err != nil {
+			return nil, err
+		}
+		return NewContract(address, transaction, ""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return ToContractFromBytes(value)
+}
+
+// ToContractByKey
+func ToContractByKey(txn *badger.Txn, key []byte) (*Contract, error) {
+	item, err := txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return ToContractFromBytes(value)
+}
+
+// ContractPaging - Returns a page of the registered contracts, most recently deployed first, optionally only those of a
+// deployer. Paging starts at the contract startingAddress, the most recent one when empty
+func ContractPaging(txn *badger.Txn, deployer, startingAddress string, page, pageSize int) ([]*Contract, *PagingResult, error) {
+	if pageSize <= 0 || pageSize > 100 {
+		return nil, nil, ErrInvalidRequestPageSize
+	}
+	if page <= 0 {
+		return nil, nil, ErrInvalidRequestPage
+	}
+
+	prefix := "key-contract-time-"
+	if deployer != "" {
+		prefix = fmt.Sprintf("key-contract-deployer-%s-", deployer)
+	}
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	opts.Reverse = true
+	iterator := txn.NewIterator(opts)
+	keys := make([]string, 0)
+
+	// Reverse seek lands on the last key <= seek, '~' sorts after every key of the prefix.
+	for iterator.Seek([]byte(prefix + "~")); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		keys = append(keys, string(iterator.Item().Key()))
+	}
+	iterator.Close()
+
+	contracts := make([]*Contract, 0)
+	if len(keys) == 0 {
+		return contracts, &PagingResult{0, ""}, nil
+	}
+
+	// The address is the last part of the key.
+	idx := -1
+	for i, key := range keys {
+		address := key[strings.LastIndex(key, "-")+1:]
+		if startingAddress == "" {
+			startingAddress = address
+		}
+		if address == startingAddress {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, nil, ErrInvalidRequestStartingHash
+	}
+
+	totalCount := len(keys) - idx
+	for i := idx + (page-1)*pageSize; i < idx+page*pageSize && i < len(keys); i++ {
+		address := keys[i][strings.LastIndex(keys[i], "-")+1:]
+		contract, err := ToContractByKey(txn, []byte(fmt.Sprintf("table-contract-%s", address)))
+		if err != nil {
+			utils.Warn(fmt.Sprintf("Could not find contract key: table-contract-%s", address), err)
+			continue
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, &PagingResult{totalCount, startingAddress}, nil
+}
@@ -0,0 +1,90 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/pkg/errors"
+)
+
+// ContractCall - A read-only call of a smart contract method, it is not signed, gossiped or persisted
+type ContractCall struct {
+	From   string        `json:"from,omitempty"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params,omitempty"`
+}
+
+// ContractCallResult - The decoded outputs of a read-only call
+type ContractCallResult struct {
+	ContractAddress string        `json:"contractAddress"`
+	Method          string        `json:"method"`
+	Outputs         []interface{} `json:"outputs"`
+	HertzCost       uint64        `json:"hertzCost"`
+}
+
+// HertzEstimate - The least hertz a contract transaction succeeds with, found by dry-runs that are not gossiped or committed
+type HertzEstimate struct {
+	Hertz           int64         `json:"hertz"`
+	HertzUsed       int64         `json:"hertzUsed"`
+	ContractAddress string        `json:"contractAddress,omitempty"`
+	Outputs         []interface{} `json:"outputs,omitempty"`
+}
+
+// String
+func (this ContractCall) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal contract call", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// String
+func (this ContractCallResult) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal contract call result", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// ToContractCallFromJson
+func ToContractCallFromJson(payload []byte) (*ContractCall, error) {
+	contractCall := &ContractCall{}
+	err := json.Unmarshal(payload, contractCall)
+	if err != nil {
+		return nil, err
+	}
+	if contractCall.Method == "" {
+		return nil, errors.Errorf("value for field 'method' is required")
+	}
+	return contractCall, nil
+}
+
+// String
+func (this HertzEstimate) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal hertz estimate", err)
+		return ""
+	}
+	return string(bytes)
+}
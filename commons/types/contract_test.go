@@ -0,0 +1,277 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// TestDeployContractTransactionWithSource
+func TestDeployContractTransactionWithSource(t *testing.T) {
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	withoutSource, err := NewDeployContractTransactionWithValue(privateKey, from, "6080", "[]", 0, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := *withoutSource
+	tx.Source = "contract Token {}"
+	tx.Metadata = `{"compiler":{"version":"0.4.24"}}`
+	tx.Hash, err = tx.NewHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Signature, err = tx.NewSignature(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash == withoutSource.Hash {
+		t.Error("source and metadata are not part of the hash")
+	}
+	if err = tx.Verify(); err != nil {
+		t.Errorf("cannot verify transaction: %v", err)
+	}
+
+	// Published source survives JSON and the binary record.
+	received, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordBytes, err := received.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ToTransactionFromBytes(recordBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Source != tx.Source || stored.Metadata != tx.Metadata {
+		t.Errorf("stored source %q and metadata %q", stored.Source, stored.Metadata)
+	}
+	if err = stored.Verify(); err != nil {
+		t.Errorf("cannot verify stored transaction: %v", err)
+	}
+	stored.Source = "contract Other {}"
+	if stored.Verify() == nil {
+		t.Error("transaction with changed source verified")
+	}
+
+	// Metadata must be JSON.
+	tx.Metadata = "{"
+	tx.Hash, _ = tx.NewHash()
+	tx.Signature, _ = tx.NewSignature(privateKey)
+	if tx.Verify() == nil {
+		t.Error("transaction with invalid metadata verified")
+	}
+}
+
+// TestNewDeployContractTransactionWithSource
+func TestNewDeployContractTransactionWithSource(t *testing.T) {
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	tx, err := NewDeployContractTransactionWithSource(privateKey, from, "6080", "[]", "contract Token {}", `{"compiler":{"version":"0.4.24"}}`, 25, now, "Token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type != TypeDeploySmartContract || tx.Code != "6080" || tx.Value != 25 || len(tx.Params) != 1 {
+		t.Errorf("NewDeployContractTransactionWithSource returning %v", tx)
+	}
+	if tx.Source != "contract Token {}" || tx.Metadata != `{"compiler":{"version":"0.4.24"}}` {
+		t.Errorf("NewDeployContractTransactionWithSource returning source %q and metadata %q", tx.Source, tx.Metadata)
+	}
+	if err = tx.Verify(); err != nil {
+		t.Errorf("cannot verify transaction: %v", err)
+	}
+
+	// Without source and metadata it is the deploy of NewDeployContractTransactionWithValue.
+	withoutSource, err := NewDeployContractTransactionWithSource(privateKey, from, "6080", "[]", "", "", 25, now, "Token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	withValue, err := NewDeployContractTransactionWithValue(privateKey, from, "6080", "[]", 25, now, "Token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withoutSource.Hash != withValue.Hash {
+		t.Errorf("hash %s, expected %s", withoutSource.Hash, withValue.Hash)
+	}
+
+	if _, err = NewDeployContractTransactionWithSource(privateKey, from, "6080", "", "contract Token {}", "", 0, now); err == nil {
+		t.Error("NewDeployContractTransactionWithSource accepting an empty abi")
+	}
+}
+
+// TestContractBinary
+func TestContractBinary(t *testing.T) {
+	contract := &Contract{
+		Address:         "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e",
+		Deployer:        "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
+		TransactionHash: "a1",
+		Abi:             hex.EncodeToString([]byte("[]")),
+		CodeHash:        "c0de",
+		Source:          "contract Token {}",
+		Metadata:        `{"language":"Solidity"}`,
+		Time:            100,
+	}
+	bytes, err := contract.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ToContractFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, contract) {
+		t.Errorf("decoded contract %v, expected %v", decoded, contract)
+	}
+
+	// The ABI and metadata are written as JSON.
+	var jsonMap map[string]interface{}
+	if err := json.Unmarshal([]byte(contract.String()), &jsonMap); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := jsonMap["abi"].([]interface{}); !ok {
+		t.Errorf("abi written as %v", jsonMap["abi"])
+	}
+	if _, ok := jsonMap["metadata"].(map[string]interface{}); !ok {
+		t.Errorf("metadata written as %v", jsonMap["metadata"])
+	}
+}
+
+// TestContractExecuteAbi
+func TestContractExecuteAbi(t *testing.T) {
+	registeredAbi := hex.EncodeToString([]byte("[]"))
+	sentAbi := `[{"name":"set","type":"function","inputs":[]}]`
+	transaction := &Transaction{Hash: "h1", Type: TypeExecuteSmartContract, Abi: sentAbi, Time: 100}
+
+	deployed := &Contract{Address: "a1", Abi: registeredAbi}
+	if abi := deployed.ExecuteAbi(transaction); abi != registeredAbi {
+		t.Errorf("deployed contract executed with ABI %s, expected the registered %s", abi, registeredAbi)
+	}
+
+	created := NewCreatedContract("b2", "a1", transaction, "c0de")
+	if created.Deployer != "a1" || created.TransactionHash != "h1" || created.Abi != "" || created.CodeHash != "c0de" || created.Time != 100 {
+		t.Errorf("NewCreatedContract returning %v", created)
+	}
+	if abi := created.ExecuteAbi(transaction); abi != hex.EncodeToString([]byte(sentAbi)) {
+		t.Errorf("created contract executed with ABI %s, expected the one sent", abi)
+	}
+
+	// Already hex encoded once the transaction was received.
+	transaction.Abi = hex.EncodeToString([]byte(sentAbi))
+	if abi := created.ExecuteAbi(transaction); abi != transaction.Abi {
+		t.Errorf("created contract executed with ABI %s, expected %s", abi, transaction.Abi)
+	}
+}
+
+// TestContractPaging
+func TestContractPaging(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	deployer := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	contracts := []*Contract{
+		{Address: "a1", Deployer: deployer, TransactionHash: "h1", Time: 100},
+		{Address: "b2", Deployer: "e6098cc0d5c20c6c31c4d69f0201a02975264e94", TransactionHash: "h2", Time: 200},
+		{Address: "c3", Deployer: deployer, TransactionHash: "h3", Time: 300},
+	}
+	for _, contract := range contracts {
+		if err := contract.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		deployer string
+		start    string
+		page     int
+		pageSize int
+		expected []string
+		count    int
+	}{
+		{"", "", 1, 10, []string{"c3", "b2", "a1"}, 3},
+		{"", "", 2, 2, []string{"a1"}, 3},
+		{"", "b2", 1, 10, []string{"b2", "a1"}, 2},
+		{deployer, "", 1, 10, []string{"c3", "a1"}, 2},
+	} {
+		found, paging, err := ContractPaging(txn, test.deployer, test.start, test.page, test.pageSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addresses := make([]string, 0)
+		for _, contract := range found {
+			addresses = append(addresses, contract.Address)
+		}
+		if !reflect.DeepEqual(addresses, test.expected) || paging.Count != test.count {
+			t.Errorf("ContractPaging(%q, %q, %d, %d) returning %v (count %d), expected %v (count %d)", test.deployer, test.start, test.page, test.pageSize, addresses, paging.Count, test.expected, test.count)
+		}
+	}
+
+	if _, _, err := ContractPaging(txn, "", "ff", 1, 10); err != ErrInvalidRequestStartingHash {
+		t.Error("ContractPaging accepting an unknown starting address")
+	}
+}
+
+// TestToContractByAddress
+func TestToContractByAddress(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+
+	// Registered.
+	registered := &Contract{Address: "a1", Deployer: "d1", TransactionHash: "h1", CodeHash: "c0de", Time: 100}
+	if err := registered.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	contract, err := ToContractByAddress(txn, "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(contract, registered) {
+		t.Errorf("ToContractByAddress returning %v, expected %v", contract, registered)
+	}
+
+	// Deployed before the registry, read from the deploy transaction.
+	transaction := &Transaction{Hash: "h2", Type: TypeDeploySmartContract, From: "d2", Abi: hex.EncodeToString([]byte("[]")), Time: 200}
+	if err := transaction.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	account := &Account{Address: "b2", TransactionHash: "h2"}
+	if err := account.Persist(txn); err != nil {
+		t.Fatal(err)
+	}
+	contract, err = ToContractByAddress(txn, "b2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.Deployer != "d2" || contract.TransactionHash != "h2" || contract.Abi != transaction.Abi || contract.CodeHash != "" {
+		t.Errorf("ToContractByAddress returning %v from the deploy transaction", contract)
+	}
+
+	if _, err := ToContractByAddress(txn, "ff"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("ToContractByAddress of an unknown address returning %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+// DelegateSet - The delegates elected from a Page or a transaction time on, replacing the genesis delegateAddresses
+type DelegateSet struct {
+	Addresses []string `json:"addresses"`
+	Page      *int64   `json:"page,omitempty"`
+	Time      *int64   `json:"time,omitempty"`
+}
+
+// IsActive - Whether the set applies to a transaction of the given time in milliseconds, a set with neither Page nor Time set never activates
+func (this DelegateSet) IsActive(time int64) bool {
+	return Fork{Page: this.Page, Time: this.Time}.IsActive(time)
+}
+
+// GetDelegateAddresses - The delegates of a transaction of the given time in milliseconds. They only come from the configuration every
+// delegate shares, never from the nodes this one discovered, so every delegate agrees: the last configured set active at that time,
+// the genesis delegateAddresses before any
+func (this Config) GetDelegateAddresses(time int64) []string {
+	addresses := this.DelegateAddresses
+	for _, delegateSet := range this.DelegateSets {
+		if delegateSet != nil && delegateSet.IsActive(time) {
+			addresses = delegateSet.Addresses
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,45 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigGetDelegateAddresses(t *testing.T) {
+	config, err := ToConfigFromJson([]byte(`{"delegateAddresses":["a1","a2"],"delegateSets":[{"addresses":["b1"],"page":10},{"addresses":["c1","c2","c3"],"time":20000},{"addresses":["d1"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		time int64
+		want []string
+	}{
+		{0, []string{"a1", "a2"}},
+		{9999, []string{"a1", "a2"}},
+		{10000, []string{"b1"}},
+		{19999, []string{"b1"}},
+		{20000, []string{"c1", "c2", "c3"}},
+		{1 << 40, []string{"c1", "c2", "c3"}},
+	}
+	for i, test := range tests {
+		if got := config.GetDelegateAddresses(test.time); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: GetDelegateAddresses(%d) = %v, want %v", i, test.time, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// EventLog - An event a contract emitted while executing a transaction
+type EventLog struct {
+	ContractAddress string                 `json:"contractAddress"`
+	TransactionHash string                 `json:"transactionHash"`
+	Index           int                    `json:"index"` // Position of the log within its transaction
+	Time            int64                  `json:"time"`  // Milliseconds, the transaction's time
+	Topics          []string               `json:"topics"`
+	Data            string                 `json:"data"`
+	Event           string                 `json:"event,omitempty"`  // Decoded with the contract's ABI, not persisted
+	Values          map[string]interface{} `json:"values,omitempty"` // Decoded with the contract's ABI, not persisted
+}
+
+// LogBloom - The bloom filter of the addresses and topics of a transaction's logs
+type LogBloom struct {
+	TransactionHash string
+	Bloom           []byte
+}
+
+// LogFilter - Topics are positional, an empty topic matches any value
+type LogFilter struct {
+	Address string
+	Topics  []string
+	From    int64 // Milliseconds
+	To      int64 // Milliseconds
+}
+
+// Key - Time is zero padded so logs iterate in time order
+func (this EventLog) Key() string {
+	return fmt.Sprintf("table-log-%020d-%s-%04d", this.Time, this.TransactionHash, this.Index)
+}
+
+// AddressKey
+func (this EventLog) AddressKey() string {
+	return fmt.Sprintf("key-log-address-%s-%020d-%s-%04d", this.ContractAddress, this.Time, this.TransactionHash, this.Index)
+}
+
+// TopicKey
+func (this EventLog) TopicKey(topic string) string {
+	return fmt.Sprintf("key-log-topic-%s-%020d-%s-%04d", topic, this.Time, this.TransactionHash, this.Index)
+}
+
+// Persist - Persists the log along with its address and topic indexes
+func (this *EventLog) Persist(txn *badger.Txn) error {
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.AddressKey()), []byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	for _, topic := range this.Topics {
+		err = txn.Set([]byte(this.TopicKey(topic)), []byte(this.Key()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String
+func (this EventLog) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal event log", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// Matches
+func (this EventLog) Matches(filter *LogFilter) bool {
+	if this.Time < filter.From || this.Time > filter.To {
+		return false
+	}
+	if filter.Address != "" && filter.Address != this.ContractAddress {
+		return false
+	}
+	for i, topic := range filter.Topics {
+		if topic == "" {
+			continue
+		}
+		if i >= len(this.Topics) || this.Topics[i] != topic {
+			return false
+		}
+	}
+	return true
+}
+
+// Key
+func (this LogBloom) Key() string {
+	return fmt.Sprintf("table-bloom-%s", this.TransactionHash)
+}
+
+// Persist
+func (this *LogBloom) Persist(txn *badger.Txn) error {
+	err := txn.Set([]byte(this.Key()), this.Bloom)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ToLogBloomByTransactionHash
+func ToLogBloomByTransactionHash(txn *badger.Txn, transactionHash string) (*LogBloom, error) {
+	logBloom := &LogBloom{TransactionHash: transactionHash}
+	item, err := txn.Get([]byte(logBloom.Key()))
+	if err != nil {
+		return nil, err
+	}
+	logBloom.Bloom, err = item.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+	return logBloom, nil
+}
+
+// ToEventLogByKey
+func ToEventLogByKey(txn *badger.Txn, key []byte) (*EventLog, error) {
+	item, err := txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return ToEventLogFromBytes(value)
+}
+
+// ToEventLogs - Returns the logs matching the filter in time order, at most LogFilterLimit of them.
+// The address index drives the scan when an address is given, then the index of the first topic,
+// otherwise every log in the time range is scanned. A transaction whose bloom fails mayContain is skipped
+// without reading its logs.
+func ToEventLogs(txn *badger.Txn, filter *LogFilter, mayContain func(bloom []byte) bool) ([]*EventLog, error) {
+	if filter.To < filter.From {
+		return nil, ErrInvalidRequestTimeRange
+	}
+
+	// Pick the index to scan.
+	prefix := "table-log-"
+	indexed := true
+	if filter.Address != "" {
+		prefix = fmt.Sprintf("key-log-address-%s-", filter.Address)
+	} else if topic := firstTopic(filter.Topics); topic != "" {
+		prefix = fmt.Sprintf("key-log-topic-%s-", topic)
+	} else {
+		indexed = false
+	}
+
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+
+	eventLogs := make([]*EventLog, 0)
+	skip := map[string]bool{}
+	for iterator.Seek([]byte(fmt.Sprintf("%s%020d", prefix, filter.From))); iterator.ValidForPrefix([]byte(prefix)); iterator.Next() {
+		item := iterator.Item()
+
+		// Key suffix is <time>-<transactionHash>-<index>.
+		parts := strings.Split(string(item.Key()[len(prefix):]), "-")
+		if len(parts) != 3 {
+			continue
+		}
+		logTime, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if logTime > filter.To {
+			break
+		}
+
+		// Bloom?
+		transactionHash := parts[1]
+		if mayContain != nil {
+			skipped, ok := skip[transactionHash]
+			if !ok {
+				logBloom, err := ToLogBloomByTransactionHash(txn, transactionHash)
+				skipped = err == nil && !mayContain(logBloom.Bloom)
+				skip[transactionHash] = skipped
+			}
+			if skipped {
+				continue
+			}
+		}
+
+		value, err := item.Value()
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		var eventLog *EventLog
+		if indexed {
+			eventLog, err = ToEventLogByKey(txn, value)
+		} else {
+			eventLog, err = ToEventLogFromBytes(value)
+		}
+		if err != nil {
+			utils.Error(err)
+			continue
+		}
+		if !eventLog.Matches(filter) {
+			continue
+		}
+		eventLogs = append(eventLogs, eventLog)
+		if len(eventLogs) >= LogFilterLimit {
+			break
+		}
+	}
+	return eventLogs, nil
+}
+
+// firstTopic
+func firstTopic(topics []string) string {
+	for _, topic := range topics {
+		if topic != "" {
+			return topic
+		}
+	}
+	return ""
+}
+
+// ToEventLogFromJson
+func ToEventLogFromJson(payload []byte) (*EventLog, error) {
+	eventLog := &EventLog{}
+	err := json.Unmarshal(payload, eventLog)
+	if err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
@@ -0,0 +1,109 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestEventLogFilter
+func TestEventLogFilter(t *testing.T) {
+	defer destruct()
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	contract := "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e"
+	eventLogs := []*EventLog{
+		{ContractAddress: contract, TransactionHash: "a1", Index: 0, Time: 100, Topics: []string{"e1", "01"}, Data: "00"},
+		{ContractAddress: contract, TransactionHash: "a1", Index: 1, Time: 100, Topics: []string{"e2"}, Data: "00"},
+		{ContractAddress: contract, TransactionHash: "b2", Index: 0, Time: 200, Topics: []string{"e1", "02"}, Data: "00"},
+		{ContractAddress: "e6098cc0d5c20c6c31c4d69f0201a02975264e94", TransactionHash: "c3", Index: 0, Time: 300, Topics: []string{"e1"}, Data: "00"},
+	}
+	for _, eventLog := range eventLogs {
+		if err := eventLog.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+		logBloom := &LogBloom{TransactionHash: eventLog.TransactionHash, Bloom: []byte(eventLog.TransactionHash)}
+		if err := logBloom.Persist(txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		filter   *LogFilter
+		expected []string
+	}{
+		{&LogFilter{From: 0, To: 1000}, []string{"a1", "a1", "b2", "c3"}},
+		{&LogFilter{Address: contract, From: 0, To: 1000}, []string{"a1", "a1", "b2"}},
+		{&LogFilter{Address: contract, From: 150, To: 1000}, []string{"b2"}},
+		{&LogFilter{Topics: []string{"e1"}, From: 0, To: 1000}, []string{"a1", "b2", "c3"}},
+		{&LogFilter{Topics: []string{"", "02"}, From: 0, To: 1000}, []string{"b2"}},
+		{&LogFilter{Address: contract, Topics: []string{"e2"}, From: 0, To: 1000}, []string{"a1"}},
+		{&LogFilter{From: 0, To: 250}, []string{"a1", "a1", "b2"}},
+	} {
+		found, err := ToEventLogs(txn, test.filter, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes := make([]string, 0)
+		for _, eventLog := range found {
+			hashes = append(hashes, eventLog.TransactionHash)
+		}
+		if !reflect.DeepEqual(hashes, test.expected) {
+			t.Errorf("ToEventLogs(%+v) returning %v, expected %v", test.filter, hashes, test.expected)
+		}
+	}
+
+	// The bloom test skips whole transactions.
+	found, err := ToEventLogs(txn, &LogFilter{Address: contract, From: 0, To: 1000}, func(bloom []byte) bool {
+		return string(bloom) != "a1"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].TransactionHash != "b2" {
+		t.Errorf("ToEventLogs not skipping on the bloom, returning %v", found)
+	}
+
+	if _, err := ToEventLogs(txn, &LogFilter{From: 10, To: 5}, nil); err != ErrInvalidRequestTimeRange {
+		t.Error("ToEventLogs accepting an inverted time range")
+	}
+}
+
+// TestReceiptLogsBinary
+func TestReceiptLogsBinary(t *testing.T) {
+	receipt := NewReceipt("a1")
+	receipt.Logs = []*EventLog{{ContractAddress: "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e", TransactionHash: "a1", Index: 0, Time: 100, Topics: []string{"e1", "01"}, Data: "00"}}
+	bytes, err := receipt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ToReceiptFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Logs, receipt.Logs) {
+		t.Errorf("receipt logs %v, expected %v", decoded.Logs, receipt.Logs)
+	}
+	decoded, err = ToReceiptFromJson([]byte(receipt.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Logs, receipt.Logs) {
+		t.Errorf("receipt json logs %v, expected %v", decoded.Logs, receipt.Logs)
+	}
+}
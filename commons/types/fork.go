@@ -0,0 +1,45 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+// Fork - Activates a set of EVM rules, named by one of the Fork constants, from a Page or a transaction time on
+type Fork struct {
+	Name string `json:"name"`
+	Page *int64 `json:"page,omitempty"`
+	Time *int64 `json:"time,omitempty"`
+}
+
+// IsActive - Whether the fork applies to a transaction of the given time in milliseconds, a fork with neither Page nor Time set never activates
+func (this Fork) IsActive(time int64) bool {
+	if this.Page != nil && ToPageNumber(time) >= *this.Page {
+		return true
+	}
+	if this.Time != nil && time >= *this.Time {
+		return true
+	}
+	return false
+}
+
+// IsForkActive - Whether the named fork is configured and active for a transaction of the given time in milliseconds
+func (this Config) IsForkActive(name string, time int64) bool {
+	for _, fork := range this.Forks {
+		if fork != nil && fork.Name == name && fork.IsActive(time) {
+			return true
+		}
+	}
+	return false
+}
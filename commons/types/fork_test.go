@@ -0,0 +1,71 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestForkIsActive(t *testing.T) {
+	page, time := int64(10), int64(20000)
+	tests := []struct {
+		fork Fork
+		time int64
+		want bool
+	}{
+		{Fork{Name: ForkIstanbul}, 1 << 40, false},
+		{Fork{Name: ForkIstanbul, Page: &page}, 9999, false},
+		{Fork{Name: ForkIstanbul, Page: &page}, 10000, true},
+		{Fork{Name: ForkIstanbul, Time: &time}, 19999, false},
+		{Fork{Name: ForkIstanbul, Time: &time}, 20000, true},
+		{Fork{Name: ForkIstanbul, Page: &page, Time: &time}, 10000, true},
+	}
+	for i, test := range tests {
+		if got := test.fork.IsActive(test.time); got != test.want {
+			t.Errorf("test %d: IsActive(%d) = %v, want %v", i, test.time, got, test.want)
+		}
+	}
+}
+
+func TestConfigIsForkActive(t *testing.T) {
+	config, err := ToConfigFromJson([]byte(`{"chainId":7,"forks":[{"name":"istanbul","page":0},{"name":"berlin","time":5000}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ChainId != 7 {
+		t.Errorf("ChainId = %d, want 7", config.ChainId)
+	}
+	if !config.IsForkActive(ForkIstanbul, 0) {
+		t.Error("istanbul should be active from page 0")
+	}
+	if config.IsForkActive(ForkBerlin, 4999) || !config.IsForkActive(ForkBerlin, 5000) {
+		t.Error("berlin should activate at time 5000")
+	}
+	if config.IsForkActive(ForkLondon, 1<<40) {
+		t.Error("london is not configured")
+	}
+
+	// Unset activations are left out of the JSON
+	bytes, err := json.Marshal(config.Forks[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bytes) != `{"name":"berlin","time":5000}` {
+		t.Errorf("unexpected fork JSON %s", bytes)
+	}
+}
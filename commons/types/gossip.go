@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
 )
 
 // Gossip
@@ -39,8 +38,8 @@ func (this Gossip) RumorKey(txHash string) string {
 	return fmt.Sprintf("cache-rumor-%s", txHash)
 }
 
-func (this *Gossip) HaveSent(cache *cache.Cache, txHash, delegateAddress string) bool {
-	value, ok := cache.Get(this.RumorKey(txHash))
+func (this *Gossip) HaveSent(cache *Cache, txHash, delegateAddress string) bool {
+	value, ok := cache.SentDelegates.Get(this.RumorKey(txHash))
 	if !ok{
 		return false
 	}
@@ -53,27 +52,30 @@ func (this *Gossip) HaveSent(cache *cache.Cache, txHash, delegateAddress string)
 	return false
 }
 
-func (this *Gossip) CacheSentDelegate(cache *cache.Cache, txHash, nodeAddress string) {
-	value, ok := cache.Get(this.RumorKey(txHash))
+func (this *Gossip) CacheSentDelegate(cache *Cache, txHash, nodeAddress string) {
+	value, ok := cache.SentDelegates.Get(this.RumorKey(txHash))
 	array := make([]string, 0)
 	if !ok {
 		array = append(array, nodeAddress)
-		cache.Set(this.RumorKey(txHash), array, GossipCacheTTL)
 	} else {
 		addresses := value.([]string)
 		array = append(addresses, nodeAddress)
 	}
-	cache.Set(this.RumorKey(txHash), array, GossipCacheTTL)
+	cache.SentDelegates.Set(this.RumorKey(txHash), array)
 }
 
 // Cache
-func (this *Gossip) Cache(cache *cache.Cache) {
-	cache.Set(this.Key(), this, GossipCacheTTL)
+func (this *Gossip) Cache(cache *Cache) {
+	cache.Gossips.Set(this.Key(), this)
 }
 
 // Persist
 func (this *Gossip) Persist(txn *badger.Txn) error{
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
 	if err != nil {
 		return err
 	}
@@ -81,7 +83,7 @@ func (this *Gossip) Persist(txn *badger.Txn) error{
 }
 
 // PersistAndCache
-func (this *Gossip) Set(txn *badger.Txn,cache *cache.Cache) error {
+func (this *Gossip) Set(txn *badger.Txn,cache *Cache) error {
 	this.Cache(cache)
 	err := this.Persist(txn)
 	if err != nil {
@@ -91,8 +93,8 @@ func (this *Gossip) Set(txn *badger.Txn,cache *cache.Cache) error {
 }
 
 //Unset
-func (this *Gossip) Unset(txn *badger.Txn,cache *cache.Cache) error {
-	cache.Delete(this.Key())
+func (this *Gossip) Unset(txn *badger.Txn,cache *Cache) error {
+	cache.Gossips.Delete(this.Key())
 	err := txn.Delete([]byte(this.Key()))
 	if err != nil {
 		return err
@@ -142,7 +144,7 @@ func (this *Gossip) Refresh(txn *badger.Txn) error {
 	if err != nil {
 		return err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromBytes(value)
 	if err != nil {
 		return err
 	}
@@ -178,8 +180,8 @@ func ToJsonByGossip(gossip Gossip) ([]byte, error) {
 }
 
 // ToGossipFromCache -
-func ToGossipFromCache(cache *cache.Cache, txHash string) (*Gossip, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-gossip-%s", txHash))
+func ToGossipFromCache(cache *Cache, txHash string) (*Gossip, error) {
+	value, ok := cache.Gossips.Get(fmt.Sprintf("table-gossip-%s", txHash))
 	if !ok{
 		return nil, ErrNotFound
 	}
@@ -197,7 +199,7 @@ func ToGossipByKey(txn *badger.Txn, key []byte) (*Gossip, error) {
 	if err != nil {
 		return nil, err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +216,7 @@ func ToGossipByTransactionHash(txn *badger.Txn, transactionHash string) (*Gossip
 	if err != nil {
 		return nil, err
 	}
-	gossip, err := ToGossipFromJson(value)
+	gossip, err := ToGossipFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +250,7 @@ func GossipPaging(page int,txn *badger.Txn) ([]*Gossip, error){
 				utils.Error(err)
 				continue
 			}
-			Gossip, err := ToGossipFromJson(value)
+			Gossip, err := ToGossipFromBytes(value)
 			if err != nil {
 				utils.Error(err)
 				continue
@@ -0,0 +1,131 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// JournalEntry - A gossip this delegate has in flight, journaled so it survives a restart
+type JournalEntry struct {
+	Gossip   *Gossip   `json:"gossip"`
+	Queued   bool      `json:"queued"`   // Reached 2/3 of rumors and is waiting on the transaction worker
+	Deadline int64     `json:"deadline"` // Milliseconds, when the queued execution timeout fires
+	Updated  time.Time `json:"updated"`
+}
+
+// Key
+func (this JournalEntry) Key() string {
+	return fmt.Sprintf("table-journal-%s", this.Gossip.Transaction.Hash)
+}
+
+// Persist
+func (this *JournalEntry) Persist(txn *badger.Txn) error {
+	this.Updated = time.Now()
+	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Unset
+func (this *JournalEntry) Unset(txn *badger.Txn) error {
+	err := txn.Delete([]byte(this.Key()))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// String
+func (this JournalEntry) String() string {
+	bytes, err := json.Marshal(this)
+	if err != nil {
+		utils.Error("unable to marshal journal entry", err)
+		return ""
+	}
+	return string(bytes)
+}
+
+// NewJournalEntry
+func NewJournalEntry(gossip *Gossip) *JournalEntry {
+	return &JournalEntry{Gossip: gossip}
+}
+
+// ToJournalEntryFromJson -
+func ToJournalEntryFromJson(payload []byte) (*JournalEntry, error) {
+	journalEntry := &JournalEntry{}
+	err := json.Unmarshal(payload, journalEntry)
+	if err != nil {
+		return nil, err
+	}
+	if journalEntry.Gossip == nil {
+		return nil, ErrInvalidRequest
+	}
+	return journalEntry, nil
+}
+
+// DeleteJournalEntry
+func DeleteJournalEntry(txn *badger.Txn, transactionHash string) error {
+	return txn.Delete([]byte(fmt.Sprintf("table-journal-%s", transactionHash)))
+}
+
+// ToJournalEntryByTransactionHash
+func ToJournalEntryByTransactionHash(txn *badger.Txn, transactionHash string) (*JournalEntry, error) {
+	item, err := txn.Get([]byte(fmt.Sprintf("table-journal-%s", transactionHash)))
+	if err != nil {
+		return nil, err
+	}
+	value, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return ToJournalEntryFromJson(value)
+}
+
+// ToJournalEntries - Returns every journal entry, oldest transaction first
+func ToJournalEntries(txn *badger.Txn) ([]*JournalEntry, error) {
+	opts := badger.DefaultIteratorOptions
+	iterator := txn.NewIterator(opts)
+	defer iterator.Close()
+	prefix := []byte("table-journal-")
+	var journalEntries = make([]*JournalEntry, 0)
+	for iterator.Seek(prefix); iterator.ValidForPrefix(prefix); iterator.Next() {
+		item := iterator.Item()
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		journalEntry, err := ToJournalEntryFromJson(value)
+		if err != nil {
+			utils.Error(fmt.Sprintf("unable to read journal entry [key=%s]", string(item.Key())), err)
+			continue
+		}
+		journalEntries = append(journalEntries, journalEntry)
+	}
+	sort.Slice(journalEntries, func(i, j int) bool {
+		return journalEntries[i].Gossip.Transaction.Time < journalEntries[j].Gossip.Transaction.Time
+	})
+	return journalEntries, nil
+}
@@ -0,0 +1,63 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+)
+
+// TestJournalEntry
+func TestJournalEntry(t *testing.T) {
+	defer destruct()
+	gossip, tx := testMockNewGossip(t)
+	journalEntry := NewJournalEntry(gossip)
+	journalEntry.Queued = true
+	journalEntry.Deadline = tx.Time + TxReceiveTimeout
+
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	err := journalEntry.Persist(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testJournalEntry, err := ToJournalEntryByTransactionHash(txn, tx.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testJournalEntry.Gossip.Transaction.Hash != tx.Hash || !testJournalEntry.Queued || testJournalEntry.Deadline != journalEntry.Deadline {
+		t.Errorf("journal entry not equal, got %s", testJournalEntry.String())
+	}
+	journalEntries, err := ToJournalEntries(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(journalEntries) != 1 {
+		t.Errorf("ToJournalEntries returning %d entries, expected 1", len(journalEntries))
+	}
+
+	err = DeleteJournalEntry(txn, tx.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journalEntries, err = ToJournalEntries(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(journalEntries) != 0 {
+		t.Error("journal entry not deleted")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+)
+
+// LruCache - A size bounded cache that evicts the least recently used entry, entries also expire after their TTL
+type LruCache struct {
+	name      string
+	size      int
+	ttl       time.Duration
+	mutex     sync.Mutex
+	lru       *simplelru.LRU
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// CacheMetrics
+type CacheMetrics struct {
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
+
+// lruEntry
+type lruEntry struct {
+	value   interface{}
+	expires time.Time // Zero never expires
+}
+
+// NewLruCache - A ttl of zero keeps entries until they are evicted or deleted
+func NewLruCache(name string, size int, ttl time.Duration) *LruCache {
+	lru, err := simplelru.NewLRU(size, nil)
+	if err != nil {
+		panic(err)
+	}
+	return &LruCache{name: name, size: size, ttl: ttl, lru: lru}
+}
+
+// Set - Adds or replaces an entry, ttl_optional overrides the cache's TTL
+func (this *LruCache) Set(key string, value interface{}, ttl_optional ...time.Duration) {
+	ttl := this.ttl
+	if len(ttl_optional) > 0 {
+		ttl = ttl_optional[0]
+	}
+	entry := &lruEntry{value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.lru.Add(key, entry) {
+		atomic.AddUint64(&this.evictions, 1)
+	}
+}
+
+// Get
+func (this *LruCache) Get(key string) (interface{}, bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	value, ok := this.lru.Get(key)
+	if !ok {
+		atomic.AddUint64(&this.misses, 1)
+		return nil, false
+	}
+	entry := value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		this.lru.Remove(key)
+		atomic.AddUint64(&this.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&this.hits, 1)
+	return entry.value, true
+}
+
+// Delete
+func (this *LruCache) Delete(key string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.lru.Remove(key)
+}
+
+// Values - Returns the unexpired values, least recently used first, without counting as hits
+func (this *LruCache) Values() []interface{} {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	now := time.Now()
+	values := make([]interface{}, 0, this.lru.Len())
+	for _, key := range this.lru.Keys() {
+		value, ok := this.lru.Peek(key)
+		if !ok {
+			continue
+		}
+		entry := value.(*lruEntry)
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			this.lru.Remove(key)
+			continue
+		}
+		values = append(values, entry.value)
+	}
+	return values
+}
+
+// Len
+func (this *LruCache) Len() int {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	return this.lru.Len()
+}
+
+// Purge
+func (this *LruCache) Purge() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.lru.Purge()
+}
+
+// Metrics
+func (this *LruCache) Metrics() CacheMetrics {
+	this.mutex.Lock()
+	size := this.lru.Len()
+	this.mutex.Unlock()
+	return CacheMetrics{
+		Name:      this.name,
+		Size:      size,
+		Capacity:  this.size,
+		Hits:      atomic.LoadUint64(&this.hits),
+		Misses:    atomic.LoadUint64(&this.misses),
+		Evictions: atomic.LoadUint64(&this.evictions),
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLruCacheEvictsLeastRecentlyUsed
+func TestLruCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLruCache("test", 2, 0)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Set("c", 3)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if value, ok := cache.Get("a"); !ok || value.(int) != 1 {
+		t.Error("expected a to be cached")
+	}
+	metrics := cache.Metrics()
+	if metrics.Size != 2 || metrics.Capacity != 2 || metrics.Evictions != 1 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+	if metrics.Hits != 2 || metrics.Misses != 1 {
+		t.Errorf("unexpected hits/misses %+v", metrics)
+	}
+}
+
+// TestLruCacheExpires
+func TestLruCacheExpires(t *testing.T) {
+	cache := NewLruCache("test", 10, time.Hour)
+	cache.Set("a", 1, time.Millisecond)
+	cache.Set("b", 2)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected a to have expired")
+	}
+	values := cache.Values()
+	if len(values) != 1 || values[0].(int) != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+// TestLruCacheDelete
+func TestLruCacheDelete(t *testing.T) {
+	cache := NewLruCache("test", 10, 0)
+	cache.Set("a", 1)
+	cache.Delete("a")
+	if _, ok := cache.Get("a"); ok {
+		t.Error("expected a to be deleted")
+	}
+	if cache.Metrics().Evictions != 0 {
+		t.Error("deletes should not count as evictions")
+	}
+}
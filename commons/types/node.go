@@ -21,8 +21,6 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
-	"strings"
 	"time"
 )
 
@@ -57,9 +55,8 @@ func (this Node) TypeKey() string {
 }
 
 //Cache
-func (this *Node) Cache(c *cache.Cache) {
-	c.Set(this.Key(), this, cache.NoExpiration)
-	c.Set(this.TypeKey(), this.Key(), cache.NoExpiration)
+func (this *Node) Cache(cache *Cache) {
+	cache.Nodes.Set(this.Key(), this)
 }
 
 //Persist
@@ -76,7 +73,7 @@ func (this *Node) Persist(txn *badger.Txn) error {
 }
 
 // PersistAndCache
-func (this *Node) Set(txn *badger.Txn, cache *cache.Cache) error {
+func (this *Node) Set(txn *badger.Txn, cache *Cache) error {
 	this.Cache(cache)
 	err := this.Persist(txn)
 	if err != nil {
@@ -86,8 +83,8 @@ func (this *Node) Set(txn *badger.Txn, cache *cache.Cache) error {
 }
 
 // Unset
-func (this *Node) Unset(txn *badger.Txn, cache *cache.Cache) error {
-	cache.Delete(this.Key())
+func (this *Node) Unset(txn *badger.Txn, cache *Cache) error {
+	cache.Nodes.Delete(this.Key())
 	err := txn.Delete([]byte(this.Key()))
 	if err != nil {
 		return err
@@ -117,8 +114,8 @@ func ToNodeFromJson(payload []byte) (*Node, error) {
 }
 
 // ToGossipFromCache -
-func ToNodeFromCache(cache *cache.Cache, address string) (*Node, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-node-%s", address))
+func ToNodeFromCache(cache *Cache, address string) (*Node, error) {
+	value, ok := cache.Nodes.Get(fmt.Sprintf("table-node-%s", address))
 	if !ok {
 		return nil, ErrNotFound
 	}
@@ -186,22 +183,12 @@ func ToNodesByType(txn *badger.Txn, tipe string) ([]*Node, error) {
 }
 
 // ToNodeByTypeFromCache -
-func ToNodesByTypeFromCache(c *cache.Cache, tipe string) ([]*Node, error) {
-	var keys []string
+func ToNodesByTypeFromCache(cache *Cache, tipe string) ([]*Node, error) {
 	var nodes []*Node
-	for i, value := range c.Items() {
-		useful := strings.HasPrefix(i, fmt.Sprintf("key-node-type-%s", tipe))
-		if useful {
-			key := value.Object.(string)
-			keys = append(keys, key)
-		}
-	}
-	for i, value := range c.Items() {
-		for _, key := range keys {
-			if i == key {
-				node := value.Object.(*Node)
-				nodes = append(nodes, node)
-			}
+	for _, value := range cache.Nodes.Values() {
+		node := value.(*Node)
+		if node.Type == tipe {
+			nodes = append(nodes, node)
 		}
 	}
 	return nodes, nil
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
 	"time"
 )
 
@@ -33,6 +32,7 @@ type Page struct {
 	ReceiptsHash 		string
 	StateHash			string
 	BWused				int64 //Bandwidth Used
+	HertzUsed			int64 // Hertz used by the page's contract transactions
 	Created 		time.Time
 }
 
@@ -44,12 +44,12 @@ func (this Page) Key() string {
 }
 
 //Cache
-func (this *Page) Cache(cache *cache.Cache,time_optional ...time.Duration){
+func (this *Page) Cache(cache *Cache,time_optional ...time.Duration){
 	TTL := PageTTL
 	if len(time_optional) > 0 {
 		TTL = time_optional[0]
 	}
-	cache.Set(this.Key(), this, TTL)
+	cache.Pages.Set(this.Key(), this, TTL)
 }
 
 //Persist
@@ -62,7 +62,7 @@ func (this *Page) Persist(txn *badger.Txn) error{
 }
 
 // PersistAndCache
-func (this *Page) Set(txn *badger.Txn,cache *cache.Cache) error {
+func (this *Page) Set(txn *badger.Txn,cache *Cache) error {
 	this.Cache(cache)
 	err := this.Persist(txn)
 	if err != nil {
@@ -72,8 +72,8 @@ func (this *Page) Set(txn *badger.Txn,cache *cache.Cache) error {
 }
 
 //Delete
-func (this *Page)Unset(txn *badger.Txn,cache *cache.Cache) error {
-	cache.Delete(this.Key())
+func (this *Page)Unset(txn *badger.Txn,cache *Cache) error {
+	cache.Pages.Delete(this.Key())
 	err := txn.Delete([]byte(this.Key()))
 	if err != nil {
 		return err
@@ -107,6 +107,9 @@ func (this *Page) UnmarshalJSON(bytes []byte) error {
 	if jsonMap["bwUsed"] != nil {
 		this.BWused = int64(jsonMap["bwUsed"].(float64))
 	}
+	if jsonMap["hertzUsed"] != nil {
+		this.HertzUsed = int64(jsonMap["hertzUsed"].(float64))
+	}
 	if jsonMap["created"] != nil {
 		created, err := time.Parse(time.RFC3339, jsonMap["created"].(string))
 		if err != nil {
@@ -126,6 +129,7 @@ func (this Page) MarshalJSON() ([]byte, error) {
 		ReceiptsHash           	string `json:"receiptsHash"`
 		StateHash 			 	string  `json:"stateHash"`
 		BWused               	int64 `json:"bwUsed"`
+		HertzUsed            	int64 `json:"hertzUsed"`
 		Created             	time.Time   `json:"created"`
 	}{
 		Hash:                   this.Hash,
@@ -134,6 +138,7 @@ func (this Page) MarshalJSON() ([]byte, error) {
 		ReceiptsHash:           this.ReceiptsHash,
 		StateHash: 				this.StateHash,
 		BWused:             	this.BWused,
+		HertzUsed:           	this.HertzUsed,
 		Created:             	this.Created,
 	})
 }
@@ -159,8 +164,8 @@ func ToPageFromJson(payload []byte) (*Page, error) {
 }
 
 // ToPageFromCache -
-func ToPageFromCache(cache *cache.Cache, number string) (*Page, error) {
-	value, ok :=cache.Get(number)
+func ToPageFromCache(cache *Cache, number string) (*Page, error) {
+	value, ok :=cache.Pages.Get(fmt.Sprintf("Page-%s", number))
 	if !ok{
 		return nil, ErrNotFound
 	}
@@ -183,4 +188,20 @@ func ToPageByKey(txn *badger.Txn, key []byte) (*Page, error) {
 		return nil, err
 	}
 	return node, err
-}
\ No newline at end of file
+}
+// ToPageNumber - The page covering a transaction time in milliseconds
+func ToPageNumber(time int64) int64 {
+	return time / PageDuration
+}
+
+// ToPageByNumber - A page that was never persisted is returned empty
+func ToPageByNumber(txn *badger.Txn, number int64) (*Page, error) {
+	page, err := ToPageByKey(txn, []byte(Page{Number: number}.Key()))
+	if err == badger.ErrKeyNotFound {
+		return &Page{Number: number, Created: time.Now()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
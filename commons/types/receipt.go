@@ -23,7 +23,6 @@ import (
 
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
 )
 
 // Receipt
@@ -33,6 +32,9 @@ type Receipt struct {
 	HumanReadableStatus string
 	ContractAddress     string
 	ContractResult      []interface{}
+	Logs                []*EventLog
+	HertzCost           int64 // Hertz used executing the transaction
+	CumulativeHertzUsed int64 // Hertz used by the transaction's page up to and including the transaction
 	Created             time.Time
 }
 
@@ -42,17 +44,21 @@ func (this Receipt) Key() string {
 }
 
 // Cache
-func (this *Receipt) Cache(cache *cache.Cache, time_optional ...time.Duration) {
+func (this *Receipt) Cache(cache *Cache, time_optional ...time.Duration) {
 	TTL := ReceiptCacheTTL
 	if len(time_optional) > 0 {
 		TTL = time_optional[0]
 	}
-	cache.Set(this.Key(), this, TTL)
+	cache.Receipts.Set(this.Key(), this, TTL)
 }
 
 // Persist
 func (this *Receipt) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
 	if err != nil {
 		return err
 	}
@@ -60,7 +66,7 @@ func (this *Receipt) Persist(txn *badger.Txn) error {
 }
 
 // Set
-func (this *Receipt) Set(txn *badger.Txn, cache *cache.Cache) error {
+func (this *Receipt) Set(txn *badger.Txn, cache *Cache) error {
 	this.Cache(cache)
 
 	err := this.Persist(txn)
@@ -71,8 +77,8 @@ func (this *Receipt) Set(txn *badger.Txn, cache *cache.Cache) error {
 }
 
 // Unset
-func (this *Receipt) Unset(txn *badger.Txn, cache *cache.Cache) error {
-	cache.Delete(this.Key())
+func (this *Receipt) Unset(txn *badger.Txn, cache *Cache) error {
+	cache.Receipts.Delete(this.Key())
 	err := txn.Delete([]byte(this.Key()))
 	if err != nil {
 		return err
@@ -103,6 +109,22 @@ func (this *Receipt) UnmarshalJSON(bytes []byte) error {
 		var contractResult = jsonMap["contractResult"]
 		this.ContractResult = contractResult.([]interface{})
 	}
+	if jsonMap["logs"] != nil {
+		var logs struct {
+			Logs []*EventLog `json:"logs"`
+		}
+		err := json.Unmarshal(bytes, &logs)
+		if err != nil {
+			return err
+		}
+		this.Logs = logs.Logs
+	}
+	if jsonMap["hertzCost"] != nil {
+		this.HertzCost = int64(jsonMap["hertzCost"].(float64))
+	}
+	if jsonMap["cumulativeHertzUsed"] != nil {
+		this.CumulativeHertzUsed = int64(jsonMap["cumulativeHertzUsed"].(float64))
+	}
 	if jsonMap["created"] != nil {
 		created, err := time.Parse(time.RFC3339, jsonMap["created"].(string))
 		if err != nil {
@@ -121,6 +143,9 @@ func (this Receipt) MarshalJSON() ([]byte, error) {
 		HumanReadableStatus string        `json:"humanReadableStatus,omitempty"`
 		ContractAddress     string        `json:"contractAddress,omitempty"`
 		ContractResult      []interface{} `json:"contractResult,omitempty"`
+		Logs                []*EventLog   `json:"logs,omitempty"`
+		HertzCost           int64         `json:"hertzCost,omitempty"`
+		CumulativeHertzUsed int64         `json:"cumulativeHertzUsed,omitempty"`
 		Created             time.Time     `json:"created"`
 	}{
 		TransactionHash:     this.TransactionHash,
@@ -128,6 +153,9 @@ func (this Receipt) MarshalJSON() ([]byte, error) {
 		HumanReadableStatus: this.HumanReadableStatus,
 		ContractAddress:     this.ContractAddress,
 		ContractResult:      this.ContractResult,
+		Logs:                this.Logs,
+		HertzCost:           this.HertzCost,
+		CumulativeHertzUsed: this.CumulativeHertzUsed,
 		Created:             this.Created,
 	})
 }
@@ -157,7 +185,12 @@ func (this *Receipt) SetInternalErrorWithNewTransaction(db *badger.DB, err error
 	defer txn.Discard()
 	this.Status = StatusInternalError
 	this.HumanReadableStatus = err.Error()
-	err = txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
+	value, err := this.MarshalBinary()
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.SetWithTTL([]byte(this.Key()), value, ReceiptCacheTTL)
 	if err != nil {
 		utils.Error(err)
 	}
@@ -172,7 +205,12 @@ func (this *Receipt) SetStatusWithNewTransaction(db *badger.DB, status string) {
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
 	this.Status = status
-	err := txn.SetWithTTL([]byte(this.Key()), []byte(this.String()), ReceiptCacheTTL)
+	value, err := this.MarshalBinary()
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.SetWithTTL([]byte(this.Key()), value, ReceiptCacheTTL)
 	if err != nil {
 		utils.Error(err)
 	}
@@ -208,8 +246,8 @@ func ToReceiptFromJson(payload []byte) (*Receipt, error) {
 }
 
 // ToReceiptFromCache -
-func ToReceiptFromCache(cache *cache.Cache, transactionHash string) (*Receipt, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-receipt-%s", transactionHash))
+func ToReceiptFromCache(cache *Cache, transactionHash string) (*Receipt, error) {
+	value, ok := cache.Receipts.Get(fmt.Sprintf("table-receipt-%s", transactionHash))
 	if !ok {
 		return nil, ErrNotFound
 	}
@@ -227,7 +265,7 @@ func ToReceiptFromKey(txn *badger.Txn, key []byte) (*Receipt, error) {
 	if err != nil {
 		return nil, err
 	}
-	receipt, err := ToReceiptFromJson(value)
+	receipt, err := ToReceiptFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
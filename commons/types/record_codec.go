@@ -0,0 +1,618 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Persisted records are a two byte header, recordMagic and the encoding version, followed by a protobuf message
+// described in records.proto. JSON records written before the binary encoding always start with '{', so the two
+// can live side by side in the same table until the schema migration rewrites them.
+const (
+	recordMagic           byte = 0xd1
+	RecordEncodingVersion byte = 1
+)
+
+// ErrUnknownRecordEncoding
+var ErrUnknownRecordEncoding = errors.New("unknown record encoding version")
+
+// IsBinaryRecord - Returns true if the payload was written with the binary record encoding
+func IsBinaryRecord(payload []byte) bool {
+	return len(payload) >= 2 && payload[0] == recordMagic
+}
+
+// encodeRecord
+func encodeRecord(message proto.Message) ([]byte, error) {
+	bytes, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{recordMagic, RecordEncodingVersion}, bytes...), nil
+}
+
+// decodeRecord
+func decodeRecord(payload []byte, message proto.Message) error {
+	if !IsBinaryRecord(payload) {
+		return ErrUnknownRecordEncoding
+	}
+	if payload[1] != RecordEncodingVersion {
+		return errors.Wrapf(ErrUnknownRecordEncoding, "version %d", payload[1])
+	}
+	return proto.Unmarshal(payload[2:], message)
+}
+
+// encodeTime
+func encodeTime(t time.Time) []byte {
+	bytes, err := t.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return bytes
+}
+
+// decodeTime
+func decodeTime(bytes []byte) (time.Time, error) {
+	var t time.Time
+	if len(bytes) == 0 {
+		return t, nil
+	}
+	err := t.UnmarshalBinary(bytes)
+	return t, err
+}
+
+// encodeValues - Contract params and results are dynamically typed, they keep their JSON form inside the record
+func encodeValues(values []interface{}) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(values)
+}
+
+// decodeValues
+func decodeValues(bytes []byte) ([]interface{}, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+	var values []interface{}
+	err := json.Unmarshal(bytes, &values)
+	return values, err
+}
+
+// decodeNumberValues - Keeps numbers as written, for values that are hashed
+func decodeNumberValues(bytes []byte) ([]interface{}, error) {
+	if len(bytes) == 0 {
+		return nil, nil
+	}
+	var values []interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	err := decoder.Decode(&values)
+	return values, err
+}
+
+// transactionRecord - Transients (receipt, gossip and names) are not persisted
+type transactionRecord struct {
+	Hash      string `protobuf:"bytes,1,opt,name=hash,proto3"`
+	Type      uint32 `protobuf:"varint,2,opt,name=type,proto3"`
+	From      string `protobuf:"bytes,3,opt,name=from,proto3"`
+	To        string `protobuf:"bytes,4,opt,name=to,proto3"`
+	Value     int64  `protobuf:"varint,5,opt,name=value,proto3"`
+	Code      string `protobuf:"bytes,6,opt,name=code,proto3"`
+	Abi       string `protobuf:"bytes,7,opt,name=abi,proto3"`
+	Method    string `protobuf:"bytes,8,opt,name=method,proto3"`
+	Params    []byte `protobuf:"bytes,9,opt,name=params,proto3"`
+	Time      int64  `protobuf:"varint,10,opt,name=time,proto3"`
+	Signature string `protobuf:"bytes,11,opt,name=signature,proto3"`
+	Hertz     int64  `protobuf:"varint,12,opt,name=hertz,proto3"`
+	Source    string `protobuf:"bytes,13,opt,name=source,proto3"`
+	Metadata  string `protobuf:"bytes,14,opt,name=metadata,proto3"`
+}
+
+func (m *transactionRecord) Reset()         { *m = transactionRecord{} }
+func (m *transactionRecord) String() string { return proto.CompactTextString(m) }
+func (*transactionRecord) ProtoMessage()    {}
+
+// accountRecord
+type accountRecord struct {
+	Address         string `protobuf:"bytes,1,opt,name=address,proto3"`
+	PrivateKey      string `protobuf:"bytes,2,opt,name=privateKey,proto3"`
+	Name            string `protobuf:"bytes,3,opt,name=name,proto3"`
+	Balance         []byte `protobuf:"bytes,4,opt,name=balance,proto3"`
+	TransactionHash string `protobuf:"bytes,5,opt,name=transactionHash,proto3"`
+	Updated         []byte `protobuf:"bytes,6,opt,name=updated,proto3"`
+	Created         []byte `protobuf:"bytes,7,opt,name=created,proto3"`
+	Nonce           uint64 `protobuf:"varint,8,opt,name=nonce,proto3"`
+}
+
+func (m *accountRecord) Reset()         { *m = accountRecord{} }
+func (m *accountRecord) String() string { return proto.CompactTextString(m) }
+func (*accountRecord) ProtoMessage()    {}
+
+// receiptRecord
+type receiptRecord struct {
+	TransactionHash     string            `protobuf:"bytes,1,opt,name=transactionHash,proto3"`
+	Status              string            `protobuf:"bytes,2,opt,name=status,proto3"`
+	HumanReadableStatus string            `protobuf:"bytes,3,opt,name=humanReadableStatus,proto3"`
+	ContractAddress     string            `protobuf:"bytes,4,opt,name=contractAddress,proto3"`
+	ContractResult      []byte            `protobuf:"bytes,5,opt,name=contractResult,proto3"`
+	Created             []byte            `protobuf:"bytes,6,opt,name=created,proto3"`
+	Logs                []*eventLogRecord `protobuf:"bytes,7,rep,name=logs,proto3"`
+	HertzCost           int64             `protobuf:"varint,8,opt,name=hertzCost,proto3"`
+	CumulativeHertzUsed int64             `protobuf:"varint,9,opt,name=cumulativeHertzUsed,proto3"`
+}
+
+func (m *receiptRecord) Reset()         { *m = receiptRecord{} }
+func (m *receiptRecord) String() string { return proto.CompactTextString(m) }
+func (*receiptRecord) ProtoMessage()    {}
+
+// eventLogRecord
+type eventLogRecord struct {
+	ContractAddress string   `protobuf:"bytes,1,opt,name=contractAddress,proto3"`
+	TransactionHash string   `protobuf:"bytes,2,opt,name=transactionHash,proto3"`
+	Index           int64    `protobuf:"varint,3,opt,name=index,proto3"`
+	Time            int64    `protobuf:"varint,4,opt,name=time,proto3"`
+	Topics          []string `protobuf:"bytes,5,rep,name=topics,proto3"`
+	Data            string   `protobuf:"bytes,6,opt,name=data,proto3"`
+}
+
+func (m *eventLogRecord) Reset()         { *m = eventLogRecord{} }
+func (m *eventLogRecord) String() string { return proto.CompactTextString(m) }
+func (*eventLogRecord) ProtoMessage()    {}
+
+// rumorRecord
+type rumorRecord struct {
+	Hash            string `protobuf:"bytes,1,opt,name=hash,proto3"`
+	Address         string `protobuf:"bytes,2,opt,name=address,proto3"`
+	TransactionHash string `protobuf:"bytes,3,opt,name=transactionHash,proto3"`
+	Time            int64  `protobuf:"varint,4,opt,name=time,proto3"`
+	Signature       string `protobuf:"bytes,5,opt,name=signature,proto3"`
+}
+
+func (m *rumorRecord) Reset()         { *m = rumorRecord{} }
+func (m *rumorRecord) String() string { return proto.CompactTextString(m) }
+func (*rumorRecord) ProtoMessage()    {}
+
+// gossipRecord
+type gossipRecord struct {
+	Transaction *transactionRecord `protobuf:"bytes,1,opt,name=transaction,proto3"`
+	Rumors      []*rumorRecord     `protobuf:"bytes,2,rep,name=rumors,proto3"`
+}
+
+func (m *gossipRecord) Reset()         { *m = gossipRecord{} }
+func (m *gossipRecord) String() string { return proto.CompactTextString(m) }
+func (*gossipRecord) ProtoMessage()    {}
+
+// contractRecord - The method catalog is not persisted
+type contractRecord struct {
+	Address         string `protobuf:"bytes,1,opt,name=address,proto3"`
+	Deployer        string `protobuf:"bytes,2,opt,name=deployer,proto3"`
+	TransactionHash string `protobuf:"bytes,3,opt,name=transactionHash,proto3"`
+	Abi             string `protobuf:"bytes,4,opt,name=abi,proto3"`
+	CodeHash        string `protobuf:"bytes,5,opt,name=codeHash,proto3"`
+	Source          string `protobuf:"bytes,6,opt,name=source,proto3"`
+	Metadata        string `protobuf:"bytes,7,opt,name=metadata,proto3"`
+	Time            int64  `protobuf:"varint,8,opt,name=time,proto3"`
+}
+
+func (m *contractRecord) Reset()         { *m = contractRecord{} }
+func (m *contractRecord) String() string { return proto.CompactTextString(m) }
+func (*contractRecord) ProtoMessage()    {}
+
+// toTransactionRecord
+func toTransactionRecord(transaction *Transaction) (*transactionRecord, error) {
+	params, err := encodeValues(transaction.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionRecord{
+		Hash:      transaction.Hash,
+		Type:      uint32(transaction.Type),
+		From:      transaction.From,
+		To:        transaction.To,
+		Value:     transaction.Value,
+		Code:      transaction.Code,
+		Abi:       transaction.Abi,
+		Method:    transaction.Method,
+		Params:    params,
+		Time:      transaction.Time,
+		Signature: transaction.Signature,
+		Hertz:     transaction.Hertz,
+		Source:    transaction.Source,
+		Metadata:  transaction.Metadata,
+	}, nil
+}
+
+// fromTransactionRecord
+func fromTransactionRecord(record *transactionRecord) (*Transaction, error) {
+	decode := decodeValues
+	if byte(record.Type) == TypeDeploySmartContract {
+		decode = decodeNumberValues
+	}
+	params, err := decode(record.Params)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Hash:      record.Hash,
+		Type:      byte(record.Type),
+		From:      record.From,
+		To:        record.To,
+		Value:     record.Value,
+		Code:      record.Code,
+		Abi:       record.Abi,
+		Method:    record.Method,
+		Params:    params,
+		Time:      record.Time,
+		Signature: record.Signature,
+		Hertz:     record.Hertz,
+		Source:    record.Source,
+		Metadata:  record.Metadata,
+	}, nil
+}
+
+// toRumorRecords
+func toRumorRecords(rumors []Rumor) []*rumorRecord {
+	records := make([]*rumorRecord, len(rumors))
+	for i, rumor := range rumors {
+		records[i] = &rumorRecord{Hash: rumor.Hash, Address: rumor.Address, TransactionHash: rumor.TransactionHash, Time: rumor.Time, Signature: rumor.Signature}
+	}
+	return records
+}
+
+// fromRumorRecords
+func fromRumorRecords(records []*rumorRecord) []Rumor {
+	rumors := make([]Rumor, len(records))
+	for i, record := range records {
+		rumors[i] = Rumor{Hash: record.Hash, Address: record.Address, TransactionHash: record.TransactionHash, Time: record.Time, Signature: record.Signature}
+	}
+	return rumors
+}
+
+// MarshalBinary
+func (this Transaction) MarshalBinary() ([]byte, error) {
+	record, err := toTransactionRecord(&this)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRecord(record)
+}
+
+// UnmarshalBinary
+func (this *Transaction) UnmarshalBinary(payload []byte) error {
+	record := &transactionRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	transaction, err := fromTransactionRecord(record)
+	if err != nil {
+		return err
+	}
+	*this = *transaction
+	return nil
+}
+
+// MarshalBinary
+func (this Account) MarshalBinary() ([]byte, error) {
+	record := &accountRecord{
+		Address:         this.Address,
+		PrivateKey:      this.PrivateKey,
+		Name:            this.Name,
+		TransactionHash: this.TransactionHash,
+		Updated:         encodeTime(this.Updated),
+		Created:         encodeTime(this.Created),
+		Nonce:           this.Nonce,
+	}
+	if this.Balance != nil {
+		balance, err := this.Balance.GobEncode()
+		if err != nil {
+			return nil, err
+		}
+		record.Balance = balance
+	}
+	return encodeRecord(record)
+}
+
+// UnmarshalBinary
+func (this *Account) UnmarshalBinary(payload []byte) error {
+	record := &accountRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	balance := big.NewInt(0)
+	if len(record.Balance) > 0 {
+		err = balance.GobDecode(record.Balance)
+		if err != nil {
+			return err
+		}
+	}
+	updated, err := decodeTime(record.Updated)
+	if err != nil {
+		return err
+	}
+	created, err := decodeTime(record.Created)
+	if err != nil {
+		return err
+	}
+	*this = Account{
+		Address:         record.Address,
+		PrivateKey:      record.PrivateKey,
+		Name:            record.Name,
+		Balance:         balance,
+		TransactionHash: record.TransactionHash,
+		Updated:         updated,
+		Created:         created,
+		Nonce:           record.Nonce,
+	}
+	return nil
+}
+
+// MarshalBinary
+func (this Receipt) MarshalBinary() ([]byte, error) {
+	contractResult, err := encodeValues(this.ContractResult)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRecord(&receiptRecord{
+		TransactionHash:     this.TransactionHash,
+		Status:              this.Status,
+		HumanReadableStatus: this.HumanReadableStatus,
+		ContractAddress:     this.ContractAddress,
+		ContractResult:      contractResult,
+		Created:             encodeTime(this.Created),
+		Logs:                toEventLogRecords(this.Logs),
+		HertzCost:           this.HertzCost,
+		CumulativeHertzUsed: this.CumulativeHertzUsed,
+	})
+}
+
+// UnmarshalBinary
+func (this *Receipt) UnmarshalBinary(payload []byte) error {
+	record := &receiptRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	contractResult, err := decodeValues(record.ContractResult)
+	if err != nil {
+		return err
+	}
+	created, err := decodeTime(record.Created)
+	if err != nil {
+		return err
+	}
+	*this = Receipt{
+		TransactionHash:     record.TransactionHash,
+		Status:              record.Status,
+		HumanReadableStatus: record.HumanReadableStatus,
+		ContractAddress:     record.ContractAddress,
+		ContractResult:      contractResult,
+		Created:             created,
+		Logs:                toEventLogs(record.Logs),
+		HertzCost:           record.HertzCost,
+		CumulativeHertzUsed: record.CumulativeHertzUsed,
+	}
+	return nil
+}
+
+// MarshalBinary
+func (this EventLog) MarshalBinary() ([]byte, error) {
+	return encodeRecord(toEventLogRecord(&this))
+}
+
+// UnmarshalBinary
+func (this *EventLog) UnmarshalBinary(payload []byte) error {
+	record := &eventLogRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	*this = *toEventLog(record)
+	return nil
+}
+
+// toEventLogRecord
+func toEventLogRecord(eventLog *EventLog) *eventLogRecord {
+	return &eventLogRecord{
+		ContractAddress: eventLog.ContractAddress,
+		TransactionHash: eventLog.TransactionHash,
+		Index:           int64(eventLog.Index),
+		Time:            eventLog.Time,
+		Topics:          eventLog.Topics,
+		Data:            eventLog.Data,
+	}
+}
+
+// toEventLog
+func toEventLog(record *eventLogRecord) *EventLog {
+	return &EventLog{
+		ContractAddress: record.ContractAddress,
+		TransactionHash: record.TransactionHash,
+		Index:           int(record.Index),
+		Time:            record.Time,
+		Topics:          record.Topics,
+		Data:            record.Data,
+	}
+}
+
+// toEventLogRecords
+func toEventLogRecords(eventLogs []*EventLog) []*eventLogRecord {
+	if len(eventLogs) == 0 {
+		return nil
+	}
+	records := make([]*eventLogRecord, 0, len(eventLogs))
+	for _, eventLog := range eventLogs {
+		records = append(records, toEventLogRecord(eventLog))
+	}
+	return records
+}
+
+// toEventLogs
+func toEventLogs(records []*eventLogRecord) []*EventLog {
+	if len(records) == 0 {
+		return nil
+	}
+	eventLogs := make([]*EventLog, 0, len(records))
+	for _, record := range records {
+		eventLogs = append(eventLogs, toEventLog(record))
+	}
+	return eventLogs
+}
+
+// MarshalBinary
+func (this Gossip) MarshalBinary() ([]byte, error) {
+	transaction, err := toTransactionRecord(&this.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRecord(&gossipRecord{Transaction: transaction, Rumors: toRumorRecords(this.Rumors)})
+}
+
+// UnmarshalBinary
+func (this *Gossip) UnmarshalBinary(payload []byte) error {
+	record := &gossipRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	transaction := &Transaction{}
+	if record.Transaction != nil {
+		transaction, err = fromTransactionRecord(record.Transaction)
+		if err != nil {
+			return err
+		}
+	}
+	*this = Gossip{Transaction: *transaction, Rumors: fromRumorRecords(record.Rumors)}
+	return nil
+}
+
+// MarshalBinary
+func (this Contract) MarshalBinary() ([]byte, error) {
+	return encodeRecord(&contractRecord{
+		Address:         this.Address,
+		Deployer:        this.Deployer,
+		TransactionHash: this.TransactionHash,
+		Abi:             this.Abi,
+		CodeHash:        this.CodeHash,
+		Source:          this.Source,
+		Metadata:        this.Metadata,
+		Time:            this.Time,
+	})
+}
+
+// UnmarshalBinary
+func (this *Contract) UnmarshalBinary(payload []byte) error {
+	record := &contractRecord{}
+	err := decodeRecord(payload, record)
+	if err != nil {
+		return err
+	}
+	*this = Contract{
+		Address:         record.Address,
+		Deployer:        record.Deployer,
+		TransactionHash: record.TransactionHash,
+		Abi:             record.Abi,
+		CodeHash:        record.CodeHash,
+		Source:          record.Source,
+		Metadata:        record.Metadata,
+		Time:            record.Time,
+	}
+	return nil
+}
+
+// ToTransactionFromBytes - Decodes a persisted transaction in either the binary or the legacy JSON encoding
+func ToTransactionFromBytes(payload []byte) (*Transaction, error) {
+	if !IsBinaryRecord(payload) {
+		return ToTransactionFromJson(payload)
+	}
+	transaction := &Transaction{}
+	err := transaction.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// ToAccountFromBytes - Decodes a persisted account in either the binary or the legacy JSON encoding
+func ToAccountFromBytes(payload []byte) (*Account, error) {
+	if !IsBinaryRecord(payload) {
+		return ToAccountFromJson(payload)
+	}
+	account := &Account{}
+	err := account.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ToReceiptFromBytes - Decodes a persisted receipt in either the binary or the legacy JSON encoding
+func ToReceiptFromBytes(payload []byte) (*Receipt, error) {
+	if !IsBinaryRecord(payload) {
+		return ToReceiptFromJson(payload)
+	}
+	receipt := &Receipt{}
+	err := receipt.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// ToGossipFromBytes - Decodes a persisted gossip in either the binary or the legacy JSON encoding
+func ToGossipFromBytes(payload []byte) (*Gossip, error) {
+	if !IsBinaryRecord(payload) {
+		return ToGossipFromJson(payload)
+	}
+	gossip := &Gossip{}
+	err := gossip.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return gossip, nil
+}
+
+// ToEventLogFromBytes
+func ToEventLogFromBytes(payload []byte) (*EventLog, error) {
+	if !IsBinaryRecord(payload) {
+		return ToEventLogFromJson(payload)
+	}
+	eventLog := &EventLog{}
+	err := eventLog.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return eventLog, nil
+}
+
+// ToContractFromBytes - Contracts were only ever persisted in the binary encoding
+func ToContractFromBytes(payload []byte) (*Contract, error) {
+	contract := &Contract{}
+	err := contract.UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
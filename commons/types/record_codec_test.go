@@ -0,0 +1,179 @@
+/*
+ *    This file is part of Disgo-Commons library.
+ *
+ *    The Disgo-Commons library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo-Commons library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo-Commons library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package types
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testRecordTransaction = &Transaction{
+	Hash:      "a48ff2bd1fb99d9170e2bae2f4ed94ed79dbc8c1002986f8054a369655e29276",
+	Type:      TypeExecuteSmartContract,
+	From:      "e6098cc0d5c20c6c31c4d69f0201a02975264e94",
+	To:        "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
+	Abi:       `[{"constant":false,"inputs":[{"name":"x","type":"uint256"}],"name":"setVar5","outputs":[],"payable":false,"type":"function"}]`,
+	Method:    "setVar5",
+	Params:    []interface{}{float64(5), "five"},
+	Time:      1531148645000,
+	Signature: "03c1fdb91cd10aa441e0025dd21def5ebe045762c1eeea0f6a3f7e63b27deb9c40e08b656a744f6c69c55f7cb41751eebd49c1eedfbd10b861834f0352c510b200",
+	Hertz:     10,
+}
+
+// TestTransactionBinaryRecord
+func TestTransactionBinaryRecord(t *testing.T) {
+	bytes, err := testRecordTransaction.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsBinaryRecord(bytes) {
+		t.Error("transaction not written as a binary record")
+	}
+	transaction, err := ToTransactionFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(transaction, testRecordTransaction) {
+		t.Errorf("transaction not equal, got %s", transaction.String())
+	}
+
+	// Legacy JSON still reads.
+	transaction, err = ToTransactionFromBytes([]byte(testRecordTransaction.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Hash != testRecordTransaction.Hash || transaction.Method != testRecordTransaction.Method {
+		t.Errorf("transaction not equal, got %s", transaction.String())
+	}
+}
+
+// TestAccountBinaryRecord
+func TestAccountBinaryRecord(t *testing.T) {
+	now := time.Now().UTC().Round(0)
+	account := &Account{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Name: "Dispatch Labs", Balance: big.NewInt(10000000), TransactionHash: "abc", Updated: now, Created: now, Nonce: 3}
+	bytes, err := account.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAccount, err := ToAccountFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testAccount, account) {
+		t.Errorf("account not equal, got %s", testAccount.String())
+	}
+}
+
+// TestReceiptBinaryRecord
+func TestReceiptBinaryRecord(t *testing.T) {
+	receipt := NewReceiptWithStatus("abc", StatusOk, "")
+	receipt.Created = receipt.Created.UTC().Round(0)
+	receipt.ContractResult = []interface{}{"ok", float64(1)}
+	receipt.HertzCost = 21000
+	receipt.CumulativeHertzUsed = 42000
+	bytes, err := receipt.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testReceipt, err := ToReceiptFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testReceipt, receipt) {
+		t.Errorf("receipt not equal, got %s", testReceipt.String())
+	}
+}
+
+// TestGossipBinaryRecord
+func TestGossipBinaryRecord(t *testing.T) {
+	gossip := NewGossip(*testRecordTransaction)
+	gossip.Rumors = append(gossip.Rumors, Rumor{Hash: "def", Address: "e6098cc0d5c20c6c31c4d69f0201a02975264e94", TransactionHash: testRecordTransaction.Hash, Time: 1531148645001, Signature: "ghi"})
+	bytes, err := gossip.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGossip, err := ToGossipFromBytes(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(testGossip, gossip) {
+		t.Errorf("gossip not equal, got %s", testGossip.String())
+	}
+}
+
+// TestUnknownRecordEncoding
+func TestUnknownRecordEncoding(t *testing.T) {
+	bytes, _ := testRecordTransaction.MarshalBinary()
+	bytes[1] = RecordEncodingVersion + 1
+	if _, err := ToTransactionFromBytes(bytes); err == nil {
+		t.Error("ToTransactionFromBytes accepting an unknown encoding version")
+	}
+}
+
+// BenchmarkTransactionWriteJson
+func BenchmarkTransactionWriteJson(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = []byte(testRecordTransaction.String())
+	}
+}
+
+// BenchmarkTransactionWriteBinary
+func BenchmarkTransactionWriteBinary(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		testRecordTransaction.MarshalBinary()
+	}
+}
+
+// BenchmarkTransactionReadJson
+func BenchmarkTransactionReadJson(b *testing.B) {
+	bytes := []byte(testRecordTransaction.String())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToTransactionFromBytes(bytes)
+	}
+}
+
+// BenchmarkTransactionReadBinary
+func BenchmarkTransactionReadBinary(b *testing.B) {
+	bytes, _ := testRecordTransaction.MarshalBinary()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToTransactionFromBytes(bytes)
+	}
+}
+
+// BenchmarkAccountReadJson
+func BenchmarkAccountReadJson(b *testing.B) {
+	account := &Account{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Name: "Dispatch Labs", Balance: big.NewInt(10000000), Updated: time.Now(), Created: time.Now()}
+	bytes := []byte(account.String())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToAccountFromBytes(bytes)
+	}
+}
+
+// BenchmarkAccountReadBinary
+func BenchmarkAccountReadBinary(b *testing.B) {
+	account := &Account{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Name: "Dispatch Labs", Balance: big.NewInt(10000000), Updated: time.Now(), Created: time.Now()}
+	bytes, _ := account.MarshalBinary()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToAccountFromBytes(bytes)
+	}
+}
@@ -30,7 +30,6 @@ import (
 	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
 )
 
@@ -48,6 +47,8 @@ type Transaction struct {
 	Time      int64 // Milliseconds
 	Signature string
 	Hertz     int64   //our version of Gas
+	Source    string  // Optional, the contract source a deployer publishes with its deploy
+	Metadata  string  // Optional, the compiler metadata JSON a deployer publishes with its deploy
 	Receipt   Receipt // Transient
 	Gossip    []Rumor // Transient
 	FromName  string  // Transient
@@ -80,13 +81,22 @@ func (this Transaction) ToKey() string {
 }
 
 //Cache
-func (this *Transaction) Cache(cache *cache.Cache) {
-	cache.Set(this.Key(), this, TransactionCacheTTL)
+func (this *Transaction) Cache(cache *Cache) {
+	cache.Transactions.Set(this.Key(), this)
+}
+
+// Uncache
+func (this *Transaction) Uncache(cache *Cache) {
+	cache.Transactions.Delete(this.Key())
 }
 
 // Persist
 func (this *Transaction) Persist(txn *badger.Txn) error {
-	err := txn.Set([]byte(this.Key()), []byte(this.String()))
+	value, err := this.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = txn.Set([]byte(this.Key()), value)
 	if err != nil {
 		return err
 	}
@@ -110,7 +120,7 @@ func (this *Transaction) Persist(txn *badger.Txn) error {
 }
 
 // PersistAndCache
-func (this *Transaction) Set(txn *badger.Txn, cache *cache.Cache) error {
+func (this *Transaction) Set(txn *badger.Txn, cache *Cache) error {
 	this.Cache(cache)
 
 	err := this.Persist(txn)
@@ -178,8 +188,8 @@ func ToTransactionFromJson(payload []byte) (*Transaction, error) {
 }
 
 // ToTransactionFromCache -
-func ToTransactionFromCache(cache *cache.Cache, hash string) (*Transaction, error) {
-	value, ok := cache.Get(fmt.Sprintf("table-transaction-%s", hash))
+func ToTransactionFromCache(cache *Cache, hash string) (*Transaction, error) {
+	value, ok := cache.Transactions.Get(fmt.Sprintf("table-transaction-%s", hash))
 	if !ok {
 		return nil, ErrNotFound
 	}
@@ -207,7 +217,7 @@ func ToTransactions(txn *badger.Txn) ([]*Transaction, error) {
 		if err != nil {
 			return nil, err
 		}
-		transaction, err := ToTransactionFromJson(value)
+		transaction, err := ToTransactionFromBytes(value)
 		if err != nil {
 			return nil, err
 		}
@@ -505,7 +515,7 @@ func ToTransactionByHash(txn *badger.Txn, hash string) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -523,7 +533,7 @@ func ToTransactionByKey(txn *badger.Txn, key []byte) (*Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +558,7 @@ func ToTransactionByAddress(txn *badger.Txn, address string) (*Transaction, erro
 	if err != nil {
 		return nil, err
 	}
-	transaction, err := ToTransactionFromJson(value)
+	transaction, err := ToTransactionFromBytes(value)
 	if err != nil {
 		return nil, err
 	}
@@ -580,6 +590,18 @@ func NewTransferTokensTransaction(privateKey string, from, to string, value int6
 
 // NewDeployContractTransaction -
 func NewDeployContractTransaction(privateKey string, from string, code string, abi string, timeInMiliseconds int64) (*Transaction, error) {
+	return NewDeployContractTransactionWithValue(privateKey, from, code, abi, 0, timeInMiliseconds)
+}
+
+// NewDeployContractTransactionWithValue - Deploys a contract with value tokens sent to its payable constructor, params are
+// the constructor arguments, they are ABI encoded after the code when the contract is deployed
+func NewDeployContractTransactionWithValue(privateKey string, from string, code string, abi string, value int64, timeInMiliseconds int64, params ...interface{}) (*Transaction, error) {
+	return NewDeployContractTransactionWithSource(privateKey, from, code, abi, "", "", value, timeInMiliseconds, params...)
+}
+
+// NewDeployContractTransactionWithSource - Deploys a contract publishing its source and compiler metadata JSON, both are
+// optional and signed with the deploy
+func NewDeployContractTransactionWithSource(privateKey string, from string, code string, abi string, source string, metadata string, value int64, timeInMiliseconds int64, params ...interface{}) (*Transaction, error) {
 	if abi == "" {
 		return nil, errors.Errorf("cannot have empty abi")
 	}
@@ -591,8 +613,14 @@ func NewDeployContractTransaction(privateKey string, from string, code string, a
 	transaction.Type = TypeDeploySmartContract
 	transaction.From = from
 	transaction.To = ""
+	transaction.Value = value
 	transaction.Code = code
 	transaction.Abi = abi
+	transaction.Source = source
+	transaction.Metadata = metadata
+	if len(params) > 0 {
+		transaction.Params = params
+	}
 	transaction.Time, err = checkTime(timeInMiliseconds)
 	if err != nil {
 		return nil, err
@@ -610,6 +638,11 @@ func NewDeployContractTransaction(privateKey string, from string, code string, a
 
 // NewExecuteContractTransaction -
 func NewExecuteContractTransaction(privateKey string, from string, to string, method string, params []interface{}, timeInMiliseconds int64) (*Transaction, error) {
+	return NewExecuteContractTransactionWithValue(privateKey, from, to, method, params, 0, timeInMiliseconds)
+}
+
+// NewExecuteContractTransactionWithValue - Executes a contract method with value tokens sent to it, the method must be payable
+func NewExecuteContractTransactionWithValue(privateKey string, from string, to string, method string, params []interface{}, value int64, timeInMiliseconds int64) (*Transaction, error) {
 	if method == "" {
 		return nil, errors.Errorf("cannot have empty method")
 	}
@@ -618,6 +651,7 @@ func NewExecuteContractTransaction(privateKey string, from string, to string, me
 	transaction.Type = TypeExecuteSmartContract
 	transaction.From = from
 	transaction.To = to
+	transaction.Value = value
 	transaction.Method = method
 	transaction.Params = params
 	transaction.Time, err = checkTime(timeInMiliseconds)
@@ -663,6 +697,21 @@ func (this Transaction) NewHash() (string, error) {
 		// TODO: this.Params,
 		this.Time,
 	}
+
+	// Constructor arguments are part of what a deploy deploys.
+	if this.Type == TypeDeploySmartContract && len(this.Params) > 0 {
+		paramsBytes, err := json.Marshal(this.Params)
+		if err != nil {
+			utils.Error("unable to marshal params", err)
+			return "", err
+		}
+		values = append(values, paramsBytes)
+	}
+
+	// Published source and metadata are signed by the deployer, a deploy without them keeps its hash.
+	if this.Type == TypeDeploySmartContract && (this.Source != "" || this.Metadata != "") {
+		values = append(values, crypto.NewHash([]byte(this.Source)), crypto.NewHash([]byte(this.Metadata)))
+	}
 	buffer := new(bytes.Buffer)
 	for _, value := range values {
 		err := binary.Write(buffer, binary.LittleEndian, value)
@@ -675,6 +724,42 @@ func (this Transaction) NewHash() (string, error) {
 	return hex.EncodeToString(hash[:]), nil
 }
 
+// HertzLimit - The most Hertz executing the transaction may use, DefaultHertzLimit when Hertz is not set
+func (this Transaction) HertzLimit() int64 {
+	if this.Hertz <= 0 {
+		return DefaultHertzLimit
+	}
+	return this.Hertz
+}
+
+// AccessSet - The state executing the transaction reads and writes. A transfer only touches its from and to accounts,
+// contracts touch everything as they share the world state root and page hertz, and can read or move any balance.
+func (this Transaction) AccessSet() *AccessSet {
+	if this.Type == TypeDeploySmartContract || this.Type == TypeExecuteSmartContract {
+		return &AccessSet{All: true}
+	}
+	return &AccessSet{Addresses: map[string]bool{this.From: true, this.To: true}}
+}
+
+// AccessSet - Accounts a transaction reads and writes, transactions whose access sets do not conflict can execute concurrently
+type AccessSet struct {
+	All       bool
+	Addresses map[string]bool
+}
+
+// Conflicts - Does either access set touch state the other does?
+func (this AccessSet) Conflicts(other *AccessSet) bool {
+	if this.All || other.All {
+		return true
+	}
+	for address := range other.Addresses {
+		if this.Addresses[address] {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSignature
 func (this Transaction) NewSignature(privateKey string) (string, error) {
 	hashBytes, err := hex.DecodeString(this.Hash)
@@ -729,16 +814,35 @@ func (this Transaction) Verify() error {
 		if len(this.Abi) == 0 {
 			return errors.New("invalid abi")
 		}
+		if this.Value < 0 {
+			return errors.New("value cannot be less than zero")
+		}
+		if len(this.Metadata) != 0 && !json.Valid([]byte(this.Metadata)) {
+			return errors.New("invalid metadata")
+		}
 		break
 	case TypeExecuteSmartContract:
 		if len(this.To) != crypto.AddressLength*2 {
 			return errors.New("invalid to address")
 		}
+		if this.Value < 0 {
+			return errors.New("value cannot be less than zero")
+		}
 
 		// TODO: Should we check method?
 		break
 	}
 
+	// Source and metadata are only published with a deploy.
+	if this.Type != TypeDeploySmartContract && (len(this.Source) != 0 || len(this.Metadata) != 0) {
+		return errors.New("source and metadata can only be published with a deployment of a smart contract")
+	}
+
+	// Hertz?
+	if this.Hertz < 0 || this.Hertz > PageHertzLimit {
+		return errors.Errorf("hertz must be between 0 and %d", PageHertzLimit)
+	}
+
 	// Hash ok?
 	hash, err := this.NewHash()
 	if err != nil {
@@ -866,6 +970,20 @@ func (this *Transaction) UnmarshalJSON(bytes []byte) error {
 			return errors.Errorf("value for field 'params' must be an array")
 		}
 		this.Params = params
+
+		// Deploy params are hashed as JSON, numbers are kept as written.
+		if this.Type == TypeDeploySmartContract {
+			var withNumbers struct {
+				Params []interface{} `json:"params"`
+			}
+			decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+			decoder.UseNumber()
+			error = decoder.Decode(&withNumbers)
+			if error != nil {
+				return error
+			}
+			this.Params = withNumbers.Params
+		}
 	}
 	if jsonMap["time"] != nil {
 		t, ok := jsonMap["time"].(float64)
@@ -885,12 +1003,24 @@ func (this *Transaction) UnmarshalJSON(bytes []byte) error {
 		}
 	}
 	if jsonMap["hertz"] != nil {
-		hertz, ok := jsonMap["type"].(float64)
+		hertz, ok := jsonMap["hertz"].(float64)
 		if !ok {
 			return errors.Errorf("value for field 'hertz' must be a number")
 		}
 		this.Hertz = int64(hertz)
 	}
+	if jsonMap["source"] != nil {
+		this.Source, ok = jsonMap["source"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'source' must be a string")
+		}
+	}
+	if jsonMap["metadata"] != nil {
+		this.Metadata, ok = jsonMap["metadata"].(string)
+		if !ok {
+			return errors.Errorf("value for field 'metadata' must be a string")
+		}
+	}
 	if jsonMap["receipt"] != nil {
 		var receipt Receipt
 		b, err := json.Marshal(jsonMap["receipt"])
@@ -918,6 +1048,8 @@ func (this Transaction) MarshalJSON() ([]byte, error) {
 		Time      int64         `json:"time"`
 		Signature string        `json:"signature"`
 		Hertz     int64         `json:"hertz"`
+		Source    string        `json:"source,omitempty"`
+		Metadata  string        `json:"metadata,omitempty"`
 		Receipt   Receipt       `json:"receipt,omitempty"`
 		Gossip    []Rumor       `json:"gossip,omitempty"`
 		FromName  string        `json:"fromName,omitempty"`
@@ -935,6 +1067,8 @@ func (this Transaction) MarshalJSON() ([]byte, error) {
 		Time:      this.Time,
 		Signature: this.Signature,
 		Hertz:     this.Hertz,
+		Source:    this.Source,
+		Metadata:  this.Metadata,
 		Receipt:   this.Receipt,
 		Gossip:    this.Gossip,
 		FromName:  this.FromName,
@@ -17,7 +17,9 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"testing"
 	"time"
@@ -74,6 +76,42 @@ func TestTransactionVerify(t *testing.T) {
 	}
 }
 
+//TestTransactionHertzLimit
+func TestTransactionHertzLimit(t *testing.T) {
+	tx := testMockTransaction(t)
+	if tx.HertzLimit() != DefaultHertzLimit {
+		t.Errorf("HertzLimit() returning invalid value: %d", tx.HertzLimit())
+	}
+	tx.Hertz = 50000
+	if tx.HertzLimit() != 50000 {
+		t.Errorf("HertzLimit() returning invalid value: %d", tx.HertzLimit())
+	}
+	tx.Hertz = PageHertzLimit + 1
+	if tx.Verify() == nil {
+		t.Error("transaction with hertz over the page limit verified")
+	}
+}
+
+//TestTransactionAccessSet
+func TestTransactionAccessSet(t *testing.T) {
+	tx := testMockTransaction(t)
+	other := *tx
+	other.From = "c296220327589dc04e6ee01bf16563f0f53895bb"
+	other.To = "a4a8bd17b4c3b13ea3e2b0cf9a6ec1e7f5b6bc47"
+	if tx.AccessSet().Conflicts(other.AccessSet()) {
+		t.Error("transfers between different accounts conflict")
+	}
+	other.To = tx.From
+	if !tx.AccessSet().Conflicts(other.AccessSet()) || !other.AccessSet().Conflicts(tx.AccessSet()) {
+		t.Error("transfers sharing an account do not conflict")
+	}
+	other.To = "a4a8bd17b4c3b13ea3e2b0cf9a6ec1e7f5b6bc47"
+	other.Type = TypeExecuteSmartContract
+	if !tx.AccessSet().Conflicts(other.AccessSet()) {
+		t.Error("contract execution does not conflict with a transfer")
+	}
+}
+
 //TestNewTransaction
 func TestNewTransaction(t *testing.T) {
 	tx := testMockTransaction(t)
@@ -321,6 +359,84 @@ func TestPrintNewExecuteTx(t *testing.T) {
 
 }
 
+//TestExecuteContractTransactionWithValue
+func TestExecuteContractTransactionWithValue(t *testing.T) {
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	to := "10412d6de794ab228e735eb0622f2deffca2edc5"
+	tx, err := NewExecuteContractTransactionWithValue(privateKey, from, to, "deposit", []interface{}{}, 25, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Value != 25 {
+		t.Errorf("NewExecuteContractTransactionWithValue returning invalid value: %d", tx.Value)
+	}
+	if err = tx.Verify(); err != nil {
+		t.Errorf("cannot verify transaction: %v", err)
+	}
+
+	tx, err = NewExecuteContractTransactionWithValue(privateKey, from, to, "deposit", []interface{}{}, -1, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Verify() == nil {
+		t.Error("transaction with a negative value verified")
+	}
+}
+
+func TestDeployContractTransactionWithParams(t *testing.T) {
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	supply, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	tx, err := NewDeployContractTransactionWithValue(privateKey, from, "6080", "[]", 0, now, supply, "Token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Params) != 2 {
+		t.Fatalf("expected 2 params, got %d", len(tx.Params))
+	}
+	if err = tx.Verify(); err != nil {
+		t.Errorf("cannot verify transaction: %v", err)
+	}
+
+	// Params are part of the hash.
+	withoutParams, err := NewDeployContractTransactionWithValue(privateKey, from, "6080", "[]", 0, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if withoutParams.Hash == tx.Hash {
+		t.Error("deploy params are not part of the hash")
+	}
+
+	// Numbers keep their exact value over JSON so the hash still matches.
+	received, err := ToTransactionFromJson([]byte(tx.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number, ok := received.Params[0].(json.Number); !ok || number.String() != supply.String() {
+		t.Errorf("unexpected param %v", received.Params[0])
+	}
+	if err = received.Verify(); err != nil {
+		t.Errorf("cannot verify received transaction: %v", err)
+	}
+	recordBytes, err := received.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ToTransactionFromBytes(recordBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = stored.Verify(); err != nil {
+		t.Errorf("cannot verify stored transaction: %v", err)
+	}
+	received.Params[1] = "Other"
+	if received.Verify() == nil {
+		t.Error("transaction with changed params verified")
+	}
+}
+
 //func TestPrintTransaction3(t *testing.T) {
 //	var privateKey= "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
 //	var from= "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl -X POST 'http://10.0.1.3:1975/v1/contracts/95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e/call' -d '{"method":"getVar5","params":[]}'
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl -X POST 'http://10.0.1.2:1975/v1/transactions/estimate' -d '{"type":2,"from":"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c","to":"3a6a5f84839e15ff5d7393f629098716fdbdb87a","value":0,"method":"setVar5","params":["hello"]}'
@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl 'http://10.0.1.2:1975/v1/accounts/3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c/balance?at=2018-07-01T00:00:00Z'
\ No newline at end of file
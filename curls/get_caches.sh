@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl 'http://10.0.1.2:1975/v1/caches'
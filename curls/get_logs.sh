@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl 'http://10.0.1.2:1975/v1/logs?address=95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e&from=2018-07-01T00:00:00Z'
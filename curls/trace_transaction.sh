@@ -0,0 +1,3 @@
+#!/usr/bin/env bash
+
+curl 'http://10.0.1.2:1975/v1/transactions/6a8d6e0e0bc1b5e5b6d1b4d0cd3a7b9e6c4f2f3a5d3c6e8f0a1b2c3d4e5f6a7b/trace?tracer=call'
@@ -17,14 +17,20 @@
 package dapos
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/helper"
 	"github.com/dispatchlabs/disgo/commons/services"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/disgover"
+	"github.com/dispatchlabs/disgo/dvm"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
 )
 
 // GetDelegateNodes
@@ -130,6 +136,94 @@ func (this *DAPoSService) GetAccount(address string) *types.Response {
 	return response
 }
 
+// GetBalance - Returns the balance of an account at a point in time, at defaults to now
+func (this *DAPoSService) GetBalance(address, at string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+		atTime, err := toMilliseconds(at, utils.ToMilliSeconds(time.Now()))
+		if err != nil {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+		accountBalance, err := types.ToBalanceAt(txn, address, atTime)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		} else {
+			response.Data = accountBalance
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved balance [address=%s, at=%s, status=%s]", address, at, response.Status))
+
+	return response
+}
+
+// GetStatement - Returns the balance deltas of an account between from and to inclusive, to defaults to now
+func (this *DAPoSService) GetStatement(address, from, to string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type == types.TypeDelegate {
+		fromTime, err := toMilliseconds(from, 0)
+		if err != nil {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+		toTime, err := toMilliseconds(to, utils.ToMilliSeconds(time.Now()))
+		if err != nil {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+		statement, err := types.ToStatement(txn, address, fromTime, toTime)
+		if err != nil {
+			if err == types.ErrInvalidRequestTimeRange {
+				response.Status = types.StatusInvalidRequest
+			} else {
+				response.Status = types.StatusInternalError
+			}
+			response.HumanReadableStatus = err.Error()
+		} else {
+			response.Data = statement
+			response.Status = types.StatusOk
+		}
+	} else {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+	}
+	utils.Info(fmt.Sprintf("retrieved statement [address=%s, from=%s, to=%s, status=%s]", address, from, to, response.Status))
+
+	return response
+}
+
+// toMilliseconds - Parses a time given as milliseconds since the epoch or RFC3339
+func toMilliseconds(value string, defaultValue int64) (int64, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	milliseconds, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return milliseconds, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %s, expected milliseconds or RFC3339", value)
+	}
+	return utils.ToMilliSeconds(t), nil
+}
+
 // NewTransaction
 func (this *DAPoSService) NewTransaction(transaction *types.Transaction) *types.Response {
 	response := types.NewResponse()
@@ -299,6 +393,498 @@ func (this *DAPoSService) DumpQueue() *types.Response {
 	return response
 }
 
+// GetLogs - Returns the contract event logs matching address and topics between from and to inclusive, to defaults to now.
+// Topics are comma separated and positional, an empty topic matches any value.
+func (this *DAPoSService) GetLogs(address, topics, from, to string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+	fromTime, err := toMilliseconds(from, 0)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	toTime, err := toMilliseconds(to, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	filter := &types.LogFilter{Address: toHex(address), From: fromTime, To: toTime}
+	if topics != "" {
+		for _, topic := range strings.Split(topics, ",") {
+			filter.Topics = append(filter.Topics, toHex(topic))
+		}
+	}
+	eventLogs, err := types.ToEventLogs(txn, filter, helper.GetLogBloomTest(filter))
+	if err != nil {
+		if err == types.ErrInvalidRequestTimeRange {
+			response.Status = types.StatusInvalidRequest
+		} else {
+			response.Status = types.StatusInternalError
+		}
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+
+	// Decode events with each contract's ABI.
+	abis := map[string]*abi.ABI{}
+	for _, eventLog := range eventLogs {
+		theABI, ok := abis[eventLog.ContractAddress]
+		if !ok {
+			contract, err := types.ToContractByAddress(txn, eventLog.ContractAddress)
+			if err == nil {
+				theABI, _ = helper.GetABI(contract.Abi)
+			}
+			abis[eventLog.ContractAddress] = theABI
+		}
+		if theABI == nil {
+			continue
+		}
+		err = helper.GetDecodedEventLog(theABI, eventLog)
+		if err != nil {
+			utils.Warn(fmt.Sprintf("unable to decode log [hash=%s, index=%d]: %v", eventLog.TransactionHash, eventLog.Index, err))
+		}
+	}
+	response.Data = eventLogs
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved logs [address=%s, topics=%s, from=%s, to=%s, count=%d]", address, topics, from, to, len(eventLogs)))
+
+	return response
+}
+
+// toHex - Lower case hex without the 0x prefix
+func toHex(value string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "0x")
+}
+
+// CallSmartContract - Runs a contract method against a throwaway copy of the contract's state, nothing is persisted or gossiped
+func (this *DAPoSService) CallSmartContract(address string, contractCall *types.ContractCall) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	// Find the contract's ABI.
+	contract, err := types.ToContractByAddress(txn, address)
+	if err != nil {
+		response.Status = types.StatusNotFound
+		response.HumanReadableStatus = fmt.Sprintf("Could not find contract with address %s", address)
+		return response
+	}
+	transaction := &types.Transaction{
+		Type:   types.TypeExecuteSmartContract,
+		From:   contractCall.From,
+		To:     address,
+		Abi:    contract.Abi,
+		Method: contractCall.Method,
+		Params: contractCall.Params,
+		Time:   utils.ToMilliSeconds(time.Now()),
+	}
+	if transaction.From == "" {
+		transaction.From = address
+	}
+	transaction.Params, err = helper.GetConvertedParams(transaction)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+
+	// Call.
+	dvmResult, err := dvm.GetDVMService().CallSmartContract(transaction, types.GetAccount().Address)
+	if revertError, ok := err.(*dvm.RevertError); ok {
+		theABI, _ := helper.GetABI(contract.Abi)
+		response.Status = types.StatusContractReverted
+		response.HumanReadableStatus = helper.GetRevertReason(theABI, revertError.Data)
+		return response
+	}
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+
+	// Decode outputs.
+	theABI, err := helper.GetABI(contract.Abi)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	outputs, err := helper.GetDecodedOutputs(theABI, contractCall.Method, dvmResult.ContractMethodExecResult)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	response.Data = &types.ContractCallResult{
+		ContractAddress: address,
+		Method:          contractCall.Method,
+		Outputs:         outputs,
+		HertzCost:       dvmResult.HertzCost,
+	}
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("called contract [address=%s, method=%s]", address, contractCall.Method))
+
+	return response
+}
+
+// TraceTransaction - Re-executes a contract transaction against the state it ran against and returns its trace
+func (this *DAPoSService) TraceTransaction(hash string, tracer string, disableMemory string, disableStack string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	transaction, err := types.ToTransactionByHash(txn, hash)
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			response.Status = types.StatusNotFound
+		} else {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		}
+		return response
+	}
+	if transaction.Type == types.TypeDeploySmartContract {
+		transaction.Params, err = helper.GetConvertedConstructorParams(transaction)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+	}
+	if transaction.Type == types.TypeExecuteSmartContract {
+		contract, err := types.ToContractByAddress(txn, transaction.To)
+		if err != nil {
+			response.Status = types.StatusNotFound
+			response.HumanReadableStatus = fmt.Sprintf("Could not find contract with address %s", transaction.To)
+			return response
+		}
+		transaction.Abi = contract.ExecuteAbi(transaction)
+		transaction.Params, err = helper.GetConvertedParams(transaction)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+	}
+
+	// Trace.
+	config := &dvm.TraceConfig{
+		Tracer:        tracer,
+		DisableMemory: disableMemory == "true",
+		DisableStack:  disableStack == "true",
+	}
+	if config.Tracer != "" && config.Tracer != dvm.TracerStruct && config.Tracer != dvm.TracerCall {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = fmt.Sprintf("tracer must be %s or %s", dvm.TracerStruct, dvm.TracerCall)
+		return response
+	}
+	gossip, err := types.ToGossipByTransactionHash(txn, hash)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	traceResult, err := dvm.GetDVMService().TraceTransaction(transaction, gossip.Rumors[0].Address, config)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	response.Data = traceResult
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("traced transaction [hash=%s, tracer=%s]", hash, tracer))
+
+	return response
+}
+
+// GetContract - Returns the registry record of a contract along with the catalog of its ABI's methods
+func (this *DAPoSService) GetContract(address string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	contract, err := types.ToContractByAddress(txn, address)
+	if err != nil {
+		if err == badger.ErrKeyNotFound || err == types.ErrNotFound {
+			response.Status = types.StatusNotFound
+			response.HumanReadableStatus = fmt.Sprintf("Could not find contract with address %s", address)
+		} else {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+		}
+		return response
+	}
+	theABI, err := helper.GetABI(contract.Abi)
+	if err == nil {
+		contract.Methods = helper.GetMethodCatalog(theABI)
+	}
+	response.Data = contract
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved contract [address=%s]", address))
+
+	return response
+}
+
+// GetContracts - Returns a page of the registered contracts, most recently deployed first, of every deployer when deployer is empty
+func (this *DAPoSService) GetContracts(deployer, page, size, start string) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	pageSize, err := strconv.Atoi(size)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	contracts, paging, err := types.ContractPaging(txn, deployer, start, pageNumber, pageSize)
+	if err != nil {
+		if err == types.ErrInvalidRequestPage || err == types.ErrInvalidRequestPageSize || err == types.ErrInvalidRequestStartingHash {
+			response.Status = types.StatusInvalidRequest
+		} else {
+			response.Status = types.StatusInternalError
+		}
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	response.Data = contracts
+	response.Paging = paging
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved contracts [deployer=%s, page=%s, count=%d]", deployer, page, len(contracts)))
+
+	return response
+}
+
+// GetContractStorage - Returns the code and a page of the storage slots of a contract
+func (this *DAPoSService) GetContractStorage(address, page, size, start string) *types.Response {
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	pageSize, err := strconv.Atoi(size)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	contractStorage, paging, err := dvm.GetDVMService().GetContractStorage(address, start, pageNumber, pageSize)
+	if err != nil {
+		setContractStorageError(response, address, err)
+		return response
+	}
+	response.Data = contractStorage
+	response.Paging = paging
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved contract storage [address=%s, page=%s]", address, page))
+
+	return response
+}
+
+// GetContractStorageSlot - Returns a storage slot of a contract, with its Merkle proof against the contract's storage root when asked for
+func (this *DAPoSService) GetContractStorageSlot(address, slot, proof string) *types.Response {
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	contractStorage, err := dvm.GetDVMService().GetContractStorageSlot(address, slot, proof == "true")
+	if err != nil {
+		setContractStorageError(response, address, err)
+		return response
+	}
+	response.Data = contractStorage
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("retrieved contract storage slot [address=%s, slot=%s]", address, slot))
+
+	return response
+}
+
+// setContractStorageError - Maps an error reading a contract's storage to the response status
+func setContractStorageError(response *types.Response, address string, err error) {
+	switch err {
+	case dvm.ErrContractNotFound:
+		response.Status = types.StatusNotFound
+		response.HumanReadableStatus = fmt.Sprintf("Could not find contract with address %s", address)
+	case dvm.ErrInvalidSlot, types.ErrInvalidRequestPage, types.ErrInvalidRequestPageSize, types.ErrInvalidRequestStartingHash:
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+	default:
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+	}
+}
+
+// EstimateHertz - Dry-runs a deploy or execute transaction to find the least hertz it succeeds with, nothing is gossiped or committed
+func (this *DAPoSService) EstimateHertz(transaction *types.Transaction) *types.Response {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	response := types.NewResponse()
+
+	// Delegate?
+	if disgover.GetDisGoverService().ThisNode.Type != types.TypeDelegate {
+		response.Status = types.StatusNotDelegate
+		response.HumanReadableStatus = types.StatusNotDelegateAsHumanReadable
+		return response
+	}
+
+	switch transaction.Type {
+	case types.TypeDeploySmartContract:
+		if transaction.Abi == "" {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = "value for field 'abi' is required"
+			return response
+		}
+		transaction.Abi = hex.EncodeToString([]byte(transaction.Abi))
+		params, err := helper.GetConvertedConstructorParams(transaction)
+		if err != nil {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+		transaction.Params = params
+	case types.TypeExecuteSmartContract:
+		contract, err := types.ToContractByAddress(txn, transaction.To)
+		if err != nil {
+			response.Status = types.StatusNotFound
+			response.HumanReadableStatus = fmt.Sprintf("Could not find contract with address %s", transaction.To)
+			return response
+		}
+		transaction.Abi = contract.ExecuteAbi(transaction)
+		transaction.Params, err = helper.GetConvertedParams(transaction)
+		if err != nil {
+			response.Status = types.StatusInvalidRequest
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+	default:
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = "only deploy and execute smart contract transactions can be estimated"
+		return response
+	}
+	theABI, err := helper.GetABI(transaction.Abi)
+	if err != nil {
+		response.Status = types.StatusInvalidRequest
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	if transaction.Time == 0 {
+		transaction.Time = utils.ToMilliSeconds(time.Now())
+	}
+
+	// Estimate.
+	estimateResult, err := dvm.GetDVMService().EstimateHertz(transaction, types.GetAccount().Address)
+	if err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = err.Error()
+		return response
+	}
+	hertzEstimate := &types.HertzEstimate{
+		Hertz:     int64(estimateResult.Hertz),
+		HertzUsed: int64(estimateResult.HertzUsed),
+	}
+	response.Data = hertzEstimate
+
+	// Failed?
+	if revertError, ok := estimateResult.Err.(*dvm.RevertError); ok {
+		response.Status = types.StatusContractReverted
+		response.HumanReadableStatus = helper.GetRevertReason(theABI, revertError.Data)
+		return response
+	}
+	if estimateResult.Err == types.ErrOutOfHertz {
+		response.Status = types.StatusOutOfHertz
+		response.HumanReadableStatus = estimateResult.Err.Error()
+		return response
+	}
+	if estimateResult.Err != nil {
+		response.Status = types.StatusInternalError
+		response.HumanReadableStatus = estimateResult.Err.Error()
+		return response
+	}
+
+	// Decode outputs.
+	if transaction.Type == types.TypeDeploySmartContract {
+		hertzEstimate.ContractAddress = hex.EncodeToString(estimateResult.ContractAddress[:])
+	} else {
+		hertzEstimate.Outputs, err = helper.GetDecodedOutputs(theABI, transaction.Method, estimateResult.ReturnValue)
+		if err != nil {
+			response.Status = types.StatusInternalError
+			response.HumanReadableStatus = err.Error()
+			return response
+		}
+	}
+	response.Status = types.StatusOk
+	utils.Info(fmt.Sprintf("estimated hertz [hertz=%d, hertzUsed=%d]", hertzEstimate.Hertz, hertzEstimate.HertzUsed))
+
+	return response
+}
+
+// GetCacheMetrics
+func (this *DAPoSService) GetCacheMetrics() *types.Response {
+	response := types.NewResponse()
+	response.Data = services.GetCache().Metrics()
+	response.Status = types.StatusOk
+	return response
+}
+
 func (this *DAPoSService) ToBeSupported() *types.Response {
 	response := types.NewResponse()
 	response.Data = types.StatusUnavailableFeature
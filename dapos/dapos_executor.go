@@ -0,0 +1,69 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// maxConflictRetries - How many times a transaction is executed alongside other transactions before it keeps losing write
+// conflicts and is executed serially
+const maxConflictRetries = 3
+
+// serialExecution - Every execution holds it shared, a transaction that kept losing write conflicts holds it exclusively so
+// nothing executes alongside it
+var serialExecution sync.RWMutex
+
+// executeGossips - Executes a batch of gossips in canonical order, by transaction time and then hash. Each gossip waits
+// for every earlier gossip whose access set conflicts with its own while independent gossips execute concurrently, so
+// every delegate ends with the state executing the batch one gossip at a time would give.
+func executeGossips(gossips []*types.Gossip, execute func(gossip *types.Gossip)) {
+	sort.Slice(gossips, func(i, j int) bool {
+		if gossips[i].Transaction.Time == gossips[j].Transaction.Time {
+			return gossips[i].Transaction.Hash < gossips[j].Transaction.Hash
+		}
+		return gossips[i].Transaction.Time < gossips[j].Transaction.Time
+	})
+
+	accessSets := make([]*types.AccessSet, len(gossips))
+	done := make([]chan struct{}, len(gossips))
+	var waitGroup sync.WaitGroup
+	for i, gossip := range gossips {
+		accessSets[i] = gossip.Transaction.AccessSet()
+		done[i] = make(chan struct{})
+		waits := make([]chan struct{}, 0)
+		for j := 0; j < i; j++ {
+			if accessSets[j].Conflicts(accessSets[i]) {
+				waits = append(waits, done[j])
+			}
+		}
+
+		waitGroup.Add(1)
+		go func(gossip *types.Gossip, waits []chan struct{}, done chan struct{}) {
+			defer waitGroup.Done()
+			defer close(done)
+			for _, wait := range waits {
+				<-wait
+			}
+			execute(gossip)
+		}(gossip, waits, done[i])
+	}
+	waitGroup.Wait()
+}
@@ -0,0 +1,101 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// transferGossip - A gossip of a transfer between two accounts
+func transferGossip(hash string, from, to string, time int64) *types.Gossip {
+	return &types.Gossip{Transaction: types.Transaction{Hash: hash, Type: types.TypeTransferTokens, From: from, To: to, Time: time}}
+}
+
+// TestExecuteGossipsConflictingInCanonicalOrder - Gossips sharing an account execute one at a time by time and then hash
+func TestExecuteGossipsConflictingInCanonicalOrder(t *testing.T) {
+	gossips := []*types.Gossip{
+		transferGossip("c3", "alice", "bob", 2),
+		transferGossip("a1", "carol", "alice", 3),
+		transferGossip("b2", "alice", "dave", 2),
+		transferGossip("d4", "bob", "alice", 1),
+		transferGossip("a0", "alice", "erin", 2),
+	}
+
+	var lock sync.Mutex
+	var order []string
+	running := 0
+	executeGossips(gossips, func(gossip *types.Gossip) {
+		lock.Lock()
+		running++
+		if running > 1 {
+			t.Errorf("%s executed alongside a conflicting gossip", gossip.Transaction.Hash)
+		}
+		lock.Unlock()
+
+		// Give a gossip executing out of order the time to show
+		time.Sleep(10 * time.Millisecond)
+
+		lock.Lock()
+		running--
+		order = append(order, gossip.Transaction.Hash)
+		lock.Unlock()
+	})
+
+	if fmt.Sprint(order) != "[d4 a0 b2 c3 a1]" {
+		t.Errorf("conflicting gossips executed in order %v, expected [d4 a0 b2 c3 a1]", order)
+	}
+}
+
+// TestExecuteGossipsIndependentConcurrently - Gossips touching different accounts execute at the same time
+func TestExecuteGossipsIndependentConcurrently(t *testing.T) {
+	gossips := make([]*types.Gossip, 0)
+	for i := 0; i < 5; i++ {
+		gossips = append(gossips, transferGossip(fmt.Sprintf("h%d", i), fmt.Sprintf("from%d", i), fmt.Sprintf("to%d", i), int64(i)))
+	}
+
+	// Every gossip waits for all of them to start, which only happens if they run concurrently
+	var started sync.WaitGroup
+	started.Add(len(gossips))
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+	var lock sync.Mutex
+	executed := map[string]bool{}
+	executeGossips(gossips, func(gossip *types.Gossip) {
+		started.Done()
+		select {
+		case <-allStarted:
+		case <-time.After(5 * time.Second):
+			t.Errorf("%s waited for an independent gossip", gossip.Transaction.Hash)
+			return
+		}
+		lock.Lock()
+		executed[gossip.Transaction.Hash] = true
+		lock.Unlock()
+	})
+
+	if len(executed) != len(gossips) {
+		t.Errorf("%d of %d independent gossips executed together", len(executed), len(gossips))
+	}
+}
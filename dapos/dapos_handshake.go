@@ -95,6 +95,7 @@ func (this *DAPoSService) cacheOnFirstReceive(gossip *types.Gossip) {
 
 	// transaction.Receipt.Status = types.StatusReceived
 	gossip.Transaction.Cache(services.GetCache())
+	journal(gossip)
 
 	delegateNodes, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
 	if err != nil {
@@ -184,96 +185,109 @@ func (this *DAPoSService) gossipWorker() {
 	for {
 		select {
 		case gossip = <-this.gossipChan:
+			go this.processGossip(gossip)
+		}
+	}
+}
 
-			go func(gossip *types.Gossip) {
-				// Find nodes in cache?
-				delegateNodes, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
-				if err != nil {
-					utils.Error(err)
-					return
-				}
-				if delegateMap == nil || len(delegateMap) == 0 {
-					for _, d := range delegateNodes {
-						delegateMap[d.Address] = d
-					}
-				}
+// processGossip
+func (this *DAPoSService) processGossip(gossip *types.Gossip) {
+	// Find nodes in cache?
+	delegateNodes, err := types.ToNodesByTypeFromCache(services.GetCache(), types.TypeDelegate)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	if delegateMap == nil || len(delegateMap) == 0 {
+		for _, d := range delegateNodes {
+			delegateMap[d.Address] = d
+		}
+	}
 
-				// Gossip timeout?
-				if len(gossip.Rumors) > 1 {
-					if !types.ValidateTimeDelta(gossip.Rumors) {
-						utils.Warn("The rumors have an invalid time delta (greater than gossip timeout milliseconds")
-						updateReceiptStatus(gossip.Transaction.Hash, types.StatusGossipingTimedOut)
-						//ignore this gossip's rumors and hopefully still hit 2/3 from well timed gossip, but keep listening
-						return
-					}
-				}
-				// Do we have 2/3 of rumors?
-				if float32(len(gossip.Rumors)) >= float32(len(delegateNodes))*2/3 {
-					if !this.gossipQueue.Exists(gossip.Transaction.Hash) {
-						//for _, rumor := range gossip.Rumors {
-						//	utils.Info(fmt.Sprintf("rumor from: [address=%s] for [tx=%s] with [hash=%s]", rumor.Address, rumor.TransactionHash, rumor.Hash))
-						//}
-						this.gossipQueue.Push(gossip)
-
-						go func() {
-							//adding timeout as a function of tx time.  If tx is in the future, add future delta to the default timeout
-							delta := gossip.Transaction.Time - utils.ToMilliSeconds(time.Now())
-							totalMilliseconds := (types.GossipTimeout * len(delegateNodes)) + types.TxReceiveTimeout
-							timeout := time.Duration(totalMilliseconds) * time.Millisecond
-							utils.Debug("Timeout Queue value: ", timeout)
-							if delta > 0 {
-								timeout = time.Millisecond*time.Duration(delta) + timeout
-							}
-							time.Sleep(timeout)
-							this.timoutChan <- true
-						}()
-						//for _, node := range delegateNodes {
-						//	haveSent := gossip.HaveSent(services.GetCache(), gossip.Transaction.Hash, node.Address)
-						//
-						//	if !haveSent {
-						//		utils.Info(fmt.Sprintf("*********** Last send after 2/3 [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
-						//		this.peerGossipGrpc(*node, gossip)
-						//	}
-						//}
-					}
-					//No reason to keep gossiping if we are executing the transaction
-					return
-				}
+	// Gossip timeout?
+	if len(gossip.Rumors) > 1 {
+		if !types.ValidateTimeDelta(gossip.Rumors) {
+			utils.Warn("The rumors have an invalid time delta (greater than gossip timeout milliseconds")
+			this.abandonGossip(gossip, types.StatusGossipingTimedOut)
+			//ignore this gossip's rumors and hopefully still hit 2/3 from well timed gossip, but keep listening
+			return
+		}
+	}
+	// Do we have 2/3 of rumors?
+	if float32(len(gossip.Rumors)) >= float32(len(delegateNodes))*2/3 {
+		if !this.gossipQueue.Exists(gossip.Transaction.Hash) {
+			//for _, rumor := range gossip.Rumors {
+			//	utils.Info(fmt.Sprintf("rumor from: [address=%s] for [tx=%s] with [hash=%s]", rumor.Address, rumor.TransactionHash, rumor.Hash))
+			//}
+			//adding timeout as a function of tx time.  If tx is in the future, add future delta to the default timeout
+			now := utils.ToMilliSeconds(time.Now())
+			delta := gossip.Transaction.Time - now
+			totalMilliseconds := (types.GossipTimeout * len(delegateNodes)) + types.TxReceiveTimeout
+			timeout := time.Duration(totalMilliseconds) * time.Millisecond
+			utils.Debug("Timeout Queue value: ", timeout)
+			if delta > 0 {
+				timeout = time.Millisecond*time.Duration(delta) + timeout
+			}
+			journalQueued(gossip, now+int64(timeout/time.Millisecond))
+			this.gossipQueue.Push(gossip)
+
+			go func() {
+				time.Sleep(timeout)
+				this.timoutChan <- true
+			}()
+			//for _, node := range delegateNodes {
+			//	haveSent := gossip.HaveSent(services.GetCache(), gossip.Transaction.Hash, node.Address)
+			//
+			//	if !haveSent {
+			//		utils.Info(fmt.Sprintf("*********** Last send after 2/3 [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
+			//		this.peerGossipGrpc(*node, gossip)
+			//	}
+			//}
+		}
+		//No reason to keep gossiping if we are executing the transaction
+		return
+	}
 
-				// Did we already receive all the delegate's rumors?
-				if len(gossip.Rumors) == len(delegateNodes) {
-					utils.Debug("already received all rumors from delegates")
-					return
-				}
+	// Did we already receive all the delegate's rumors?
+	if len(gossip.Rumors) == len(delegateNodes) {
+		utils.Debug("already received all rumors from delegates")
+		return
+	}
 
-				// Get random delegate?
-				node := this.getRandomDelegate(gossip, delegateNodes)
-				if node == nil {
-					utils.Warn("did not find any delegates to rumor with")
-					gossip.Cache(services.GetCache())
-					updateReceiptStatus(gossip.Transaction.Hash, types.StatusCouldNotReachConsensus)
-
-					//Commented out because if we have no-one left to talk to, why are we continuing?
-					//Plus it was causing me all kinds of timeout problems
-					if len(gossip.Rumors) != len(delegateNodes) {
-						utils.Debug(fmt.Sprintf("Stopped Gossiping when there are %d nodes that don't have a rumor", len(delegateNodes)-len(gossip.Rumors)))
-					}
+	// Get random delegate?
+	node := this.getRandomDelegate(gossip, delegateNodes)
+	if node == nil {
+		utils.Warn("did not find any delegates to rumor with")
+		gossip.Cache(services.GetCache())
+		this.abandonGossip(gossip, types.StatusCouldNotReachConsensus)
+
+		//Commented out because if we have no-one left to talk to, why are we continuing?
+		//Plus it was causing me all kinds of timeout problems
+		if len(gossip.Rumors) != len(delegateNodes) {
+			utils.Debug(fmt.Sprintf("Stopped Gossiping when there are %d nodes that don't have a rumor", len(delegateNodes)-len(gossip.Rumors)))
+		}
 
-					return
-				}
-				utils.Debug(fmt.Sprintf("Picked RandomDelegate = [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
+		return
+	}
+	utils.Debug(fmt.Sprintf("Picked RandomDelegate = [hash=%s] to delegate [Port %d] [address=%s]", gossip.Transaction.Hash, node.HttpEndpoint.Port, node.Address))
 
-				// Peer gossip.
-				//peerGossip, err := this.peerGossipGrpc(*node, gossip)
-				_, err = this.peerGossipGrpc(*node, gossip)
-				if err != nil {
-					utils.Error(err)
-					this.gossipChan <- gossip
-					return
-				}
-				//this.gossipChan <- peerGossip
-			}(gossip)
-		}
+	// Peer gossip.
+	//peerGossip, err := this.peerGossipGrpc(*node, gossip)
+	_, err = this.peerGossipGrpc(*node, gossip)
+	if err != nil {
+		utils.Error(err)
+		this.gossipChan <- gossip
+		return
+	}
+	//this.gossipChan <- peerGossip
+}
+
+// abandonGossip - Gives a gossip that can no longer reach consensus its receipt status and removes it from the journal,
+// unless another copy of it already reached consensus and is queued for execution
+func (this *DAPoSService) abandonGossip(gossip *types.Gossip, status string) {
+	updateReceiptStatus(gossip.Transaction.Hash, status)
+	if !this.gossipQueue.Exists(gossip.Transaction.Hash) {
+		unjournal(gossip.Transaction.Hash)
 	}
 }
 
@@ -330,43 +344,106 @@ func (this *DAPoSService) transactionWorker() {
 	for {
 		select {
 		case <-this.timoutChan:
-			this.doWork()
+
+			// Every timeout that is also due pops a gossip into the same batch.
+			count := 1
+			for drained := false; !drained; {
+				select {
+				case <-this.timoutChan:
+					count++
+				default:
+					drained = true
+				}
+			}
+			this.doWork(count)
 		}
 	}
 }
 
-func (this *DAPoSService) doWork() {
-	var gossip *types.Gossip
+// doWork - Pops up to count gossips and executes them as one batch
+func (this *DAPoSService) doWork(count int) {
+	gossips := make([]*types.Gossip, 0, count)
+	for len(gossips) < count && this.gossipQueue.HasAvailable() {
+		gossips = append(gossips, this.gossipQueue.Pop())
+	}
+	executeGossips(gossips, executeGossip)
+}
 
-	if this.gossipQueue.HasAvailable() {
-		gossip = this.gossipQueue.Pop()
-		// Get receipt.
-		receipt, err := types.ToReceiptFromCache(services.GetCache(), gossip.Transaction.Hash)
-		if err != nil {
-			utils.Error(fmt.Sprintf("receipt not found [hash=%s]", gossip.Transaction.Hash))
-			receipt = types.NewReceipt(gossip.Transaction.Hash)
-			receipt.Status = types.StatusReceiptNotFound
-			receipt.Cache(services.GetCache())
-			return
+// executeGossip
+func executeGossip(gossip *types.Gossip) {
+	defer unjournal(gossip.Transaction.Hash)
+	// Get receipt. A burst can evict it from the cache while the gossip is queued, the journal still knows the gossip is in
+	// flight so it executes as on every other delegate.
+	receipt, err := types.ToReceiptFromCache(services.GetCache(), gossip.Transaction.Hash)
+	if err != nil && isJournaled(gossip.Transaction.Hash) {
+		utils.Warn(fmt.Sprintf("receipt evicted from the cache, executing the journaled transaction [hash=%s]", gossip.Transaction.Hash))
+		receipt = types.NewReceipt(gossip.Transaction.Hash)
+		receipt.Cache(services.GetCache())
+		err = nil
+	}
+	if err != nil {
+		utils.Error(fmt.Sprintf("receipt not found [hash=%s]", gossip.Transaction.Hash))
+		receipt = types.NewReceipt(gossip.Transaction.Hash)
+		receipt.Status = types.StatusReceiptNotFound
+		receipt.Cache(services.GetCache())
+		return
+	}
+	initialRcvDuration := gossip.Rumors[0].Time - gossip.Transaction.Time
+	utils.Debug("Initial Receive Duration = ", initialRcvDuration, types.TxReceiveTimeout)
+	if initialRcvDuration >= types.TxReceiveTimeout {
+		utils.Error(fmt.Sprintf("Timed out [hash=%s] %v milliseconds", gossip.Transaction.Hash, initialRcvDuration))
+		receipt = types.NewReceipt(gossip.Transaction.Hash)
+		receipt.Status = types.StatusTransactionTimeOut
+		receipt.Cache(services.GetCache())
+		return
+	}
+	receipt.Created = time.Now()
+	if types.GetConfig().IsBookkeeper {
+		executeTransaction(&gossip.Transaction, receipt, gossip)
+	}
+}
+
+// executeTransaction - A transaction that loses a write conflict is executed again until it succeeds, serially once it
+// kept losing them. A contract too as the world state only moves when its transaction commits
+func executeTransaction(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) {
+	err := executeConcurrently(transaction, receipt, gossip)
+	if err != badger.ErrConflict {
+		return
+	}
+	utils.Warn(fmt.Sprintf("write conflicts, executing serially [hash=%s]", transaction.Hash))
+	serialExecution.Lock()
+	defer serialExecution.Unlock()
+	gossiped := *transaction
+	for err == badger.ErrConflict {
+		err = tryExecuteTransaction(transaction, receipt, gossip)
+		if err == badger.ErrConflict {
+			*transaction = gossiped
 		}
-		initialRcvDuration := gossip.Rumors[0].Time - gossip.Transaction.Time
-		utils.Debug("Initial Receive Duration = ", initialRcvDuration, types.TxReceiveTimeout)
-		if initialRcvDuration >= types.TxReceiveTimeout {
-			utils.Error(fmt.Sprintf("Timed out [hash=%s] %v milliseconds", gossip.Transaction.Hash, initialRcvDuration))
-			receipt = types.NewReceipt(gossip.Transaction.Hash)
-			receipt.Status = types.StatusTransactionTimeOut
-			receipt.Cache(services.GetCache())
-			return
+	}
+}
+
+// executeConcurrently - Executes the transaction alongside others, up to maxConflictRetries times while it loses write
+// conflicts
+func executeConcurrently(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) error {
+	serialExecution.RLock()
+	defer serialExecution.RUnlock()
+
+	// A contract transaction converts its ABI and params as it executes, it is executed again as gossiped
+	gossiped := *transaction
+	for attempt := 1; ; attempt++ {
+		err := tryExecuteTransaction(transaction, receipt, gossip)
+		if err == badger.ErrConflict {
+			*transaction = gossiped
 		}
-		receipt.Created = time.Now()
-		if types.GetConfig().IsBookkeeper {
-			executeTransaction(&gossip.Transaction, receipt, gossip)
+		if err != badger.ErrConflict || attempt == maxConflictRetries {
+			return err
 		}
+		utils.Warn(fmt.Sprintf("write conflict, executing again [hash=%s, attempt=%d]", transaction.Hash, attempt))
 	}
 }
 
-// executeTransaction
-func executeTransaction(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) {
+// tryExecuteTransaction - Returns badger.ErrConflict when another writer committed state the transaction read
+func tryExecuteTransaction(transaction *types.Transaction, receipt *types.Receipt, gossip *types.Gossip) error {
 	utils.Info("executeTransaction --> ", transaction.Hash)
 	services.Lock(transaction.Hash)
 	defer services.Unlock(transaction.Hash)
@@ -378,7 +455,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	_, err := txn.Get([]byte(transaction.Key()))
 	if err == nil {
 		utils.Info("Already executed this transaction --> ", transaction.Hash)
-		return
+		return nil
 	}
 
 	// Find/create fromAccount?
@@ -392,7 +469,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
 		}
 	}
 
@@ -404,7 +481,44 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		} else {
 			utils.Error(err)
 			receipt.SetInternalErrorWithNewTransaction(services.GetDb(), err)
-			return
+			return nil
+		}
+	}
+
+	fromBalance := fromAccount.Balance.Int64()
+	toBalance := toAccount.Balance.Int64()
+	var logBloom *types.LogBloom
+	var contractAccounts []*types.Account
+
+	// The DVM writes the world state through txn, its trie nodes are dropped from memory unless txn commits
+	var dvmResult *dvm.DVMResult
+	defer func() {
+		if dvmResult != nil && dvmResult.StorageState != nil {
+			dvmResult.StorageState.Discard()
+		}
+	}()
+
+	// Sufficient tokens for the value sent to a contract?
+	if (transaction.Type == types.TypeDeploySmartContract || transaction.Type == types.TypeExecuteSmartContract) && fromAccount.Balance.Int64() < transaction.Value {
+		utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
+		receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientTokens)
+		return nil
+	}
+
+	// Does the page have room for the hertz limit of a contract transaction?
+	var page *types.Page
+	if transaction.Type == types.TypeDeploySmartContract || transaction.Type == types.TypeExecuteSmartContract {
+		page, err = types.ToPageByNumber(txn, types.ToPageNumber(transaction.Time))
+		if err != nil {
+			utils.Error(err)
+			receipt.SetInternalErrorWithNewTransaction(services.GetDb(), err)
+			return nil
+		}
+		if page.HertzUsed+transaction.HertzLimit() > types.PageHertzLimit {
+			utils.Error(fmt.Sprintf("page hertz limit exceeded [hash=%s, page=%d]", transaction.Hash, page.Number))
+			receipt.HumanReadableStatus = fmt.Sprintf("page %d has %d hertz left", page.Number, types.PageHertzLimit-page.HertzUsed)
+			receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusPageHertzLimitExceeded)
+			return nil
 		}
 	}
 
@@ -416,7 +530,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		if fromAccount.Balance.Int64() < transaction.Value {
 			utils.Error(fmt.Sprintf("insufficient tokens [hash=%s]", transaction.Hash))
 			receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInsufficientTokens)
-			return
+			return nil
 		}
 		fromAccount.Balance.SetInt64(fromAccount.Balance.Int64() - transaction.Value)
 		toAccount.Balance.SetInt64(toAccount.Balance.Int64() + transaction.Value)
@@ -428,13 +542,30 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		// ENCODE to HEX here, the DECODE is happening in GetABI()
 		transaction.Abi = hex.EncodeToString([]byte(transaction.Abi))
 
-		dvmResult, err := dvmService.DeploySmartContract(transaction)
+		// READ CONSTRUCTOR PARAMS, the transaction keeps its JSON params as they are part of its hash
+		deployTransaction := *transaction
+		deployTransaction.Params, err = helper.GetConvertedConstructorParams(transaction)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
+		}
+
+		dvmResult, err = dvmService.DeploySmartContract(txn, &deployTransaction, gossip.Rumors[0].Address)
+		if err == types.ErrOutOfHertz {
+			return outOfHertz(txn, transaction, gossip, receipt, page)
+		}
+		if revertError, ok := err.(*dvm.RevertError); ok {
+			return contractReverted(txn, transaction, gossip, receipt, page, revertError)
+		}
+		if err != nil {
+			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
 		}
 
 		err = processDVMResult(transaction, dvmResult, receipt)
@@ -443,7 +574,21 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
+		}
+
+		receipt.Logs = helper.ToEventLogs(dvmResult.Logs, transaction.Hash, transaction.Time)
+		logBloom = &types.LogBloom{TransactionHash: transaction.Hash, Bloom: dvmResult.Bloom.Bytes()}
+		receipt.HertzCost = int64(dvmResult.HertzCost)
+
+		// Move tokens.
+		contractAccounts, err = applyContractBalances(txn, transaction, dvmResult.Balances, fromAccount, toAccount, now)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
 		}
 
 		// Update contract account.
@@ -456,45 +601,89 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 			}
 		}
 
+		// Register contract.
+		codeHash := dvmResult.StorageState.EthStateDB.GetCodeHash(dvmResult.ContractAddress)
+		contract := types.NewContract(smartContractAddress, transaction, hex.EncodeToString(codeHash[:]))
+		err = contract.Persist(txn)
+		if err == nil {
+			err = registerCreatedContracts(txn, transaction, dvmResult)
+		}
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
+
 		receipt.ContractAddress = smartContractAddress
 		utils.Info(fmt.Sprintf("deployed contract [hash=%s, contractAddress=%s]", transaction.Hash, smartContractAddress))
 		break
 	case types.TypeExecuteSmartContract:
 
 		// READ PARAMS
-		contractTx, err := types.ToTransactionByAddress(txn, transaction.To)
+		contract, err := types.ToContractByAddress(txn, transaction.To)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
 		}
 
-		transaction.Abi = contractTx.Abi
+		transaction.Abi = contract.ExecuteAbi(transaction)
 		transaction.Params, err = helper.GetConvertedParams(transaction)
 		if err != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
 		}
 		// }
 
 		dvmService := dvm.GetDVMService()
-		dvmResult, err1 := dvmService.ExecuteSmartContract(transaction)
+		var err1 error
+		dvmResult, err1 = dvmService.ExecuteSmartContract(txn, transaction, gossip.Rumors[0].Address)
 		if err1 != nil {
 			utils.Error(err, utils.GetCallStackWithFileAndLineNumber())
 		}
 
 		err = processDVMResult(transaction, dvmResult, receipt)
+		if err == types.ErrOutOfHertz {
+			return outOfHertz(txn, transaction, gossip, receipt, page)
+		}
+		if revertError, ok := err.(*dvm.RevertError); ok {
+			return contractReverted(txn, transaction, gossip, receipt, page, revertError)
+		}
 		if err != nil {
 			utils.Error(err)
 			receipt.Status = types.StatusInternalError
 			receipt.HumanReadableStatus = err.Error()
 			receipt.Cache(services.GetCache())
-			return
+			return nil
+		}
+		receipt.Logs = helper.ToEventLogs(dvmResult.Logs, transaction.Hash, transaction.Time)
+		logBloom = &types.LogBloom{TransactionHash: transaction.Hash, Bloom: dvmResult.Bloom.Bytes()}
+		receipt.HertzCost = int64(dvmResult.HertzCost)
+
+		// Move tokens.
+		contractAccounts, err = applyContractBalances(txn, transaction, dvmResult.Balances, fromAccount, toAccount, now)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
+		// Register the contracts it created.
+		err = registerCreatedContracts(txn, transaction, dvmResult)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
 		}
 		receipt.ContractAddress = transaction.To
 		utils.Info(fmt.Sprintf("executed contract [hash=%s, contractAddress=%s]", transaction.Hash, transaction.To))
@@ -502,7 +691,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 	default:
 		utils.Error(fmt.Sprintf("invalid transaction type [hash=%s]", transaction.Hash))
 		receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusInvalidTransaction)
-		return
+		return nil
 	}
 
 	// Persist transaction
@@ -512,7 +701,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
 	}
 
 	// Save fromAccount.
@@ -523,7 +712,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
 	}
 
 	// Save toAccount.
@@ -534,7 +723,62 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
+	}
+
+	// Save balance deltas.
+	balanceDeltas := []*types.BalanceDelta{
+		types.NewBalanceDelta(fromAccount.Address, transaction.Hash, transaction.Time, fromAccount.Balance.Int64()-fromBalance, fromAccount.Balance.Int64()),
+		types.NewBalanceDelta(toAccount.Address, transaction.Hash, transaction.Time, toAccount.Balance.Int64()-toBalance, toAccount.Balance.Int64()),
+	}
+	for _, balanceDelta := range balanceDeltas {
+		if balanceDelta.Delta == 0 {
+			continue
+		}
+		err = balanceDelta.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
+	}
+
+	// Save event logs.
+	for _, eventLog := range receipt.Logs {
+		err = eventLog.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
+	}
+	if logBloom != nil && len(receipt.Logs) > 0 {
+		err = logBloom.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
+	}
+
+	// Charge the page.
+	if page != nil {
+		page.HertzUsed += receipt.HertzCost
+		receipt.CumulativeHertzUsed = page.HertzUsed
+		err = page.Persist(txn)
+		if err != nil {
+			utils.Error(err)
+			receipt.Status = types.StatusInternalError
+			receipt.HumanReadableStatus = err.Error()
+			receipt.Cache(services.GetCache())
+			return nil
+		}
 	}
 
 	// Save receipt.
@@ -545,7 +789,7 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
 	}
 
 	// Save gossip.
@@ -555,21 +799,150 @@ func executeTransaction(transaction *types.Transaction, receipt *types.Receipt,
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
 	}
 
 	// Commit.
 	err = txn.Commit(nil)
 	if err != nil {
-		if err == badger.ErrConflict { // Another thread already committed this transaction. This will happen, which is ok.
-			return
+		if err == badger.ErrConflict {
+			return err
 		}
 		utils.Error(err)
 		receipt.Status = types.StatusInternalError
 		receipt.HumanReadableStatus = err.Error()
 		receipt.Cache(services.GetCache())
-		return
+		return nil
+	}
+
+	if dvmResult != nil {
+		dvmResult.StorageState.Persisted()
+	}
+
+	// Invalidate the cached copies of what we persisted.
+	transaction.Uncache(services.GetCache())
+	fromAccount.Uncache(services.GetCache())
+	toAccount.Uncache(services.GetCache())
+	for _, account := range contractAccounts {
+		account.Uncache(services.GetCache())
+	}
+	return nil
+}
+
+// applyContractBalances - Reflects the token balances a contract execution moved back into the Dispatch accounts. The
+// from and to accounts are updated in place and saved by the caller, any other account is saved with its balance delta.
+func applyContractBalances(txn *badger.Txn, transaction *types.Transaction, balances map[string]*big.Int, fromAccount *types.Account, toAccount *types.Account, now time.Time) ([]*types.Account, error) {
+	accounts := make([]*types.Account, 0)
+	for address, balance := range balances {
+		if address == fromAccount.Address {
+			fromAccount.Balance.Set(balance)
+			continue
+		}
+		if address == toAccount.Address {
+			toAccount.Balance.Set(balance)
+			continue
+		}
+		account, err := types.ToAccountByAddress(txn, address)
+		if err != nil {
+			if err != badger.ErrKeyNotFound {
+				return nil, err
+			}
+			account = &types.Account{Address: address, Balance: big.NewInt(0), Created: now}
+		}
+		balanceDelta := types.NewBalanceDelta(address, transaction.Hash, transaction.Time, balance.Int64()-account.Balance.Int64(), balance.Int64())
+		account.Balance = new(big.Int).Set(balance)
+		account.Updated = now
+		err = account.Persist(txn)
+		if err != nil {
+			return nil, err
+		}
+		err = balanceDelta.Persist(txn)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+		utils.Info(fmt.Sprintf("contract moved tokens [hash=%s, address=%s, delta=%d]", transaction.Hash, address, balanceDelta.Delta))
+	}
+	return accounts, nil
+}
+
+// registerCreatedContracts - Registers the contracts the contracts a transaction ran created, with their accounts
+func registerCreatedContracts(txn *badger.Txn, transaction *types.Transaction, dvmResult *dvm.DVMResult) error {
+	for address, creator := range dvmResult.StorageState.CreatedContracts {
+		contractAddress := hex.EncodeToString(address[:])
+		codeHash := dvmResult.StorageState.EthStateDB.GetCodeHash(address)
+		contract := types.NewCreatedContract(contractAddress, hex.EncodeToString(creator[:]), transaction, hex.EncodeToString(codeHash[:]))
+		err := contract.Persist(txn)
+		if err != nil {
+			return err
+		}
+		for _, stateObject := range dvmResult.StorageState.EthStateDB.StateObjects {
+			if stateObject.Account().Address == contractAddress {
+				stateObject.Account().TransactionHash = transaction.Hash
+				err = stateObject.Account().Persist(txn)
+				if err != nil {
+					return err
+				}
+				break
+			}
+		}
+		utils.Info(fmt.Sprintf("contract created a contract [hash=%s, creator=%s, contractAddress=%s]", transaction.Hash, contract.Deployer, contractAddress))
+	}
+	return nil
+}
+
+// outOfHertz - The transaction used all of its hertz, nothing it did is persisted but its page is still charged
+func outOfHertz(txn *badger.Txn, transaction *types.Transaction, gossip *types.Gossip, receipt *types.Receipt, page *types.Page) error {
+	utils.Error(fmt.Sprintf("out of hertz [hash=%s, hertz=%d]", transaction.Hash, transaction.HertzLimit()))
+	err := persistFailedTransaction(txn, transaction, gossip, receipt, page, transaction.HertzLimit())
+	if err == badger.ErrConflict {
+		return err
+	}
+	receipt.HumanReadableStatus = types.ErrOutOfHertz.Error()
+	receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusOutOfHertz)
+	return nil
+}
+
+// contractReverted - The contract reverted, nothing it did is persisted but its page is charged for the hertz it used
+func contractReverted(txn *badger.Txn, transaction *types.Transaction, gossip *types.Gossip, receipt *types.Receipt, page *types.Page, revertError *dvm.RevertError) error {
+	var theABI *abi.ABI
+	if len(transaction.Abi) > 0 {
+		theABI, _ = helper.GetABI(transaction.Abi)
+	}
+	reason := helper.GetRevertReason(theABI, revertError.Data)
+	utils.Error(fmt.Sprintf("contract reverted [hash=%s, reason=%s]", transaction.Hash, reason))
+	err := persistFailedTransaction(txn, transaction, gossip, receipt, page, int64(revertError.HertzUsed))
+	if err == badger.ErrConflict {
+		return err
+	}
+	receipt.HumanReadableStatus = reason
+	receipt.SetStatusWithNewTransaction(services.GetDb(), types.StatusContractReverted)
+	return nil
+}
+
+// persistFailedTransaction - Persist a failed contract transaction and its gossip so it can still be traced, and charge
+// its page for the hertz it used. They are committed with the pre-state the DVM recorded in txn, badger.ErrConflict is
+// returned so the transaction is executed again
+func persistFailedTransaction(txn *badger.Txn, transaction *types.Transaction, gossip *types.Gossip, receipt *types.Receipt, page *types.Page, hertz int64) error {
+	page.HertzUsed += hertz
+	err := transaction.Persist(txn)
+	if err == nil {
+		err = gossip.Set(txn, services.GetCache())
+	}
+	if err == nil {
+		err = page.Persist(txn)
+	}
+	if err == nil {
+		err = txn.Commit(nil)
+	}
+	if err != nil {
+		utils.Error(err)
+		return err
 	}
+	transaction.Uncache(services.GetCache())
+	receipt.HertzCost = hertz
+	receipt.CumulativeHertzUsed = page.HertzUsed
+	return nil
 }
 
 //TODO: implement if useful
@@ -598,7 +971,7 @@ func processDVMResult(transaction *types.Transaction, dvmResult *dvm.DVMResult,
 					marshalledValues, err := method.Outputs.UnpackValues(dvmResult.ContractMethodExecResult)
 					if err == nil {
 						utils.Info(fmt.Sprintf("CONTRACT-CALL-RES: %v", marshalledValues))
-						receipt.ContractResult = marshalledValues
+						receipt.ContractResult = helper.ToJsonValues(method.Outputs, marshalledValues)
 					} else {
 						errorToReturn = err
 						utils.Error(err)
@@ -0,0 +1,129 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm"
+)
+
+const (
+	// Stores its uint256 argument in slot 0: PUSH1 4 CALLDATALOAD PUSH1 0 SSTORE STOP
+	setterCode = "6007600c60003960076000f3" + "60043560005500"
+	setterAbi  = `[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"set","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
+	// Creates a setter from the init code after it: PUSH1 19 PUSH1 18 PUSH1 0 CODECOPY PUSH1 19 PUSH1 0 PUSH1 0 CREATE
+	// PUSH1 0 SSTORE STOP
+	factoryCode = "6025600c60003960256000f3" + "60136012600039601360006000f060005500" + setterCode
+	factoryAbi  = `[{"constant":false,"inputs":[],"name":"create","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+)
+
+// TestApplyContractBalances - Tokens a contract sent to other accounts are written back to the account table
+func TestApplyContractBalances(t *testing.T) {
+	now := time.Now()
+	fromAccount := &types.Account{Address: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", Balance: big.NewInt(1000), Created: now}
+	toAccount := &types.Account{Address: "c296220327589dc04e6ee01bf16563f0f53895bb", Balance: big.NewInt(100), Created: now}
+	payee := &types.Account{Address: "d70613f93152c84050e7826c4e2b0cc02c1c3b99", Balance: big.NewInt(40), Created: now}
+	newPayee := "0e4c3b56bd1a0f2f8e4e27e9c3bcf8be1d77f103"
+	transaction := &types.Transaction{Hash: "4b0a2f0c0d3a7e9f", Type: types.TypeExecuteSmartContract, From: fromAccount.Address, To: toAccount.Address, Time: 1000}
+
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	err := payee.Persist(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances := map[string]*big.Int{
+		fromAccount.Address: big.NewInt(990),
+		toAccount.Address:   big.NewInt(75),
+		payee.Address:       big.NewInt(70),
+		newPayee:            big.NewInt(5),
+	}
+	accounts, err := applyContractBalances(txn, transaction, balances, fromAccount, toAccount, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fromAccount.Balance.Int64() != 990 || toAccount.Balance.Int64() != 75 {
+		t.Errorf("from and to accounts left with %d and %d, expected 990 and 75", fromAccount.Balance.Int64(), toAccount.Balance.Int64())
+	}
+	if len(accounts) != 2 {
+		t.Errorf("applyContractBalances returning %d accounts, expected 2", len(accounts))
+	}
+	for address, expected := range map[string]int64{payee.Address: 70, newPayee: 5} {
+		account, err := types.ToAccountByAddress(txn, address)
+		if err != nil {
+			t.Fatalf("account %s not persisted: %v", address, err)
+		}
+		if account.Balance.Int64() != expected {
+			t.Errorf("account %s persisted with balance %d, expected %d", address, account.Balance.Int64(), expected)
+		}
+	}
+	balanceDeltas, err := types.ToBalanceDeltasByTransactionHash(txn, transaction.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deltas := map[string]int64{}
+	for _, balanceDelta := range balanceDeltas {
+		deltas[balanceDelta.Address] = balanceDelta.Delta
+	}
+	if len(deltas) != 2 || deltas[payee.Address] != 30 || deltas[newPayee] != 5 {
+		t.Errorf("applyContractBalances recording deltas %v, expected 30 to %s and 5 to %s", deltas, payee.Address, newPayee)
+	}
+}
+
+// TestRegisterCreatedContracts - A contract created by another is registered with its creator as the deployer, it is
+// executed with the ABI its transactions send
+func TestRegisterCreatedContracts(t *testing.T) {
+	factory := deployContract(t, 80, factoryCode, factoryAbi)
+	create := contractTransaction(81, types.TypeExecuteSmartContract, "", factoryAbi)
+	create.To, create.Method = factory, "create"
+
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	dvmResult, err := dvm.GetDVMService().ExecuteSmartContract(txn, create, create.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dvmResult.StorageState.Discard()
+	err = registerCreatedContracts(txn, create, dvmResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dvmResult.StorageState.CreatedContracts) != 1 {
+		t.Fatalf("factory created %d contracts, expected 1", len(dvmResult.StorageState.CreatedContracts))
+	}
+	for address := range dvmResult.StorageState.CreatedContracts {
+		contract, err := types.ToContractByAddress(txn, hex.EncodeToString(address[:]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contract.Deployer != factory || contract.TransactionHash != create.Hash || contract.CodeHash == "" {
+			t.Errorf("created contract registered as %v", contract)
+		}
+		set := &types.Transaction{Type: types.TypeExecuteSmartContract, To: contract.Address, Abi: setterAbi, Method: "set"}
+		if contract.ExecuteAbi(set) != hex.EncodeToString([]byte(setterAbi)) {
+			t.Errorf("created contract executed with ABI %s", contract.ExecuteAbi(set))
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// journal - Records a gossip as in flight
+func journal(gossip *types.Gossip) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	err := types.NewJournalEntry(gossip).Persist(txn)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// journalQueued - Records a gossip as queued for execution once deadline passes
+func journalQueued(gossip *types.Gossip, deadline int64) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	journalEntry := types.NewJournalEntry(gossip)
+	journalEntry.Queued = true
+	journalEntry.Deadline = deadline
+	err := journalEntry.Persist(txn)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// unjournal - Removes a gossip that has reached a terminal status
+func unjournal(transactionHash string) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	err := types.DeleteJournalEntry(txn, transactionHash)
+	if err != nil {
+		utils.Error(err)
+		return
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		utils.Error(err)
+	}
+}
+
+// isJournaled - Is a gossip still in flight?
+func isJournaled(transactionHash string) bool {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	_, err := types.ToJournalEntryByTransactionHash(txn, transactionHash)
+	if err != nil && err != badger.ErrKeyNotFound {
+		utils.Error(err)
+	}
+	return err == nil
+}
+
+// terminate - Gives the receipt of a gossip that can no longer complete a terminal status and removes it from the journal
+func terminate(transactionHash, status, humanReadableStatus string) {
+	receipt := types.NewReceiptWithStatus(transactionHash, status, humanReadableStatus)
+	receipt.Cache(services.GetCache())
+	receipt.SetStatusWithNewTransaction(services.GetDb(), status)
+	unjournal(transactionHash)
+	utils.Warn(fmt.Sprintf("journaled transaction can not complete [hash=%s, status=%s]", transactionHash, status))
+}
+
+// replayJournal - Restores the gossips that were in flight when this delegate stopped
+func (this *DAPoSService) replayJournal() {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	journalEntries, err := types.ToJournalEntries(txn)
+	if err != nil {
+		utils.Error("unable to read journal", err)
+		return
+	}
+	if len(journalEntries) == 0 {
+		return
+	}
+	utils.Info(fmt.Sprintf("replaying %d journaled transactions", len(journalEntries)))
+
+	now := utils.ToMilliSeconds(time.Now())
+
+	for _, journalEntry := range journalEntries {
+		gossip := journalEntry.Gossip
+		hash := gossip.Transaction.Hash
+
+		// Executed before we stopped?
+		_, err = txn.Get([]byte(gossip.Transaction.Key()))
+		if err == nil {
+			unjournal(hash)
+			continue
+		}
+		if err != badger.ErrKeyNotFound {
+			utils.Error(err)
+			continue
+		}
+
+		if journalEntry.Queued {
+			receipt := types.NewReceipt(hash)
+			receipt.Cache(services.GetCache())
+			gossip.Cache(services.GetCache())
+			gossip.Transaction.Cache(services.GetCache())
+			if !this.gossipQueue.Exists(hash) {
+				this.gossipQueue.Push(gossip)
+				go func(delay int64) {
+					if delay > 0 {
+						time.Sleep(time.Duration(delay) * time.Millisecond)
+					}
+					this.timoutChan <- true
+				}(journalEntry.Deadline - now)
+			}
+			continue
+		}
+
+		// Still within the gossip window?
+		gossipWindow := toGossipWindow(gossip.Transaction.Time)
+		if now-gossip.Transaction.Time > gossipWindow {
+			terminate(hash, types.StatusGossipingTimedOut, "Delegate restarted before the transaction reached consensus")
+			continue
+		}
+		receipt := types.NewReceipt(hash)
+		receipt.Cache(services.GetCache())
+		gossip.Cache(services.GetCache())
+		gossip.Transaction.Cache(services.GetCache())
+		this.gossipChan <- gossip
+		go expire(hash, gossipWindow-(now-gossip.Transaction.Time))
+	}
+}
+
+// toGossipWindow - Milliseconds a transaction has to reach consensus, from the delegates configured at its time as the
+// nodes cache is still empty when the journal is replayed at startup
+func toGossipWindow(transactionTime int64) int64 {
+	delegateAddresses := types.GetConfig().GetDelegateAddresses(transactionTime)
+	return int64((types.GossipTimeout * len(delegateAddresses)) + types.TxReceiveTimeout)
+}
+
+// expire - Terminates a replayed gossip that has not been queued for execution by the end of its gossip window
+func expire(transactionHash string, delay int64) {
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	journalEntry, err := types.ToJournalEntryByTransactionHash(txn, transactionHash)
+	if err != nil {
+		if err != badger.ErrKeyNotFound {
+			utils.Error(err)
+		}
+		return
+	}
+	if journalEntry.Queued {
+		return
+	}
+	terminate(transactionHash, types.StatusCouldNotReachConsensus, "Delegate restarted and the transaction did not reach consensus in time")
+}
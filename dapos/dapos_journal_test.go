@@ -0,0 +1,167 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"container/heap"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/queue"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+)
+
+// TestGossipTimeoutUnjournals - A gossip whose rumors took too long is given up on and leaves the journal
+func TestGossipTimeoutUnjournals(t *testing.T) {
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	transaction, err := types.NewTransferTokensTransaction(privateKey, from, "d5765c93699c96327753230ac3d78edb3b34236b", 1, 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gossip := types.NewGossip(*transaction)
+	for _, address := range []string{"c296220327589dc04e6ee01bf16563f0f53895bb", "d70613f93152c84050e7826c4e2b0cc02c1c3b99"} {
+		rumor := types.NewRumor(privateKey, address, transaction.Hash)
+		rumor.Time = now - 3*types.GossipTimeout
+		gossip.Rumors = append(gossip.Rumors, *rumor)
+	}
+	types.NewReceipt(transaction.Hash).Cache(services.GetCache())
+	journal(gossip)
+
+	service := &DAPoSService{gossipChan: make(chan *types.Gossip, 1), gossipQueue: queue.NewGossipQueue()}
+	service.processGossip(gossip)
+
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	_, err = types.ToJournalEntryByTransactionHash(txn, transaction.Hash)
+	if err != badger.ErrKeyNotFound {
+		t.Errorf("timed out gossip left in the journal [err=%v]", err)
+	}
+	receipt, err := types.ToReceiptFromCache(services.GetCache(), transaction.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.StatusGossipingTimedOut {
+		t.Errorf("timed out gossip given receipt status %s", receipt.Status)
+	}
+}
+
+// TestReplayJournalWindow - At startup no delegate has been discovered yet, the gossip window comes from the configured
+// delegates
+func TestReplayJournalWindow(t *testing.T) {
+	config := types.GetConfig()
+	delegateAddresses, delegateSets := config.DelegateAddresses, config.DelegateSets
+	defer func() { config.DelegateAddresses, config.DelegateSets = delegateAddresses, delegateSets }()
+	config.DelegateAddresses = []string{"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c", "c296220327589dc04e6ee01bf16563f0f53895bb", "d70613f93152c84050e7826c4e2b0cc02c1c3b99"}
+	config.DelegateSets = nil
+	window := int64(3*types.GossipTimeout + types.TxReceiveTimeout)
+
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	inWindow, err := types.NewTransferTokensTransaction(privateKey, from, "d5765c93699c96327753230ac3d78edb3b34236b", 1, 1, now-window+types.GossipTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pastWindow, err := types.NewTransferTokensTransaction(privateKey, from, "d5765c93699c96327753230ac3d78edb3b34236b", 2, 1, now-window-types.GossipTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal(types.NewGossip(*inWindow))
+	journal(types.NewGossip(*pastWindow))
+	defer unjournal(inWindow.Hash)
+
+	service := &DAPoSService{gossipChan: make(chan *types.Gossip, 10), gossipQueue: queue.NewGossipQueue()}
+	service.replayJournal()
+
+	replayed := make(map[string]bool)
+	for len(service.gossipChan) > 0 {
+		replayed[(<-service.gossipChan).Transaction.Hash] = true
+	}
+	if !replayed[inWindow.Hash] {
+		t.Error("gossip within the window of the configured delegates not replayed")
+	}
+	if replayed[pastWindow.Hash] {
+		t.Error("gossip past the window of the configured delegates replayed")
+	}
+	receipt, err := types.ToReceiptFromCache(services.GetCache(), pastWindow.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.StatusGossipingTimedOut {
+		t.Errorf("gossip past the window given receipt status %s", receipt.Status)
+	}
+}
+
+// TestQueuedGossipsSurviveReceiptEviction - A burst evicting the receipts of queued gossips does not keep them from executing
+func TestQueuedGossipsSurviveReceiptEviction(t *testing.T) {
+	config := types.GetConfig()
+	isBookkeeper := config.IsBookkeeper
+	defer func() { config.IsBookkeeper = isBookkeeper }()
+	config.IsBookkeeper = true
+
+	privateKey := "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	from := "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	now := utils.ToMilliSeconds(time.Now())
+	service := &DAPoSService{gossipQueue: queue.NewGossipQueue()}
+	hashes := make([]string, 0)
+	for i := int64(0); i < 2; i++ {
+		transaction, err := types.NewTransferTokensTransaction(privateKey, from, "d5765c93699c96327753230ac3d78edb3b34236b", 1+i, 1, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gossip := types.NewGossip(*transaction)
+		gossip.Rumors = append(gossip.Rumors, *types.NewRumor(privateKey, from, transaction.Hash))
+		types.NewReceipt(transaction.Hash).Cache(services.GetCache())
+		journalQueued(gossip, now)
+		// Straight onto the heap, Push hands the gossip to a watcher goroutine and doWork could peek before it lands.
+		heap.Push(service.gossipQueue.Queue, &queue.Item{Data: gossip, Priority: gossip.Transaction.Time})
+		hashes = append(hashes, transaction.Hash)
+	}
+
+	// Burst.
+	for i := 0; i < types.ReceiptCacheSize; i++ {
+		types.NewReceipt(fmt.Sprintf("%064x", i)).Cache(services.GetCache())
+	}
+	for _, hash := range hashes {
+		if _, err := types.ToReceiptFromCache(services.GetCache(), hash); err == nil {
+			t.Fatalf("receipt of %s not evicted", hash)
+		}
+	}
+
+	service.doWork(len(hashes))
+	for _, hash := range hashes {
+		receipt, err := types.ToReceiptFromCache(services.GetCache(), hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receipt.Status == types.StatusReceiptNotFound || receipt.Status == types.StatusPending {
+			t.Errorf("queued gossip %s given receipt status %s", hash, receipt.Status)
+		}
+		txn := services.NewTxn(false)
+		_, err = types.ToJournalEntryByTransactionHash(txn, hash)
+		txn.Discard()
+		if err != badger.ErrKeyNotFound {
+			t.Errorf("executed gossip %s left in the journal [err=%v]", hash, err)
+		}
+	}
+}
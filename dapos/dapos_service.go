@@ -90,6 +90,9 @@ func (this *DAPoSService) disGoverServiceInitFinished() {
 	go this.transactionWorker()
 	//go this.queueWorker()
 
+	// Replay in flight gossips.
+	this.replayJournal()
+
 	utils.Events().Raise(types.Events.DAPoSServiceInitFinished)
 }
 
@@ -113,6 +116,11 @@ func (this *DAPoSService) createGenesisTransactionAndAccount() error {
 			if err != nil {
 				return err
 			}
+			balanceDelta := types.NewBalanceDelta(account.Address, transaction.Hash, transaction.Time, transaction.Value, transaction.Value)
+			err = balanceDelta.Persist(txn)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return txn.Commit(nil)
@@ -58,7 +58,7 @@ func (this *DAPoSService) SynchronizeGrpc(constext context.Context, request *pro
 				return err
 			}
 			keyString := string(key)
-			if !strings.HasPrefix(keyString, "table-") && !strings.HasPrefix(keyString, "key-") && !strings.HasPrefix(keyString, "AccountState-") {
+			if !strings.HasPrefix(keyString, "table-") && !strings.HasPrefix(keyString, "key-") && keyString != "WorldState" {
 				continue
 			}
 
@@ -28,18 +28,30 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/dispatchlabs/disgo/commons/helper"
 	"encoding/hex"
+	"github.com/dispatchlabs/disgo/dvm"
 )
 
 // WithHttp -
 func (this *DAPoSService) WithHttp() *DAPoSService {
 	//Accounts
 	services.GetHttpRouter().HandleFunc("/v1/accounts/{address}", this.getAccountHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/accounts/{address}/balance", this.getBalanceHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/accounts/{address}/statement", this.getStatementHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/accounts", this.unsupportedFunctionHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/address", this.getSeedAddressHandler).Methods("GET")
 	//Transactions
 	services.GetHttpRouter().HandleFunc("/v1/transactions", this.newTransactionHandler).Methods("POST")
+	services.GetHttpRouter().HandleFunc("/v1/transactions/estimate", this.estimateHertzHandler).Methods("POST")
 	services.GetHttpRouter().HandleFunc("/v1/transactions/{hash}", this.getTransactionHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/transactions/{hash}/trace", this.traceTransactionHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/transactions", this.getTransactionsHandler).Methods("GET")
+
+	services.GetHttpRouter().HandleFunc("/v1/contracts", this.getContractsHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/contracts/{address}", this.getContractHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/contracts/{address}/call", this.callSmartContractHandler).Methods("POST")
+	services.GetHttpRouter().HandleFunc("/v1/contracts/{address}/storage", this.getContractStorageHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/contracts/{address}/storage/{slot}", this.getContractStorageSlotHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/logs", this.getLogsHandler).Methods("GET")
 	//Artifacts
 	services.GetHttpRouter().HandleFunc("/v1/artifacts/{query}", this.unsupportedFunctionHandler).Methods("GET") //TODO: support pagination
 	services.GetHttpRouter().HandleFunc("/v1/artifacts/", this.unsupportedFunctionHandler).Methods("POST")
@@ -56,6 +68,7 @@ func (this *DAPoSService) WithHttp() *DAPoSService {
 	services.GetHttpRouter().HandleFunc("/v1/queue", this.getQueueHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips", this.getGossipsHandler).Methods("GET")
 	services.GetHttpRouter().HandleFunc("/v1/gossips/{hash}", this.getGossipHandler).Methods("GET")
+	services.GetHttpRouter().HandleFunc("/v1/caches", this.getCacheMetricsHandler).Methods("GET")
 
 	services.GetHttpRouter().HandleFunc("/v1/receipts/{hash}", this.unsupportedFunctionHandler).Methods("GET")
 
@@ -81,6 +94,7 @@ func setHeaders(response *types.Response, responseWriter *http.ResponseWriter) {
 	// StatusJsonParseError               = "StatusJsonParseError"
 	// StatusInternalError                = "InternalError"
 	// StatusUnavailableFeature           = "UnavailableFeature"
+	// StatusInvalidRequest               = "InvalidRequest"
 
 	if response != nil {
 		if response.Status == types.StatusOk {
@@ -129,6 +143,22 @@ func (this *DAPoSService) getAccountHandler(responseWriter http.ResponseWriter,
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getBalanceHandler
+func (this *DAPoSService) getBalanceHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetBalance(vars["address"], request.URL.Query().Get("at"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getStatementHandler
+func (this *DAPoSService) getStatementHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetStatement(vars["address"], request.URL.Query().Get("from"), request.URL.Query().Get("to"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getTransactionHandler
 func (this *DAPoSService) getTransactionHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	vars := mux.Vars(request)
@@ -160,7 +190,29 @@ func (this *DAPoSService) newTransactionHandler(responseWriter http.ResponseWrit
 	defer txn.Discard()
 
 	if transaction.Type == types.TypeDeploySmartContract {
-		_, err := helper.GetABI(hex.EncodeToString([]byte(transaction.Abi)))
+		theABI, err := helper.GetABI(hex.EncodeToString([]byte(transaction.Abi)))
+		if err != nil {
+			utils.Error("Paramater type error", err)
+			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
+			return
+		}
+
+		// Can the code be deployed?
+		diagnostics := dvm.AnalyseDeploy(transaction, theABI)
+		for _, diagnostic := range diagnostics {
+			utils.Warn(fmt.Sprintf("code %s [hash=%s, check=%s]: %s", diagnostic.Severity, transaction.Hash, diagnostic.Check, diagnostic.Message))
+		}
+		if dvm.HasErrors(diagnostics) {
+			response := types.NewResponseWithStatus(types.StatusInvalidCode, "the code did not pass the deploy checks")
+			response.Data = diagnostics
+			setHeaders(response, &responseWriter)
+			responseWriter.Write([]byte(response.String()))
+			return
+		}
+
+		constructorTransaction := *transaction
+		constructorTransaction.Abi = hex.EncodeToString([]byte(transaction.Abi))
+		_, err = helper.GetConvertedConstructorParams(&constructorTransaction)
 		if err != nil {
 			utils.Error("Paramater type error", err)
 			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
@@ -169,13 +221,13 @@ func (this *DAPoSService) newTransactionHandler(responseWriter http.ResponseWrit
 	}
 
 	if transaction.Type == types.TypeExecuteSmartContract {
-		contractTx, err := types.ToTransactionByAddress(txn, transaction.To)
+		contract, err := types.ToContractByAddress(txn, transaction.To)
 		if err != nil {
 			utils.Error(err)
 			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: Could not find contract with address %s"}`, types.StatusNotFound, transaction.To), http.StatusBadRequest)
 			return
 		}
-		transaction.Abi = contractTx.Abi
+		transaction.Abi = contract.ExecuteAbi(transaction)
 		transaction.Params, err = helper.GetConvertedParams(transaction)
 		if err != nil {
 			utils.Error("Paramater type error", err)
@@ -189,6 +241,111 @@ func (this *DAPoSService) newTransactionHandler(responseWriter http.ResponseWrit
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getLogsHandler
+func (this *DAPoSService) getLogsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	response := this.GetLogs(query.Get("address"), query.Get("topics"), query.Get("from"), query.Get("to"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// traceTransactionHandler
+func (this *DAPoSService) traceTransactionHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	query := request.URL.Query()
+	response := this.TraceTransaction(vars["hash"], query.Get("tracer"), query.Get("disableMemory"), query.Get("disableStack"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// estimateHertzHandler
+func (this *DAPoSService) estimateHertzHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		utils.Error("unable to read HTTP body of request", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInternalError, err), http.StatusInternalServerError)
+		return
+	}
+	transaction, err := types.ToTransactionFromJson(body)
+	if err != nil {
+		utils.Error("Paramater type error", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
+		return
+	}
+	response := this.EstimateHertz(transaction)
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// callSmartContractHandler
+func (this *DAPoSService) callSmartContractHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		utils.Error("unable to read HTTP body of request", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInternalError, err), http.StatusInternalServerError)
+		return
+	}
+	contractCall, err := types.ToContractCallFromJson(body)
+	if err != nil {
+		utils.Error("Paramater type error", err)
+		services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusJsonParseError, err), http.StatusBadRequest)
+		return
+	}
+	response := this.CallSmartContract(vars["address"], contractCall)
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getContractHandler
+func (this *DAPoSService) getContractHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetContract(vars["address"])
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getContractsHandler
+func (this *DAPoSService) getContractsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	pageNumber := query.Get("page")
+	if pageNumber == "" {
+		pageNumber = "1"
+	}
+	pageLimit := query.Get("pageSize")
+	if pageLimit == "" {
+		pageLimit = "10"
+	}
+	response := this.GetContracts(query.Get("deployer"), pageNumber, pageLimit, query.Get("pageStart"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getContractStorageHandler
+func (this *DAPoSService) getContractStorageHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	query := request.URL.Query()
+	pageNumber := query.Get("page")
+	if pageNumber == "" {
+		pageNumber = "1"
+	}
+	pageLimit := query.Get("pageSize")
+	if pageLimit == "" {
+		pageLimit = "10"
+	}
+	response := this.GetContractStorage(vars["address"], pageNumber, pageLimit, query.Get("pageStart"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
+// getContractStorageSlotHandler
+func (this *DAPoSService) getContractStorageSlotHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	response := this.GetContractStorageSlot(vars["address"], vars["slot"], request.URL.Query().Get("proof"))
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 func (this *DAPoSService) getTransactionsHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	response := types.NewResponse()
 	pageNumber := request.URL.Query().Get("page")
@@ -225,6 +382,13 @@ func (this *DAPoSService) getQueueHandler(responseWriter http.ResponseWriter, re
 	responseWriter.Write([]byte(response.String()))
 }
 
+// getCacheMetricsHandler
+func (this *DAPoSService) getCacheMetricsHandler(responseWriter http.ResponseWriter, request *http.Request) {
+	response := this.GetCacheMetrics()
+	setHeaders(response, &responseWriter)
+	responseWriter.Write([]byte(response.String()))
+}
+
 // getArtifactHandler
 func (this *DAPoSService) unsupportedFunctionHandler(responseWriter http.ResponseWriter, request *http.Request) {
 	response := this.ToBeSupported()
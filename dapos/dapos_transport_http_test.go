@@ -0,0 +1,200 @@
+/*
+ *    This file is part of DAPoS library.
+ *
+ *    The DAPoS library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DAPoS library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DAPoS library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dapos
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/disgover"
+	"github.com/dispatchlabs/disgo/dvm"
+	"github.com/gorilla/mux"
+)
+
+// The init code of the contracts returns the runtime after it
+const (
+	// Counts its uint256 argument down to zero so its cost depends on it
+	loopCode = "6012600c60003960126000f3" + "6004355b8015601057600190036003565b00"
+	loopAbi  = `[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"loop","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
+	// Stores n in slot n - 1 and so on down to 1 in slot 0 for its uint256 argument n
+	storeCode = "6018600c60003960186000f3" + "6004355b801560165760019003806001018155600356" + "5b00"
+	storeAbi  = `[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"store","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+)
+
+// contractTransaction - A deploy or execute transaction of the test contracts
+func contractTransaction(hash int, typ byte, code, abi string) *types.Transaction {
+	return &types.Transaction{
+		Hash: fmt.Sprintf("%064x", hash),
+		Type: typ,
+		From: "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c",
+		Code: code,
+		Abi:  hex.EncodeToString([]byte(abi)),
+		Time: int64(hash),
+	}
+}
+
+// deployContract - Deploys and registers a contract
+func deployContract(t *testing.T, hash int, code, abi string) string {
+	transaction := contractTransaction(hash, types.TypeDeploySmartContract, code, abi)
+	var dvmResult *dvm.DVMResult
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		var err error
+		dvmResult, err = dvm.GetDVMService().DeploySmartContract(txn, transaction, transaction.From)
+		if err != nil {
+			return err
+		}
+		return types.NewContract(hex.EncodeToString(dvmResult.ContractAddress[:]), transaction, "").Persist(txn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dvmResult.StorageState.Persisted()
+	return hex.EncodeToString(dvmResult.ContractAddress[:])
+}
+
+// estimate - Posts a transaction to the estimate endpoint
+func estimate(t *testing.T, service *DAPoSService, transaction string) (string, *types.HertzEstimate) {
+	recorder := httptest.NewRecorder()
+	service.estimateHertzHandler(recorder, httptest.NewRequest("POST", "/v1/transactions/estimate", bytes.NewBufferString(transaction)))
+	var response struct {
+		Status string              `json:"status"`
+		Data   types.HertzEstimate `json:"data"`
+	}
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.Status, &response.Data
+}
+
+// asDelegate - Makes this node a delegate until the returned function is called
+func asDelegate() func() {
+	thisNode := disgover.GetDisGoverService().ThisNode
+	nodeType := thisNode.Type
+	thisNode.Type = types.TypeDelegate
+	return func() { thisNode.Type = nodeType }
+}
+
+// TestEstimateHertzHandler - The endpoint estimates more hertz for more work and reports when the hertz limit is too low
+func TestEstimateHertzHandler(t *testing.T) {
+	defer asDelegate()()
+	service := &DAPoSService{}
+	contractAddress := deployContract(t, 1, loopCode, loopAbi)
+	loop := `{"type":%d,"from":"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c","to":"%s","method":"loop","params":[%d],"time":2,"hertz":%d}`
+
+	status, few := estimate(t, service, fmt.Sprintf(loop, types.TypeExecuteSmartContract, contractAddress, 10, 0))
+	if status != types.StatusOk {
+		t.Fatalf("estimate of loop(10) returned status %s", status)
+	}
+	status, many := estimate(t, service, fmt.Sprintf(loop, types.TypeExecuteSmartContract, contractAddress, 100, 0))
+	if status != types.StatusOk {
+		t.Fatalf("estimate of loop(100) returned status %s", status)
+	}
+	if many.Hertz <= few.Hertz {
+		t.Errorf("estimate of loop(100) is %d, expected more than the %d of loop(10)", many.Hertz, few.Hertz)
+	}
+
+	status, bounded := estimate(t, service, fmt.Sprintf(loop, types.TypeExecuteSmartContract, contractAddress, 100, few.Hertz))
+	if status != types.StatusOutOfHertz {
+		t.Errorf("estimate of loop(100) limited to %d hertz returned status %s, expected %s", few.Hertz, status, types.StatusOutOfHertz)
+	}
+	if bounded.Hertz != few.Hertz {
+		t.Errorf("estimate limited to %d hertz returned %d", few.Hertz, bounded.Hertz)
+	}
+}
+
+// getContractStorage - Gets a storage endpoint of a contract
+func getContractStorage(t *testing.T, handler http.HandlerFunc, url string, vars map[string]string) (string, *dvm.ContractStorage, *types.PagingResult) {
+	recorder := httptest.NewRecorder()
+	handler(recorder, mux.SetURLVars(httptest.NewRequest("GET", url, nil), vars))
+	var response struct {
+		Status string              `json:"status"`
+		Data   dvm.ContractStorage `json:"data"`
+		Paging types.PagingResult  `json:"paging"`
+	}
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.Status, &response.Data, &response.Paging
+}
+
+// TestContractStorageHandlers - The storage endpoints page through the slots and prove them against the storage root
+func TestContractStorageHandlers(t *testing.T) {
+	defer asDelegate()()
+	service := &DAPoSService{}
+	contractAddress := deployContract(t, 10, storeCode, storeAbi)
+	store := contractTransaction(11, types.TypeExecuteSmartContract, "", storeAbi)
+	store.To, store.Method, store.Params = contractAddress, "store", []interface{}{big.NewInt(3)}
+	var dvmResult *dvm.DVMResult
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		var err error
+		dvmResult, err = dvm.GetDVMService().ExecuteSmartContract(txn, store, store.From)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dvmResult.StorageState.Persisted()
+	vars := map[string]string{"address": contractAddress}
+	url := "/v1/contracts/" + contractAddress + "/storage"
+
+	status, first, paging := getContractStorage(t, service.getContractStorageHandler, url+"?pageSize=2", vars)
+	if status != types.StatusOk || len(first.Slots) != 2 || paging.Count != 2 || first.NextSlot == "" {
+		t.Fatalf("first page returned status %s, %d slots, a count of %d and next slot %q", status, len(first.Slots), paging.Count, first.NextSlot)
+	}
+	status, last, paging := getContractStorage(t, service.getContractStorageHandler, url+"?pageSize=2&pageStart="+first.NextSlot, vars)
+	if status != types.StatusOk || len(last.Slots) != 1 || paging.Count != 1 || last.NextSlot != "" {
+		t.Fatalf("last page returned status %s, %d slots, a count of %d and next slot %q", status, len(last.Slots), paging.Count, last.NextSlot)
+	}
+	status, _, _ = getContractStorage(t, service.getContractStorageHandler, url+"?pageSize=500", vars)
+	if status != types.StatusInvalidRequest {
+		t.Errorf("page of 500 slots returned status %s", status)
+	}
+
+	for _, storageSlot := range append(first.Slots, last.Slots...) {
+		vars := map[string]string{"address": contractAddress, "slot": storageSlot.Key}
+		status, proven, _ := getContractStorage(t, service.getContractStorageSlotHandler, url+"/"+storageSlot.Key+"?proof=true", vars)
+		if status != types.StatusOk || len(proven.Slots) != 1 {
+			t.Fatalf("slot %s returned status %s", storageSlot.Key, status)
+		}
+		proof := make([][]byte, len(proven.Slots[0].Proof))
+		for i, node := range proven.Slots[0].Proof {
+			proof[i], _ = hex.DecodeString(node)
+		}
+		root, _ := hex.DecodeString(proven.StorageRoot)
+		key, _ := hex.DecodeString(storageSlot.Key)
+		value, err := dvm.VerifyStorageProof(crypto.BytesToHash(root), crypto.BytesToHash(key), proof)
+		if err != nil {
+			t.Fatalf("proof of slot %s does not verify: %v", storageSlot.Key, err)
+		}
+		if hex.EncodeToString(value[:]) != storageSlot.Value {
+			t.Errorf("proof of slot %s shows %x, expected %s", storageSlot.Key, value, storageSlot.Value)
+		}
+	}
+}
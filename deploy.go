@@ -0,0 +1,132 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/helper"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/sdk"
+)
+
+// libraryFlags - The repeated -lib name=address flags of disgo deploy
+type libraryFlags map[string]string
+
+func (this libraryFlags) String() string {
+	return fmt.Sprint(map[string]string(this))
+}
+
+func (this libraryFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("library %s is not name=address", value)
+	}
+	this[parts[0]] = parts[1]
+	return nil
+}
+
+// runDeploy - disgo deploy, deploys a contract out of solc, Truffle or Hardhat output through a delegate, returns the exit code
+func runDeploy(args []string) int {
+	libraries := libraryFlags{}
+	flags := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	artifactFile := flags.String("artifact", "", "Path to solc standard-JSON or --combined-json output, or a Truffle or Hardhat artifact (required)")
+	contract := flags.String("contract", "", "Contract to deploy, by name or source:name (default the only contract with code)")
+	flags.Var(libraries, "lib", "Library address as name=address or source:name=address, repeatable")
+	params := flags.String("params", "[]", "Constructor arguments as a JSON array")
+	value := flags.Int64("value", 0, "Tokens sent to the constructor")
+	delegate := flags.String("delegate", "", "Delegate to deploy through as host:port (required)")
+	privateKey := flags.String("key", "", "Private key of the deployer in hex (required)")
+	wait := flags.Duration("wait", 0, "How long to wait for the receipt, 0 does not wait")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: disgo deploy -artifact <file> -delegate <host:port> -key <private key> [-contract <name>] [-lib <name=address>]... [-params <json>] [-value <tokens>] [-wait <duration>]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *artifactFile == "" || *delegate == "" || *privateKey == "" {
+		flags.Usage()
+		return 2
+	}
+
+	output, err := ioutil.ReadFile(*artifactFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	artifact, err := helper.ReadArtifact(output, *contract, libraries)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var constructorParams []interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(*params)))
+	decoder.UseNumber()
+	if err = decoder.Decode(&constructorParams); err != nil {
+		fmt.Fprintf(os.Stderr, "params are not a JSON array: %v\n", err)
+		return 2
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(*privateKey, "0x"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid private key: %v\n", err)
+		return 2
+	}
+	host, port, err := net.SplitHostPort(*delegate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid delegate: %v\n", err)
+		return 2
+	}
+	portNumber, err := strconv.ParseInt(port, 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid delegate port: %v\n", err)
+		return 2
+	}
+	delegateNode := types.Node{HttpEndpoint: &types.Endpoint{Host: host, Port: portNumber}}
+
+	hash, err := sdk.DeployArtifact(delegateNode, strings.TrimPrefix(*privateKey, "0x"), crypto.PubkeyToAddress(key.PublicKey), artifact, *value, constructorParams...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to deploy %s: %v\n", artifact.Name, err)
+		return 1
+	}
+	fmt.Printf("deploying %s [hash=%s]\n", artifact.Name, hash)
+	if *wait <= 0 {
+		return 0
+	}
+
+	receipt, err := sdk.WaitForReceipt(delegateNode, hash, *wait)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if receipt.Status != types.StatusOk {
+		fmt.Fprintf(os.Stderr, "deploy failed: %s %s\n", receipt.Status, receipt.HumanReadableStatus)
+		return 1
+	}
+	fmt.Printf("deployed %s [contractAddress=%s]\n", artifact.Name, receipt.ContractAddress)
+	return 0
+}
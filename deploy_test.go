@@ -0,0 +1,147 @@
+/*
+ *    This file is part of Disgo library.
+ *
+ *    The Disgo library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The Disgo library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the Disgo library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+const testPrivateKey = "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+
+// testArtifact - Writes a Hardhat artifact to a temporary file, returns its path
+func testArtifact(t *testing.T) string {
+	file, err := ioutil.TempFile("", "artifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(`{"contractName":"Token","sourceName":"contracts/Token.sol","abi":[{"type":"constructor","inputs":[{"name":"name","type":"string"}],"payable":true,"stateMutability":"payable"}],"bytecode":"0x6080604052"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file.Name()
+}
+
+// testDelegate - A delegate that accepts deploys and reports them as deployed, with the transactions it received
+func testDelegate(t *testing.T) (*httptest.Server, func() []*types.Transaction) {
+	var mutex sync.Mutex
+	received := make([]*types.Transaction, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if request.Method == http.MethodPost && request.URL.Path == "/v1/transactions" {
+			body, _ := ioutil.ReadAll(request.Body)
+			transaction, err := types.ToTransactionFromJson(body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			received = append(received, transaction)
+			responseWriter.Write([]byte(types.NewResponseWithStatus(types.StatusPending, "Pending").String()))
+			return
+		}
+		for _, transaction := range received {
+			if request.URL.Path == "/v1/transactions/"+transaction.Hash {
+				transaction.Receipt = types.Receipt{Status: types.StatusOk, ContractAddress: "95d7b0f7dd388e37b2c8eebd2ced116817c8bf4e"}
+				response := types.NewResponse()
+				response.Data = transaction
+				responseWriter.Write([]byte(response.String()))
+				return
+			}
+		}
+		responseWriter.Write([]byte(types.NewResponseWithStatus(types.StatusNotFound, "Not Found").String()))
+	}))
+	return server, func() []*types.Transaction {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return received
+	}
+}
+
+// TestRunDeploy
+func TestRunDeploy(t *testing.T) {
+	artifact := testArtifact(t)
+	defer os.Remove(artifact)
+	server, received := testDelegate(t)
+	defer server.Close()
+	delegate := strings.TrimPrefix(server.URL, "http://")
+
+	code := runDeploy([]string{"-artifact", artifact, "-delegate", delegate, "-key", "0x" + testPrivateKey, "-params", `["Token"]`, "-value", "25", "-wait", "5s"})
+	if code != 0 {
+		t.Fatalf("runDeploy returning %d", code)
+	}
+	transactions := received()
+	if len(transactions) != 1 {
+		t.Fatalf("delegate received %d transactions", len(transactions))
+	}
+	transaction := transactions[0]
+	if transaction.Type != types.TypeDeploySmartContract || transaction.From != "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c" || transaction.Value != 25 {
+		t.Errorf("deploy %v", transaction)
+	}
+	if transaction.Code != "6080604052" || len(transaction.Params) != 1 || transaction.Params[0] != "Token" {
+		t.Errorf("deploy of code %s with params %v", transaction.Code, transaction.Params)
+	}
+	if err := transaction.Verify(); err != nil {
+		t.Errorf("cannot verify deploy: %v", err)
+	}
+}
+
+// TestRunDeployArguments
+func TestRunDeployArguments(t *testing.T) {
+	artifact := testArtifact(t)
+	defer os.Remove(artifact)
+
+	// A delegate nothing listens on, none of the deploys get to it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegate := listener.Addr().String()
+	listener.Close()
+
+	for _, test := range []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"no artifact", []string{"-delegate", delegate, "-key", testPrivateKey}, 2},
+		{"no delegate", []string{"-artifact", artifact, "-key", testPrivateKey}, 2},
+		{"no key", []string{"-artifact", artifact, "-delegate", delegate}, 2},
+		{"unknown flag", []string{"-lang", "java"}, 2},
+		{"library not name=address", []string{"-artifact", artifact, "-delegate", delegate, "-key", testPrivateKey, "-lib", "Math"}, 2},
+		{"params not an array", []string{"-artifact", artifact, "-delegate", delegate, "-key", testPrivateKey, "-params", `{"name":"Token"}`}, 2},
+		{"invalid key", []string{"-artifact", artifact, "-delegate", delegate, "-key", "zz"}, 2},
+		{"delegate without port", []string{"-artifact", artifact, "-delegate", "localhost", "-key", testPrivateKey}, 2},
+		{"delegate port not a number", []string{"-artifact", artifact, "-delegate", "localhost:http", "-key", testPrivateKey}, 2},
+		{"missing artifact", []string{"-artifact", artifact + ".missing", "-delegate", delegate, "-key", testPrivateKey}, 1},
+		{"unknown contract", []string{"-artifact", artifact, "-contract", "Other", "-delegate", delegate, "-key", testPrivateKey}, 1},
+		{"delegate down", []string{"-artifact", artifact, "-delegate", delegate, "-key", testPrivateKey}, 1},
+	} {
+		if code := runDeploy(test.args); code != test.expected {
+			t.Errorf("%s: runDeploy returning %d, expected %d", test.name, code, test.expected)
+		}
+	}
+}
@@ -158,6 +158,61 @@ func (db *BadgerDatabase) Dump() {
 	//}
 }
 
+// TxnDatabase - Reads and writes through a Badger transaction of the caller, nothing written is stored until the
+// caller commits it and reads see what was written before
+type TxnDatabase struct {
+	txn *badger.Txn
+}
+
+func NewTxnDatabase(txn *badger.Txn) *TxnDatabase {
+	disgoServices.GetDbService()
+	return &TxnDatabase{txn: txn}
+}
+
+func (db *TxnDatabase) Put(key []byte, value []byte) error {
+	utils.Debug(fmt.Sprintf("TxnDatabase-PUT-Key   : %s", crypto.Encode(key)))
+
+	// Badger keeps the slices until the transaction commits
+	return db.txn.Set(common.CopyBytes(key), common.CopyBytes(value))
+}
+
+func (db *TxnDatabase) Get(key []byte) ([]byte, error) {
+	utils.Debug(fmt.Sprintf("TxnDatabase-GET-Key   : %s", crypto.Encode(key)))
+
+	item, err := db.txn.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return common.CopyBytes(val), nil
+}
+
+func (db *TxnDatabase) Has(key []byte) (bool, error) {
+	item, err := db.Get(key)
+	if err != nil {
+		return false, err
+	}
+	return (item != nil), nil
+}
+
+func (db *TxnDatabase) Delete(key []byte) error {
+	return db.txn.Delete(common.CopyBytes(key))
+}
+
+func (db *TxnDatabase) Close() {
+}
+
+func (db *TxnDatabase) NewBatch() ethdbInterfaces.Batch {
+	return &memBatch{db: db}
+}
+
+func (db *TxnDatabase) Dump() {
+	GetBadgerDatabase().Dump()
+}
+
 // func (db *BadgerDatabase) Keys() [][]byte {
 // 	db.lock.RLock()
 // 	defer db.lock.RUnlock()
@@ -178,7 +233,7 @@ func (db *BadgerDatabase) Dump() {
 type kv struct{ k, v []byte }
 
 type memBatch struct {
-	db     *BadgerDatabase
+	db     ethdbInterfaces.Putter
 	writes []kv
 	size   int
 }
@@ -221,7 +276,9 @@ func (b *memBatch) Write() error {
 		// utils.Debug(fmt.Sprintf("memBatch-Write-KEY-RAW: %v", kv.k))
 		// utils.Debug(fmt.Sprintf("memBatch-Write-VAL-RAW: %v", kv.v))
 
-		b.db.Put(kv.k, kv.v)
+		if err := b.db.Put(kv.k, kv.v); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,134 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/vm"
+)
+
+// Severities of a CodeDiagnostic
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// CodeDiagnostic - A problem found in the code or ABI of a deploy, one with SeverityError rejects the deploy
+type CodeDiagnostic struct {
+	Severity string  `json:"severity"`
+	Check    string  `json:"check"`
+	Message  string  `json:"message"`
+	PC       *uint64 `json:"pc,omitempty"`
+	Opcode   string  `json:"opcode,omitempty"`
+	Method   string  `json:"method,omitempty"`
+}
+
+// AnalyseDeploy - Checks the code of a deploy and the ABI it was sent with before it is gossiped, so a deploy that can
+// not work is rejected up front instead of with an InternalError receipt
+func AnalyseDeploy(tx *commonTypes.Transaction, theABI *abi.ABI) []*CodeDiagnostic {
+	diagnostics := make([]*CodeDiagnostic, 0)
+
+	// Hex?
+	code, err := hex.DecodeString(strings.TrimPrefix(tx.Code, "0x"))
+	if err != nil {
+		return append(diagnostics, &CodeDiagnostic{Severity: SeverityError, Check: "hex", Message: fmt.Sprintf("code is not valid hex: %v", err)})
+	}
+	if len(code) == 0 {
+		return append(diagnostics, &CodeDiagnostic{Severity: SeverityError, Check: "hex", Message: "code is empty"})
+	}
+
+	// Size? The code deployed is limited to params.MaxCodeSize, the code creating it to twice that.
+	if len(code) > params.MaxInitCodeSize {
+		diagnostics = append(diagnostics, &CodeDiagnostic{Severity: SeverityError, Check: "size", Message: fmt.Sprintf("code is %d bytes, the limit is %d", len(code), params.MaxInitCodeSize)})
+	}
+
+	// Instructions under the forks active at the time of the deploy.
+	chainConfig := newChainConfig(tx)
+	pageNumber := big.NewInt(commonTypes.ToPageNumber(tx.Time))
+	analysis := vm.AnalyseCode(withoutMetadata(code), newVMConfig(chainConfig, pageNumber, vm.Config{}), chainConfig, pageNumber)
+	for _, instruction := range analysis.Unsupported {
+		pc := instruction.PC
+		diagnostics = append(diagnostics, &CodeDiagnostic{Severity: SeverityError, Check: "opcode", Message: fmt.Sprintf("%s at %d is not supported by the forks active on this network", instruction.Op, pc), PC: &pc, Opcode: instruction.Op.String()})
+	}
+	for _, instruction := range analysis.InvalidJumps {
+		pc := instruction.PC
+		diagnostics = append(diagnostics, &CodeDiagnostic{Severity: SeverityWarning, Check: "jump", Message: fmt.Sprintf("%s at %d is not to a JUMPDEST", instruction.Op, pc), PC: &pc, Opcode: instruction.Op.String()})
+	}
+	if analysis.Truncated {
+		diagnostics = append(diagnostics, &CodeDiagnostic{Severity: SeverityWarning, Check: "jump", Message: "code ends inside the data of a PUSH"})
+	}
+
+	// Does the code dispatch to the functions of the ABI? A warning only, the selector can be computed or pushed as part
+	// of a larger constant
+	names := make([]string, 0, len(theABI.Methods))
+	for name := range theABI.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		method := theABI.Methods[name]
+		if !hasSelector(code, method.Id()) {
+			diagnostics = append(diagnostics, &CodeDiagnostic{Severity: SeverityWarning, Check: "selector", Message: fmt.Sprintf("selector %x of %s is not in the code", method.Id(), method.Sig()), Method: name})
+		}
+	}
+	return diagnostics
+}
+
+// HasErrors - Does any of the diagnostics reject the deploy?
+func HasErrors(diagnostics []*CodeDiagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutMetadata - Code without the CBOR encoded metadata Solidity appends, its length is in the last two bytes
+func withoutMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	start := len(code) - 2 - length
+	if length == 0 || start < 0 || code[start] < 0xa1 || code[start] > 0xa5 {
+		return code
+	}
+	return code[:start]
+}
+
+// hasSelector - Compilers compare the selector of a call with a PUSH4, or a shorter PUSH when it starts with zero bytes
+func hasSelector(code []byte, selector []byte) bool {
+	if bytes.Contains(code, selector) {
+		return true
+	}
+	trimmed := bytes.TrimLeft(selector, "\x00")
+	if len(trimmed) == 0 {
+		return true
+	}
+	return bytes.Contains(code, append([]byte{byte(vm.PUSH1) + byte(len(trimmed)-1)}, trimmed...))
+}
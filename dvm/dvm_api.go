@@ -19,10 +19,11 @@ package dvm
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
+	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
-	"github.com/dispatchlabs/disgo/commons/services"
 	commonTypes "github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
@@ -30,12 +31,13 @@ import (
 	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
 )
 
-// DeploySmartContract -
-func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMResult, error) {
+// DeploySmartContract - The coinbase is the delegate that first received the transaction. The world state is read and
+// written through txn so it only moves when the caller commits txn, StorageState must then be told it was persisted.
+func (dvm *DVMService) DeploySmartContract(txn *badger.Txn, tx *commonTypes.Transaction, coinbase string) (*DVMResult, error) {
 	utils.Debug(fmt.Sprintf("DVMServices-DeploySmartContract: %s", tx))
 
 	// Load the TRIE state for [FROM:TO] combo
-	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelper(crypto.GetAddressBytes(tx.To)) // crypto.GetAddressBytes(tx.From),
+	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelperWithTxn(txn, crypto.GetAddressBytes(tx.To)) // crypto.GetAddressBytes(tx.From),
 	if err != nil {
 		// return nil, err
 
@@ -59,8 +61,12 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 	}
 
 	stateHelper.EthStateDB.SetNonce(crypto.GetAddressBytes(tx.From), uint64(tx.Time))
-	if err := dvm.applyTransaction(tx, stateHelper); err != nil {
+	if err := dvm.applyTransaction(tx, coinbase, stateHelper); err != nil {
 		utils.Error(err)
+		// Record what the failed transaction ran against so it can still be traced
+		if recordErr := stateHelper.RecordPreState(tx); recordErr != nil {
+			utils.Error(recordErr)
+		}
 		// return nil, err
 
 		return &DVMResult{
@@ -108,8 +114,8 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 	}
 
 	// Get info about the TX
-	bytes, _ := hex.DecodeString(tx.Hash)
-	receipt, err := dvm.getReceipt(bytes)
+	receipt := stateHelper.Receipts[len(stateHelper.Receipts)-1]
+	balances := stateHelper.EthStateDB.BalanceChanges()
 
 	return &DVMResult{
 		From:                     crypto.GetAddressBytes(tx.From),
@@ -127,17 +133,15 @@ func (dvm *DVMService) DeploySmartContract(tx *commonTypes.Transaction) (*DVMRes
 		CumulativeHertzUsed: receipt.CumulativeGasUsed,
 		Bloom:               receipt.Bloom,
 		Logs:                receipt.Logs,
+		Balances:            balances,
 	}, nil
 }
 
-// ExecuteSmartContract -
-func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMResult, error) {
+// ExecuteSmartContract - The coinbase is the delegate that first received the transaction. The world state is read and
+// written through txn so it only moves when the caller commits txn, StorageState must then be told it was persisted.
+func (dvm *DVMService) ExecuteSmartContract(txn *badger.Txn, tx *commonTypes.Transaction, coinbase string) (*DVMResult, error) {
 	utils.Debug(fmt.Sprintf("DVMServices-ExecuteSmartContract: %s", tx))
 
-	// Load the contract transaction
-	txn := services.NewTxn(true)
-	defer txn.Discard()
-
 	/*
 		contractTx, err := commonTypes.ToTransactionByAddress(txn, tx.To)
 		if err != nil {
@@ -161,7 +165,7 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 		}
 	*/
 	// Load the TRIE state for [FROM:TO] combo
-	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelper(crypto.GetAddressBytes(tx.To)) // crypto.GetAddressBytes(tx.From)
+	stateHelper, err := vmstatehelperimplemtations.NewVMStateHelperWithTxn(txn, crypto.GetAddressBytes(tx.To)) // crypto.GetAddressBytes(tx.From)
 	if err != nil {
 		// return nil, err
 
@@ -242,16 +246,20 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 		crypto.GetAddressBytes(tx.From),
 		&toAsBytes,
 		0, // nonce
-		vmstatehelperimplemtations.DefaultValue,
-		vmstatehelperimplemtations.DefaultGas.Uint64(),
+		big.NewInt(tx.Value),
+		uint64(tx.HertzLimit()),
 		vmstatehelperimplemtations.DefaultGasPrice,
 		callData,
 		false,
 	)
 
-	execResult, execError := dvm.call(tx, callMsg, stateHelper)
+	execResult, execError := dvm.call(tx, coinbase, callMsg, stateHelper)
 	if execError != nil {
 		utils.Error(execError)
+		// Record what the failed transaction ran against so it can still be traced
+		if recordErr := stateHelper.RecordPreState(tx); recordErr != nil {
+			utils.Error(recordErr)
+		}
 		// return nil, execError
 
 		return &DVMResult{
@@ -299,8 +307,8 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 	}
 
 	// Get info about the TX
-	bytes, _ := hex.DecodeString(tx.Hash)
-	receipt, err := dvm.getReceipt(bytes)
+	receipt := stateHelper.Receipts[len(stateHelper.Receipts)-1]
+	balances := stateHelper.EthStateDB.BalanceChanges()
 
 	// Return the state of the storage and the execution result
 	return &DVMResult{
@@ -319,5 +327,72 @@ func (dvm *DVMService) ExecuteSmartContract(tx *commonTypes.Transaction) (*DVMRe
 		CumulativeHertzUsed: receipt.CumulativeGasUsed,
 		Bloom:               receipt.Bloom,
 		Logs:                receipt.Logs,
+		Balances:            balances,
+	}, nil
+}
+
+// CallSmartContract - Executes a smart contract method against a throwaway copy of the contract's state, nothing is committed.
+// The coinbase is the delegate serving the call
+func (dvm *DVMService) CallSmartContract(tx *commonTypes.Transaction, coinbase string) (*DVMResult, error) {
+	utils.Debug(fmt.Sprintf("DVMServices-CallSmartContract: %s", tx))
+
+	// Load a read-only copy of the TRIE state for the contract
+	stateHelper, err := vmstatehelperimplemtations.NewReadOnlyVMStateHelper(crypto.GetAddressBytes(tx.To))
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the method params from ABI
+	fromHexAsByteArray, _ := hex.DecodeString(tx.Abi)
+	jsonABI, err := abi.JSON(strings.NewReader(string(fromHexAsByteArray)))
+	if err != nil {
+		return nil, err
+	}
+	callData, err := jsonABI.Pack(tx.Method, tx.Params...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute the Smart-Contract Method
+	toAsBytes := crypto.GetAddressBytes(tx.To)
+	callMsg := ethTypes.NewMessage(
+		crypto.GetAddressBytes(tx.From),
+		&toAsBytes,
+		0, // nonce
+		vmstatehelperimplemtations.DefaultValue,
+		uint64(tx.HertzLimit()),
+		vmstatehelperimplemtations.DefaultGasPrice,
+		callData,
+		false,
+	)
+	execResult, execError := dvm.call(tx, coinbase, callMsg, stateHelper)
+	if execError != nil {
+		return nil, execError
+	}
+
+	// The receipt only lives in the state helper, it is never written
+	var hertzCost uint64
+	var status uint
+	if len(stateHelper.Receipts) > 0 {
+		receipt := stateHelper.Receipts[len(stateHelper.Receipts)-1]
+		hertzCost = receipt.GasUsed
+		status = receipt.Status
+	}
+	if status == ethTypes.ReceiptStatusFailed {
+		return nil, fmt.Errorf("call of method %s failed", tx.Method)
+	}
+
+	return &DVMResult{
+		From:                     crypto.GetAddressBytes(tx.From),
+		To:                       toAsBytes,
+		ABI:                      tx.Abi,
+		StorageState:             stateHelper,
+		ContractAddress:          toAsBytes,
+		ContractMethod:           tx.Method,
+		ContractMethodExecResult: execResult,
+
+		Divvy:     vmstatehelperimplemtations.DefaultDivvy,
+		Status:    status,
+		HertzCost: hertzCost,
 	}, nil
 }
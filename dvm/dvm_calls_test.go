@@ -0,0 +1,98 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+// The contracts below are hand assembled like the ones in dvm_test.go
+const (
+	// Stores its uint256 argument in slot 0: PUSH1 4 CALLDATALOAD PUSH1 0 SSTORE STOP
+	setterCode = "6007600c60003960076000f3" + "60043560005500"
+
+	// Calls its address argument with its uint256 argument: PUSH1 36 CALLDATALOAD PUSH1 4 MSTORE PUSH1 0 PUSH1 0 PUSH1 36
+	// PUSH1 0 PUSH1 0 PUSH1 4 CALLDATALOAD GAS CALL POP STOP
+	callerCode = "6017600c60003960176000f3" + "602435600452" + "60006000602460006000600435" + "5af15000"
+
+	// The same with DELEGATECALL, which takes no value: PUSH1 36 CALLDATALOAD PUSH1 4 MSTORE PUSH1 0 PUSH1 0 PUSH1 36
+	// PUSH1 0 PUSH1 4 CALLDATALOAD GAS DELEGATECALL POP STOP
+	delegateCallerCode = "6015600c60003960156000f3" + "602435600452" + "6000600060246000600435" + "5af45000"
+
+	// Creates a setter from the init code after it and stores the setter's address in slot 0: PUSH1 19 PUSH1 18 PUSH1 0
+	// CODECOPY PUSH1 19 PUSH1 0 PUSH1 0 CREATE PUSH1 0 SSTORE STOP
+	factoryCode = "6025600c60003960256000f3" + "60136012600039601360006000f060005500" + setterCode
+)
+
+var (
+	setterAbi  = hex.EncodeToString([]byte(`[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"set","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`))
+	forwardAbi = hex.EncodeToString([]byte(`[{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"n","type":"uint256"}],"name":"forward","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`))
+)
+
+// TestContractCalls - A contract calling another writes the callee's storage while delegating to it writes its own
+func TestContractCalls(t *testing.T) {
+	setter := deploy(t, 60, setterCode, setterAbi)
+	caller := deploy(t, 61, callerCode, forwardAbi)
+	delegateCaller := deploy(t, 62, delegateCallerCode, forwardAbi)
+
+	_, _, err := execute(63, caller, forwardAbi, "forward", crypto.GetAddressBytes(setter), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if callee, own := slotValue(t, setter, "0x0"), slotValue(t, caller, "0x0"); callee != 7 || own != 0 {
+		t.Errorf("CALL stored %d in the callee and %d in the caller, expected 7 and 0", callee, own)
+	}
+
+	_, _, err = execute(64, delegateCaller, forwardAbi, "forward", crypto.GetAddressBytes(setter), big.NewInt(9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if callee, own := slotValue(t, setter, "0x0"), slotValue(t, delegateCaller, "0x0"); callee != 7 || own != 9 {
+		t.Errorf("DELEGATECALL left %d in the callee and stored %d in the caller, expected 7 and 9", callee, own)
+	}
+}
+
+// TestContractCreate - A contract created by another is reported with its creator and executes like a deployed one
+func TestContractCreate(t *testing.T) {
+	factory := deploy(t, 70, factoryCode, testAbi("create"))
+
+	_, result, err := execute(71, factory, testAbi("create"), "create")
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractStorage, err := GetDVMService().GetContractStorageSlot(factory, "0x0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := contractStorage.Slots[0].Value[24:]
+	created := result.StorageState.CreatedContracts
+	if len(created) != 1 || created[crypto.GetAddressBytes(child)] != crypto.GetAddressBytes(factory) {
+		t.Fatalf("created contracts %v, expected %s created by %s", created, child, factory)
+	}
+
+	_, _, err = execute(72, child, setterAbi, "set", big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := slotValue(t, child, "0x0"); value != 5 {
+		t.Errorf("created contract stored %d, expected 5", value)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"sort"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// dispatchReader - What the Dispatch precompiled contracts read of the platform state while a transaction executes
+type dispatchReader struct {
+	tx *commonTypes.Transaction
+}
+
+// GetBalance - Balance of the account, zero if there is none
+func (this *dispatchReader) GetBalance(address crypto.AddressBytes) *big.Int {
+	account := this.getAccount(address)
+	if account == nil || account.Balance == nil {
+		return big.NewInt(0)
+	}
+	return account.Balance
+}
+
+// GetName - Name of the account, empty if there is none
+func (this *dispatchReader) GetName(address crypto.AddressBytes) string {
+	account := this.getAccount(address)
+	if account == nil {
+		return ""
+	}
+	return account.Name
+}
+
+// GetDelegates - Addresses of the delegates at the time of the transaction, sorted so every delegate returns them in the same order.
+// They come from the configured delegate sets rather than the nodes this delegate discovered, which differ between delegates
+func (this *dispatchReader) GetDelegates() []crypto.AddressBytes {
+	addresses := commonTypes.GetConfig().GetDelegateAddresses(this.tx.Time)
+	delegates := make([]crypto.AddressBytes, 0, len(addresses))
+	for _, address := range addresses {
+		delegates = append(delegates, crypto.GetAddressBytes(address))
+	}
+	sort.Slice(delegates, func(i, j int) bool {
+		return bytes.Compare(delegates[i][:], delegates[j][:]) < 0
+	})
+	return delegates
+}
+
+// GetTime - Time of the transaction in milliseconds
+func (this *dispatchReader) GetTime() int64 {
+	return this.tx.Time
+}
+
+func (this *dispatchReader) getAccount(address crypto.AddressBytes) *commonTypes.Account {
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	account, err := commonTypes.ToAccountByAddress(txn, hex.EncodeToString(address[:]))
+	if err != nil {
+		return nil
+	}
+	return account
+}
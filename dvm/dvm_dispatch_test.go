@@ -0,0 +1,54 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// TestGetDelegates - The delegates come from the delegate set configured for the time of the transaction, sorted
+func TestGetDelegates(t *testing.T) {
+	config := commonTypes.GetConfig()
+	delegateAddresses, delegateSets := config.DelegateAddresses, config.DelegateSets
+	defer func() { config.DelegateAddresses, config.DelegateSets = delegateAddresses, delegateSets }()
+	page := int64(5)
+	config.DelegateAddresses = []string{"d70613f93152c84050e7826c4e2b0cc02c1c3b99", "c296220327589dc04e6ee01bf16563f0f53895bb"}
+	config.DelegateSets = []*commonTypes.DelegateSet{{Addresses: []string{"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"}, Page: &page}}
+
+	tests := []struct {
+		time int64
+		want []string
+	}{
+		{0, []string{"c296220327589dc04e6ee01bf16563f0f53895bb", "d70613f93152c84050e7826c4e2b0cc02c1c3b99"}},
+		{commonTypes.PageDuration * page, []string{"3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"}},
+	}
+	for i, test := range tests {
+		reader := &dispatchReader{tx: &commonTypes.Transaction{Time: test.time}}
+		delegates := reader.GetDelegates()
+		if len(delegates) != len(test.want) {
+			t.Fatalf("test %d: %d delegates, want %d", i, len(delegates), len(test.want))
+		}
+		for j, delegate := range delegates {
+			if crypto.EncodeNo0x(delegate[:]) != test.want[j] {
+				t.Errorf("test %d: delegate %d is %s, want %s", i, j, crypto.EncodeNo0x(delegate[:]), test.want[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm/ethereum"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/vm"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+// EstimateResult - The least hertz a contract transaction succeeds with
+type EstimateResult struct {
+	Hertz           uint64              // Hertz limit the transaction succeeds with
+	HertzUsed       uint64              // Hertz the transaction used
+	ReturnValue     []byte              // What the method returned or the contract reverted with
+	ContractAddress crypto.AddressBytes // Address a deploy would create
+	Err             error               // Why the transaction fails even with the most hertz, a *RevertError when it reverted
+}
+
+// EstimateHertz - Binary searches the least hertz a deploy or execute transaction succeeds with, every run is against a
+// read-only copy of the world state so nothing is committed. The coinbase is the delegate serving the estimate
+func (dvm *DVMService) EstimateHertz(tx *commonTypes.Transaction, coinbase string) (*EstimateResult, error) {
+	utils.Debug(fmt.Sprintf("DVMServices-EstimateHertz: %s", tx))
+
+	// Does it succeed with the most hertz it may have?
+	hi := uint64(commonTypes.PageHertzLimit)
+	if tx.Hertz > 0 {
+		hi = uint64(tx.Hertz)
+	}
+	result, err := dvm.dryRun(tx, coinbase, hi)
+	if err != nil {
+		return nil, err
+	}
+	if result.Err != nil {
+		return result, nil
+	}
+
+	// It needs at least what it used, refunds are only given back at the end
+	lo := uint64(0)
+	if result.HertzUsed > 0 {
+		lo = result.HertzUsed - 1
+	}
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		midResult, err := dvm.dryRun(tx, coinbase, mid)
+		if err != nil {
+			return nil, err
+		}
+		if midResult.Err != nil {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	result.Hertz = hi
+	return result, nil
+}
+
+// dryRun - Applies the transaction limited to hertz against a read-only copy of the world state
+func (dvm *DVMService) dryRun(tx *commonTypes.Transaction, coinbase string, hertz uint64) (*EstimateResult, error) {
+	stateHelper, err := vmstatehelperimplemtations.NewReadOnlyVMStateHelper(crypto.GetAddressBytes(tx.To))
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type == commonTypes.TypeDeploySmartContract {
+		stateHelper.EthStateDB.SetNonce(crypto.GetAddressBytes(tx.From), uint64(tx.Time))
+	}
+	msg, err := newMessage(tx, hertz)
+	if err != nil {
+		return nil, err
+	}
+
+	stateHelper.EthStateDB.Prepare(crypto.GetHashBytes(tx.Hash), crypto.GetHashBytes(tx.Hash), 0)
+	vmenv := newEVM(tx, newContext(tx, coinbase, msg.From(), msg.GasPrice()), stateHelper, vm.Config{})
+	ret, contractAddress, gas, _, err := ethereum.ApplyMessage(vmenv, msg, stateHelper.GP)
+
+	result := &EstimateResult{
+		Hertz:           hertz,
+		HertzUsed:       gas,
+		ReturnValue:     ret,
+		ContractAddress: contractAddress,
+	}
+	if err != nil {
+		result.Err = toExecutionError(err, ret, gas)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"math/big"
+	"testing"
+
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// loopTransaction - Calls loop(n) on a contract
+func loopTransaction(contractAddress string, n int64, hertz int64) *commonTypes.Transaction {
+	return &commonTypes.Transaction{
+		Hash:   testHash(1000 + int(n)),
+		Type:   commonTypes.TypeExecuteSmartContract,
+		From:   testFrom,
+		To:     contractAddress,
+		Abi:    loopAbi,
+		Method: "loop",
+		Params: []interface{}{big.NewInt(n)},
+		Time:   1000,
+		Hertz:  hertz,
+	}
+}
+
+// TestEstimateHertzDependsOnInput - The estimate grows with the work the input asks for and is the least hertz that succeeds
+func TestEstimateHertzDependsOnInput(t *testing.T) {
+	contractAddress := deploy(t, 10, loopCode, loopAbi)
+
+	previous := uint64(0)
+	for _, n := range []int64{0, 10, 100} {
+		tx := loopTransaction(contractAddress, n, 0)
+		result, err := GetDVMService().EstimateHertz(tx, testFrom)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Err != nil {
+			t.Fatalf("estimate of loop(%d) failed: %v", n, result.Err)
+		}
+		if result.Hertz <= previous {
+			t.Errorf("estimate of loop(%d) is %d, expected more than %d", n, result.Hertz, previous)
+		}
+		previous = result.Hertz
+
+		enough, err := GetDVMService().dryRun(tx, testFrom, result.Hertz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enough.Err != nil {
+			t.Errorf("loop(%d) failed with the estimated %d hertz: %v", n, result.Hertz, enough.Err)
+		}
+		short, err := GetDVMService().dryRun(tx, testFrom, result.Hertz-1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if short.Err != commonTypes.ErrOutOfHertz {
+			t.Errorf("loop(%d) with one hertz less than the estimate returned %v, expected out of hertz", n, short.Err)
+		}
+	}
+}
+
+// TestEstimateHertzOutOfHertz - The estimate never searches above the hertz limit of the transaction
+func TestEstimateHertzOutOfHertz(t *testing.T) {
+	contractAddress := deploy(t, 11, loopCode, loopAbi)
+	bound, err := GetDVMService().EstimateHertz(loopTransaction(contractAddress, 10, 0), testFrom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := loopTransaction(contractAddress, 1000, int64(bound.Hertz))
+	result, err := GetDVMService().EstimateHertz(tx, testFrom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Err != commonTypes.ErrOutOfHertz {
+		t.Errorf("estimate above the hertz limit returned %v, expected out of hertz", result.Err)
+	}
+	if result.Hertz != bound.Hertz {
+		t.Errorf("estimate above the hertz limit returned %d hertz, expected the limit of %d", result.Hertz, bound.Hertz)
+	}
+}
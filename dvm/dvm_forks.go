@@ -0,0 +1,91 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"math/big"
+
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/vm"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+// forkOrder - The forks a network can activate, each one implies the ones before it
+var forkOrder = []string{
+	commonTypes.ForkPetersburg,
+	commonTypes.ForkIstanbul,
+	commonTypes.ForkBerlin,
+	commonTypes.ForkLondon,
+	commonTypes.ForkShanghai,
+}
+
+// newChainConfig - The EVM chain rules of a transaction, from the forks the network configured as active at its time
+func newChainConfig(tx *commonTypes.Transaction) *params.ChainConfig {
+	config := commonTypes.GetConfig()
+
+	// The last configured fork that is active also activates the ones before it
+	active := -1
+	for i, name := range forkOrder {
+		if config.IsForkActive(name, tx.Time) {
+			active = i
+		}
+	}
+
+	// The block number is the page number, so the forks the DVM always ran are active from page 0 on
+	chainConfig := *params.MainnetChainConfig
+	chainConfig.HomesteadBlock = big.NewInt(0)
+	chainConfig.EIP150Block = big.NewInt(0)
+	chainConfig.EIP155Block = big.NewInt(0)
+	chainConfig.EIP158Block = big.NewInt(0)
+	chainConfig.ByzantiumBlock = big.NewInt(0)
+	if config.ChainId != 0 {
+		chainConfig.ChainID = big.NewInt(config.ChainId)
+	}
+	forkBlocks := []**big.Int{
+		&chainConfig.PetersburgBlock,
+		&chainConfig.IstanbulBlock,
+		&chainConfig.BerlinBlock,
+		&chainConfig.LondonBlock,
+		&chainConfig.ShanghaiBlock,
+	}
+	for i := 0; i <= active; i++ {
+		*forkBlocks[i] = big.NewInt(0)
+	}
+	if active >= 0 {
+		chainConfig.ConstantinopleBlock = big.NewInt(0)
+	}
+	return &chainConfig
+}
+
+// newEVM - The EVM a transaction runs in under the chain rules of its time
+func newEVM(tx *commonTypes.Transaction, context vm.Context, stateHelper *vmstatehelperimplemtations.VMStateHelper, vmConfig vm.Config) *vm.EVM {
+	chainConfig := newChainConfig(tx)
+	if commonTypes.GetConfig().IsForkActive(commonTypes.ForkDispatch, tx.Time) {
+		context.Dispatch = &dispatchReader{tx: tx}
+	}
+	return vm.NewEVM(context, stateHelper.EthStateDB, chainConfig, newVMConfig(chainConfig, context.BlockNumber, vmConfig), stateHelper)
+}
+
+// newVMConfig - vmConfig with the instructions the chain rules at a page run
+func newVMConfig(chainConfig *params.ChainConfig, pageNumber *big.Int, vmConfig vm.Config) vm.Config {
+	if !chainConfig.IsPetersburg(pageNumber) {
+		// Before any fork the DVM runs the constantinople instructions on byzantium gas prices
+		vmConfig.JumpTable = vm.ConstantinopleInstructionSet
+	}
+	return vmConfig
+}
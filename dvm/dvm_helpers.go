@@ -17,41 +17,119 @@
 package dvm
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
 	commonTypes "github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
-	"github.com/dispatchlabs/disgo/dvm/badgerwrapper"
 	"github.com/dispatchlabs/disgo/dvm/ethereum"
-	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
-	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
 	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/vm"
 	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
 )
 
-func (self *DVMService) applyTransaction(tx *commonTypes.Transaction, stateHelper *vmstatehelperimplemtations.VMStateHelper) error {
-	price := big.NewInt(int64(0))
+// toPageHash - The GetHash of the EVM running a transaction of page current, BLOCKHASH returns the hash persisted with one of
+// the 256 pages before it. Other pages, and pages never persisted, have a zero hash like blocks out of reach in Ethereum.
+func toPageHash(current int64) vm.GetHashFunc {
+	return func(number uint64) crypto.HashBytes {
+		if current <= 0 || number >= uint64(current) || uint64(current)-number > 256 {
+			return crypto.HashBytes{}
+		}
+		txn := services.NewTxn(false)
+		defer txn.Discard()
+		page, err := commonTypes.ToPageByNumber(txn, int64(number))
+		if err != nil {
+			utils.Error(fmt.Sprintf("unable to read page %d", number), err)
+			return crypto.HashBytes{}
+		}
+		return crypto.GetHashBytes(page.Hash)
+	}
+}
 
-	context := vm.Context{
+// newContext - The EVM context a transaction runs in, a page stands for a block.
+// The block information only comes from the transaction, the delegate that first received it and the persisted pages
+// before it, so it is identical on every delegate.
+func newContext(tx *commonTypes.Transaction, coinbase string, origin crypto.AddressBytes, gasPrice *big.Int) vm.Context {
+	return vm.Context{
 		CanTransfer: ethereum.CanTransfer,
 		Transfer:    ethereum.Transfer,
-		GetHash:     func(uint64) crypto.HashBytes { return crypto.GetHashBytes(tx.Hash) },
+		GetHash:     toPageHash(commonTypes.ToPageNumber(tx.Time)),
 
 		// Message information
-		Origin:   crypto.GetAddressBytes(tx.From),
-		GasPrice: price,
+		Origin:   origin,
+		GasPrice: gasPrice,
 
 		// Block information
-		Coinbase:    crypto.GetAddressBytes(tx.From),
+		Coinbase:    crypto.GetAddressBytes(coinbase),
 		GasLimit:    vmstatehelperimplemtations.GasLimit.Uint64(),
-		BlockNumber: params.MainnetChainConfig.ByzantiumBlock, // the vm has a dependency on this
-		// Time: *big.Int                  // Provides information for TIME
-		// Difficulty  *big.Int            // Provides information for DIFFICULTY
+		BlockNumber: big.NewInt(commonTypes.ToPageNumber(tx.Time)),
+		Time:        big.NewInt(tx.Time / 1000), // Seconds, like block.timestamp
+		Difficulty:  new(big.Int),
+	}
+}
+
+// newMessage - The message a deploy or execute transaction is applied as, limited to hertz
+func newMessage(tx *commonTypes.Transaction, hertz uint64) (ethTypes.Message, error) {
+	switch tx.Type {
+	case commonTypes.TypeDeploySmartContract:
+		msg := ethTypes.AsMessage(tx, hertz)
+		if len(tx.Params) == 0 {
+			return msg, nil
+		}
+
+		// The constructor arguments are ABI encoded after the code
+		fromHexAsByteArray, _ := hex.DecodeString(tx.Abi)
+		jsonABI, err := abi.JSON(strings.NewReader(string(fromHexAsByteArray)))
+		if err != nil {
+			return ethTypes.Message{}, err
+		}
+		arguments, err := jsonABI.Pack("", tx.Params...)
+		if err != nil {
+			return ethTypes.Message{}, err
+		}
+		return ethTypes.NewMessage(
+			msg.From(),
+			nil,
+			msg.Nonce(),
+			msg.Value(),
+			hertz,
+			msg.GasPrice(),
+			append(msg.Data(), arguments...),
+			msg.CheckNonce(),
+		), nil
+	case commonTypes.TypeExecuteSmartContract:
+		fromHexAsByteArray, _ := hex.DecodeString(tx.Abi)
+		jsonABI, err := abi.JSON(strings.NewReader(string(fromHexAsByteArray)))
+		if err != nil {
+			return ethTypes.Message{}, err
+		}
+		callData, err := jsonABI.Pack(tx.Method, tx.Params...)
+		if err != nil {
+			return ethTypes.Message{}, err
+		}
+		toAsBytes := crypto.GetAddressBytes(tx.To)
+		return ethTypes.NewMessage(
+			crypto.GetAddressBytes(tx.From),
+			&toAsBytes,
+			0, // nonce
+			big.NewInt(tx.Value),
+			hertz,
+			vmstatehelperimplemtations.DefaultGasPrice,
+			callData,
+			false,
+		), nil
 	}
+	return ethTypes.Message{}, fmt.Errorf("transaction %s is not a contract transaction", tx.Hash)
+}
+
+func (self *DVMService) applyTransaction(tx *commonTypes.Transaction, coinbase string, stateHelper *vmstatehelperimplemtations.VMStateHelper) error {
+	context := newContext(tx, coinbase, crypto.GetAddressBytes(tx.From), big.NewInt(int64(0)))
 
 	// Prepare the ethState with transaction Hash so that it can be used in emitted logs
 	var txIndex = 0
@@ -66,26 +144,28 @@ func (self *DVMService) applyTransaction(tx *commonTypes.Transaction, stateHelpe
 		Limit:          0,
 	})
 
-	vmenv := vm.NewEVM(
+	vmenv := newEVM(
+		tx,
 		context,
-		stateHelper.EthStateDB,
-		params.MainnetChainConfig,
+		stateHelper,
 		vm.Config{
 			Debug:       vmstatehelperimplemtations.IsDemo,
 			Tracer:      vmLogger,
 			NoRecursion: false,
 			// EnablePreimageRecording bool
-			JumpTable: vm.ConstantinopleInstructionSet,
 		},
-		stateHelper,
 	)
 
-	msg := ethTypes.AsMessage(tx, uint64(vmstatehelperimplemtations.DefaultGasLimit))
+	msg, err := newMessage(tx, uint64(tx.HertzLimit()))
+	if err != nil {
+		return err
+	}
 
 	// Apply the transaction to the current state (included in the env)
 	// GRAB-THIS: gas will be the GAS/Hertz used to execute the TX - for contract creation or execution
-	_, contractAddress, gas, failed, err := ethereum.ApplyMessage(vmenv, msg, stateHelper.GP)
+	ret, contractAddress, gas, failed, err := ethereum.ApplyMessage(vmenv, msg, stateHelper.GP)
 	if err != nil {
+		err = toExecutionError(err, ret, gas)
 		utils.Error(fmt.Sprintf("%s Applying transaction to WAS", err))
 		return err
 	}
@@ -113,6 +193,7 @@ func (self *DVMService) applyTransaction(tx *commonTypes.Transaction, stateHelpe
 	stateHelper.Transactions = append(stateHelper.Transactions, tx)
 	stateHelper.Receipts = append(stateHelper.Receipts, receipt)
 	stateHelper.AllLogs = append(stateHelper.AllLogs, receipt.Logs...)
+	recordCreatedContracts(vmenv, stateHelper)
 
 	utils.Debug(fmt.Sprintf("%s Applied tx to WAS", tx.Hash))
 
@@ -127,23 +208,8 @@ func (self *DVMService) applyTransaction(tx *commonTypes.Transaction, stateHelpe
 	return nil
 }
 
-func (self *DVMService) call(tx *commonTypes.Transaction, callMsg ethTypes.Message, stateHelper *vmstatehelperimplemtations.VMStateHelper) ([]byte, error) {
-	context := vm.Context{
-		CanTransfer: ethereum.CanTransfer,
-		Transfer:    ethereum.Transfer,
-		GetHash:     func(uint64) crypto.HashBytes { return crypto.GetHashBytes(tx.Hash) },
-
-		// Message information
-		Origin:   callMsg.From(),
-		GasPrice: callMsg.GasPrice(),
-
-		// Block information
-		Coinbase:    crypto.GetAddressBytes(tx.From),
-		GasLimit:    vmstatehelperimplemtations.GasLimit.Uint64(),
-		BlockNumber: params.MainnetChainConfig.ByzantiumBlock, // the vm has a dependency on this
-		// Time: *big.Int                  // Provides information for TIME
-		// Difficulty  *big.Int            // Provides information for DIFFICULTY
-	}
+func (self *DVMService) call(tx *commonTypes.Transaction, coinbase string, callMsg ethTypes.Message, stateHelper *vmstatehelperimplemtations.VMStateHelper) ([]byte, error) {
+	context := newContext(tx, coinbase, callMsg.From(), callMsg.GasPrice())
 
 	// The EVM should never be reused and is not thread safe.
 	// Call is done on a copy of the state...we dont want any changes to be persisted
@@ -156,23 +222,22 @@ func (self *DVMService) call(tx *commonTypes.Transaction, callMsg ethTypes.Messa
 		Limit:          0,
 	})
 
-	vmenv := vm.NewEVM(
+	vmenv := newEVM(
+		tx,
 		context,
-		stateHelper.EthStateDB,
-		params.MainnetChainConfig,
+		stateHelper,
 		vm.Config{
 			Debug:       vmstatehelperimplemtations.IsDemo,
 			Tracer:      vmLogger,
 			NoRecursion: false,
 			// EnablePreimageRecording bool
-			JumpTable: vm.ConstantinopleInstructionSet,
 		},
-		stateHelper,
 	)
 
 	// Apply the transaction to the current state (included in the env)
 	execResult, _ /*contractAddress*/, gas, failed, execError := ethereum.ApplyMessage(vmenv, callMsg, stateHelper.GP)
 	if execError != nil {
+		execError = toExecutionError(execError, execResult, gas)
 		utils.Error(fmt.Sprintf("%s Executing Call on WAS", execError))
 		return nil, execError
 	}
@@ -201,6 +266,7 @@ func (self *DVMService) call(tx *commonTypes.Transaction, callMsg ethTypes.Messa
 	stateHelper.Transactions = append(stateHelper.Transactions, tx)
 	stateHelper.Receipts = append(stateHelper.Receipts, receipt)
 	stateHelper.AllLogs = append(stateHelper.AllLogs, receipt.Logs...)
+	recordCreatedContracts(vmenv, stateHelper)
 	// __END__
 
 	// DEMO-Today
@@ -213,18 +279,43 @@ func (self *DVMService) call(tx *commonTypes.Transaction, callMsg ethTypes.Messa
 	return execResult, execError
 }
 
-func (self *DVMService) getReceipt(txHash []byte) (*ethTypes.Receipt, error) {
-	utils.Debug(fmt.Sprintf("receipts- [%v]", crypto.Encode(vmstatehelperimplemtations.ReceiptsPrefix)))
-	data, err := badgerwrapper.GetBadgerDatabase().Get(append(vmstatehelperimplemtations.ReceiptsPrefix, txHash[:]...))
-	if err != nil {
-		utils.Error(fmt.Sprintf("%s GetReceipt", err))
-		return nil, err
+// RevertError - The contract reverted, Data is what it reverted with
+type RevertError struct {
+	Data      []byte
+	HertzUsed uint64
+}
+
+// Error
+func (this *RevertError) Error() string {
+	return vm.ErrExecutionReverted.Error()
+}
+
+// recordCreatedContracts - Keeps the contracts a contract created that still have code, a caller may have reverted the
+// others
+func recordCreatedContracts(vmenv *vm.EVM, stateHelper *vmstatehelperimplemtations.VMStateHelper) {
+	for address, creator := range vmenv.Created {
+		if stateHelper.EthStateDB.GetCodeSize(address) == 0 {
+			continue
+		}
+		if stateHelper.CreatedContracts == nil {
+			stateHelper.CreatedContracts = make(map[crypto.AddressBytes]crypto.AddressBytes)
+		}
+		stateHelper.CreatedContracts[address] = creator
 	}
-	var receipt ethTypes.ReceiptForStorage
-	if err := rlp.DecodeBytes(data, &receipt); err != nil {
-		utils.Error(fmt.Sprintf("%s Decoding Receipt", err))
-		return nil, err
+}
+
+// toHertzError - Running out of gas is reported as running out of Hertz
+func toHertzError(err error) error {
+	if err == vm.ErrOutOfGas || err == vm.ErrCodeStoreOutOfGas {
+		return commonTypes.ErrOutOfHertz
 	}
+	return err
+}
 
-	return (*ethTypes.Receipt)(&receipt), nil
+// toExecutionError - A revert keeps what the contract reverted with
+func toExecutionError(err error, ret []byte, gas uint64) error {
+	if err == vm.ErrExecutionReverted {
+		return &RevertError{Data: ret, HertzUsed: gas}
+	}
+	return toHertzError(err)
 }
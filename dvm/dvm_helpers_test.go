@@ -0,0 +1,145 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// setPageHash - Persists a page with its hash
+func setPageHash(t *testing.T, number int64, hash string) {
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		page := &commonTypes.Page{Number: number, Hash: hash, Created: time.Now()}
+		return page.Persist(txn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNewContext - The block information comes from the page of the transaction and the delegate that first received it
+func TestNewContext(t *testing.T) {
+	tx := &commonTypes.Transaction{Hash: testHash(20), Time: 1539000000123}
+	coinbase := "c296220327589dc04e6ee01bf16563f0f53895bb"
+	origin := crypto.GetAddressBytes(testFrom)
+	context := newContext(tx, coinbase, origin, big.NewInt(0))
+
+	if expected := commonTypes.ToPageNumber(tx.Time); context.BlockNumber.Int64() != expected {
+		t.Errorf("block number is %d, expected page %d", context.BlockNumber.Int64(), expected)
+	}
+	if context.Time.Int64() != 1539000000 {
+		t.Errorf("block time is %d, expected the transaction time in seconds 1539000000", context.Time.Int64())
+	}
+	if context.Coinbase != crypto.GetAddressBytes(coinbase) {
+		t.Errorf("coinbase is %s, expected %s", crypto.EncodeNo0x(context.Coinbase[:]), coinbase)
+	}
+	if context.Origin != origin {
+		t.Errorf("origin is %s, expected %s", crypto.EncodeNo0x(context.Origin[:]), testFrom)
+	}
+	// BLOCKHASH reads the hash stored with the 256 pages before the page of the transaction
+	page := commonTypes.ToPageNumber(tx.Time)
+	for _, number := range []int64{page - 1, page - 256, page - 257, page} {
+		setPageHash(t, number, testHash(int(number)))
+	}
+	tests := []struct {
+		number   int64
+		expected crypto.HashBytes
+	}{
+		{page - 1, crypto.GetHashBytes(testHash(int(page - 1)))},
+		{page - 256, crypto.GetHashBytes(testHash(int(page - 256)))},
+		{page - 2, crypto.HashBytes{}}, // never persisted
+		{page - 257, crypto.HashBytes{}},
+		{page, crypto.HashBytes{}},
+		{page + 1, crypto.HashBytes{}},
+	}
+	for _, test := range tests {
+		if hash := context.GetHash(uint64(test.number)); hash != test.expected {
+			t.Errorf("hash of page %d is %x, expected %x", test.number, hash, test.expected)
+		}
+	}
+
+	// Nothing in the context depends on when or where it is created
+	again := newContext(tx, coinbase, origin, big.NewInt(0))
+	if again.BlockNumber.Cmp(context.BlockNumber) != 0 || again.Time.Cmp(context.Time) != 0 || again.Coinbase != context.Coinbase ||
+		again.GasLimit != context.GasLimit || again.Difficulty.Cmp(context.Difficulty) != 0 || again.GetHash(uint64(page-1)) != context.GetHash(uint64(page-1)) {
+		t.Error("two contexts of the same transaction differ")
+	}
+}
+
+// TestContextIsDeterministic - A contract reading the block information gets the same values every time the transaction runs
+func TestContextIsDeterministic(t *testing.T) {
+	abi := testAbi("context")
+	contractAddress := deploy(t, 21, contextCode, abi)
+	coinbase := "c296220327589dc04e6ee01bf16563f0f53895bb"
+	tx := &commonTypes.Transaction{
+		Hash:   testHash(22),
+		Type:   commonTypes.TypeExecuteSmartContract,
+		From:   testFrom,
+		To:     contractAddress,
+		Abi:    abi,
+		Method: "context",
+		Time:   1539000000123,
+	}
+
+	page := commonTypes.ToPageNumber(tx.Time)
+	setPageHash(t, page-1, testHash(23))
+
+	first, err := GetDVMService().dryRun(tx, coinbase, uint64(tx.HertzLimit()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Err != nil {
+		t.Fatal(first.Err)
+	}
+	second, err := GetDVMService().dryRun(tx, coinbase, uint64(tx.HertzLimit()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.ReturnValue, second.ReturnValue) {
+		t.Errorf("two executions of the same transaction saw %x and %x", first.ReturnValue, second.ReturnValue)
+	}
+	if len(first.ReturnValue) != 128 {
+		t.Fatalf("contract returned %d bytes, expected 128", len(first.ReturnValue))
+	}
+
+	if number := new(big.Int).SetBytes(first.ReturnValue[:32]).Int64(); number != page {
+		t.Errorf("contract saw block number %d, expected page %d", number, page)
+	}
+	if time := new(big.Int).SetBytes(first.ReturnValue[32:64]).Int64(); time != tx.Time/1000 {
+		t.Errorf("contract saw block time %d, expected %d", time, tx.Time/1000)
+	}
+	if expected := crypto.GetAddressBytes(coinbase); !bytes.Equal(first.ReturnValue[76:96], expected[:]) {
+		t.Errorf("contract saw coinbase %x, expected %s", first.ReturnValue[76:96], coinbase)
+	}
+	txn := services.NewTxn(false)
+	defer txn.Discard()
+	previous, err := commonTypes.ToPageByNumber(txn, page-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := crypto.GetHashBytes(previous.Hash); !bytes.Equal(first.ReturnValue[96:], expected[:]) {
+		t.Errorf("contract saw hash %x of the previous page, expected the stored %s", first.ReturnValue[96:], previous.Hash)
+	}
+}
@@ -3,6 +3,7 @@ package dvm
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"strings"
 
 	"github.com/dispatchlabs/disgo/commons/crypto"
@@ -29,6 +30,7 @@ type DVMResult struct {
 	CumulativeHertzUsed uint64
 	Bloom               types.Bloom
 	Logs                []*types.Log
+	Balances            map[string]*big.Int // Balances, by address, of the accounts whose tokens moved
 }
 
 // String -
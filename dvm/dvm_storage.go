@@ -0,0 +1,221 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/ethdb"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/trie"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+var (
+	ErrContractNotFound = errors.New("contract not found")
+	ErrInvalidSlot      = errors.New("slot must be at most 32 hex encoded bytes")
+)
+
+// StorageSlot - A slot of a contract's storage, the proof nodes are RLP encoded and ordered from the storage root down
+type StorageSlot struct {
+	Key   string   `json:"key"`
+	Value string   `json:"value"`
+	Proof []string `json:"proof,omitempty"`
+}
+
+// ContractStorage - The code and storage of a contract as stored in the world state
+type ContractStorage struct {
+	Address     string         `json:"address"`
+	CodeHash    string         `json:"codeHash"`
+	Code        string         `json:"code"`
+	StorageRoot string         `json:"storageRoot"`
+	Slots       []*StorageSlot `json:"slots"`
+	NextSlot    string         `json:"nextSlot,omitempty"` // Where the next page of slots starts, empty after the last one
+}
+
+// GetContractStorage - Returns a page of a contract's storage slots in the order of the storage trie, which is the order of
+// the hashes of their keys. Paging starts at the slot "start" (the first slot when empty) and the storage trie is only
+// iterated from there until the page is full, so the count is the number of slots in the page and the next page starts
+// at NextSlot
+func (dvm *DVMService) GetContractStorage(address string, start string, page, pageSize int) (*ContractStorage, *commonTypes.PagingResult, error) {
+	utils.Debug(fmt.Sprintf("DVMServices-GetContractStorage: %s", address))
+
+	if pageSize <= 0 || pageSize > 100 {
+		return nil, nil, commonTypes.ErrInvalidRequestPageSize
+	}
+	if page <= 0 {
+		return nil, nil, commonTypes.ErrInvalidRequestPage
+	}
+
+	stateHelper, contractStorage, err := loadContractStorage(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	storageTrie := stateHelper.EthStateDB.StorageTrie(crypto.GetAddressBytes(address))
+	if err := stateHelper.EthStateDB.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	// Seek the hash of the starting slot
+	var seek []byte
+	if start != "" {
+		key, err := toSlot(start)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = hex.EncodeToString(key[:])
+		hash := crypto.NewHash(key[:])
+		seek = hash[:]
+	}
+
+	paging := &commonTypes.PagingResult{}
+	skip := (page - 1) * pageSize
+	it := trie.NewIterator(storageTrie.NodeIterator(seek))
+	for it.Next() {
+		key := storageTrie.GetKey(it.Key)
+		if key == nil {
+			return nil, nil, fmt.Errorf("no preimage of storage key %x", it.Key)
+		}
+		slot := hex.EncodeToString(key)
+		if paging.PageStart == "" {
+			if start != "" && slot != start {
+				return nil, nil, commonTypes.ErrInvalidRequestStartingHash
+			}
+			paging.PageStart = slot
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if len(contractStorage.Slots) == pageSize {
+			contractStorage.NextSlot = slot
+			break
+		}
+
+		// The trie holds the RLP encoding of the value
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := crypto.BytesToHash(content)
+		contractStorage.Slots = append(contractStorage.Slots, &StorageSlot{Key: slot, Value: hex.EncodeToString(value[:])})
+	}
+	if it.Err != nil {
+		return nil, nil, it.Err
+	}
+	if start != "" && paging.PageStart == "" {
+		return nil, nil, commonTypes.ErrInvalidRequestStartingHash
+	}
+	paging.Count = len(contractStorage.Slots)
+	return contractStorage, paging, nil
+}
+
+// GetContractStorageSlot - Returns a slot of a contract's storage, with the proof the slot holds the value (or is empty)
+// in the trie whose root is the contract's storage root when asked for
+func (dvm *DVMService) GetContractStorageSlot(address string, slot string, withProof bool) (*ContractStorage, error) {
+	utils.Debug(fmt.Sprintf("DVMServices-GetContractStorageSlot: %s[%s]", address, slot))
+
+	key, err := toSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	stateHelper, contractStorage, err := loadContractStorage(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBytes := crypto.GetAddressBytes(address)
+	value := stateHelper.EthStateDB.GetState(addressBytes, key)
+	storageSlot := &StorageSlot{Key: hex.EncodeToString(key[:]), Value: hex.EncodeToString(value[:])}
+	if withProof {
+		proof, err := stateHelper.EthStateDB.GetStorageProof(addressBytes, key)
+		if err != nil {
+			return nil, err
+		}
+		storageSlot.Proof = make([]string, len(proof))
+		for i, node := range proof {
+			storageSlot.Proof[i] = hex.EncodeToString(node)
+		}
+	}
+	if err := stateHelper.EthStateDB.Error(); err != nil {
+		return nil, err
+	}
+	contractStorage.Slots = append(contractStorage.Slots, storageSlot)
+
+	return contractStorage, nil
+}
+
+// VerifyStorageProof - Checks the proof of a slot against a storage root and returns the value the slot holds,
+// the value is empty when the proof shows the slot is not in the trie
+func VerifyStorageProof(storageRoot crypto.HashBytes, slot crypto.HashBytes, proof [][]byte) (crypto.HashBytes, error) {
+	proofDb := ethdb.NewMemDatabase()
+	for _, node := range proof {
+		proofDb.Put(crypto.NewHash(node).Bytes(), node)
+	}
+	key := crypto.NewHash(slot[:])
+	enc, _, err := trie.VerifyProof(storageRoot, key[:], proofDb)
+	if err != nil || len(enc) == 0 {
+		return crypto.HashBytes{}, err
+	}
+	_, content, _, err := rlp.Split(enc)
+	if err != nil {
+		return crypto.HashBytes{}, err
+	}
+	return crypto.BytesToHash(content), nil
+}
+
+// loadContractStorage - Opens a read-only copy of the world state and fills in what is stored for the contract itself
+func loadContractStorage(address string) (*vmstatehelperimplemtations.VMStateHelper, *ContractStorage, error) {
+	addressBytes := crypto.GetAddressBytes(address)
+	stateHelper, err := vmstatehelperimplemtations.NewReadOnlyVMStateHelper(addressBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateDB := stateHelper.EthStateDB
+	if !stateDB.Exist(addressBytes) || stateDB.GetCodeSize(addressBytes) == 0 {
+		return nil, nil, ErrContractNotFound
+	}
+
+	codeHash := stateDB.GetCodeHash(addressBytes)
+	storageRoot := stateDB.GetStorageRoot(addressBytes)
+	return stateHelper, &ContractStorage{
+		Address:     hex.EncodeToString(addressBytes[:]),
+		CodeHash:    hex.EncodeToString(codeHash[:]),
+		Code:        hex.EncodeToString(stateDB.GetCode(addressBytes)),
+		StorageRoot: hex.EncodeToString(storageRoot[:]),
+		Slots:       make([]*StorageSlot, 0),
+	}, nil
+}
+
+// toSlot - Parses a hex slot, short slots such as "0" or "0x1" are left padded like Solidity's slot numbers
+func toSlot(slot string) (crypto.HashBytes, error) {
+	slot = strings.TrimPrefix(slot, "0x")
+	if len(slot)%2 == 1 {
+		slot = "0" + slot
+	}
+	bytes, err := hex.DecodeString(slot)
+	if err != nil || len(bytes) == 0 || len(bytes) > crypto.HashLength {
+		return crypto.HashBytes{}, ErrInvalidSlot
+	}
+	return crypto.BytesToHash(bytes), nil
+}
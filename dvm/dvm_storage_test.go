@@ -0,0 +1,228 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+// deployStorage - Deploys a contract with slots 0 to n - 1 holding 1 to n
+func deployStorage(t *testing.T, hash int, n int64) string {
+	contractAddress := deploy(t, hash, storeCode, storeAbi)
+	_, _, err := execute(hash+1, contractAddress, storeAbi, "store", big.NewInt(n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contractAddress
+}
+
+// TestGetContractStoragePages - Pages follow the storage trie from their starting slot and link to the next page
+func TestGetContractStoragePages(t *testing.T) {
+	contractAddress := deployStorage(t, 30, 25)
+
+	var previous crypto.HashBytes
+	values := map[int64]int64{}
+	start := ""
+	for _, expected := range []int{10, 10, 5} {
+		contractStorage, paging, err := GetDVMService().GetContractStorage(contractAddress, start, 1, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if paging.Count != expected || len(contractStorage.Slots) != expected {
+			t.Fatalf("page starting at %q has %d slots and a count of %d, expected %d", start, len(contractStorage.Slots), paging.Count, expected)
+		}
+		if start != "" && paging.PageStart != start {
+			t.Errorf("page starting at %s returned page start %s", start, paging.PageStart)
+		}
+		for _, slot := range contractStorage.Slots {
+			key, _ := hex.DecodeString(slot.Key)
+			value, _ := hex.DecodeString(slot.Value)
+			hash := crypto.NewHash(key)
+			if bytes.Compare(hash[:], previous[:]) <= 0 {
+				t.Errorf("slot %s is not after the previous slot in the storage trie", slot.Key)
+			}
+			previous = hash
+			values[new(big.Int).SetBytes(key).Int64()] = new(big.Int).SetBytes(value).Int64()
+		}
+		start = contractStorage.NextSlot
+	}
+	if start != "" {
+		t.Errorf("last page links to a next page starting at %s", start)
+	}
+	if len(values) != 25 {
+		t.Fatalf("pages returned %d slots, expected 25", len(values))
+	}
+	for key, value := range values {
+		if value != key+1 {
+			t.Errorf("slot %d holds %d, expected %d", key, value, key+1)
+		}
+	}
+
+	// A later page of a start is the page the cursor leads to
+	first, paging, err := GetDVMService().GetContractStorage(contractAddress, "", 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _, err := GetDVMService().GetContractStorage(contractAddress, paging.PageStart, 2, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, _, err := GetDVMService().GetContractStorage(contractAddress, first.NextSlot, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Slots) != 10 || second.Slots[0].Key != next.Slots[0].Key || second.NextSlot != next.NextSlot {
+		t.Error("page 2 differs from the page the next slot of page 1 starts")
+	}
+
+	_, _, err = GetDVMService().GetContractStorage(contractAddress, "0x99", 1, 10)
+	if err != commonTypes.ErrInvalidRequestStartingHash {
+		t.Errorf("page starting at an empty slot returned %v", err)
+	}
+	_, _, err = GetDVMService().GetContractStorage(contractAddress, "", 1, 0)
+	if err != commonTypes.ErrInvalidRequestPageSize {
+		t.Errorf("page of size 0 returned %v", err)
+	}
+}
+
+// TestGetContractStorageSlotProof - The proof of a slot verifies against the storage root of the contract
+func TestGetContractStorageSlotProof(t *testing.T) {
+	contractAddress := deployStorage(t, 40, 5)
+
+	for slot, expected := range map[string]int64{"0x3": 4, "0x0": 1, "0x99": 0} {
+		contractStorage, err := GetDVMService().GetContractStorageSlot(contractAddress, slot, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		storageSlot := contractStorage.Slots[0]
+		if len(storageSlot.Proof) == 0 {
+			t.Fatalf("slot %s returned without a proof", slot)
+		}
+		proof := make([][]byte, len(storageSlot.Proof))
+		for i, node := range storageSlot.Proof {
+			proof[i], _ = hex.DecodeString(node)
+		}
+		root, _ := hex.DecodeString(contractStorage.StorageRoot)
+		key, _ := toSlot(slot)
+		value, err := VerifyStorageProof(crypto.BytesToHash(root), key, proof)
+		if err != nil {
+			t.Fatalf("proof of slot %s does not verify: %v", slot, err)
+		}
+		if value.Big().Int64() != expected || storageSlot.Value != hex.EncodeToString(value[:]) {
+			t.Errorf("proof of slot %s shows %x, expected %d", slot, value, expected)
+		}
+
+		// A proof only holds for its own root
+		if _, err := VerifyStorageProof(crypto.NewHash([]byte("root")), key, proof); err == nil {
+			t.Errorf("proof of slot %s verified against another root", slot)
+		}
+	}
+}
+
+// worldStateRoot - The root of the world state every contract reads
+func worldStateRoot(t *testing.T) []byte {
+	var root []byte
+	err := services.GetDb().View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte("WorldState"))
+		if err != nil {
+			return err
+		}
+		root, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// slotValue - The value a contract holds in a storage slot
+func slotValue(t *testing.T, contractAddress string, slot string) int64 {
+	contractStorage, err := GetDVMService().GetContractStorageSlot(contractAddress, slot, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := hex.DecodeString(contractStorage.Slots[0].Value)
+	return new(big.Int).SetBytes(value).Int64()
+}
+
+// TestFailedCommitKeepsWorldState - Nothing a contract wrote through a transaction that failed to commit is persisted, the
+// world state stays at its root and the contract executes again against it
+func TestFailedCommitKeepsWorldState(t *testing.T) {
+	contractAddress := deploy(t, 50, storeCode, storeAbi)
+	root := worldStateRoot(t)
+	tx := &commonTypes.Transaction{
+		Hash:   testHash(51),
+		Type:   commonTypes.TypeExecuteSmartContract,
+		From:   testFrom,
+		To:     contractAddress,
+		Abi:    storeAbi,
+		Method: "store",
+		Params: []interface{}{big.NewInt(3)},
+		Time:   51,
+	}
+
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	result, err := GetDVMService().ExecuteSmartContract(txn, tx, testFrom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another writer commits the world state the execution read
+	err = services.GetDb().Update(func(other *badger.Txn) error {
+		return other.Set([]byte("WorldState"), root)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Commit(nil); err != badger.ErrConflict {
+		t.Fatalf("commit returned %v, expected %v", err, badger.ErrConflict)
+	}
+	result.StorageState.Discard()
+
+	if !bytes.Equal(worldStateRoot(t), root) {
+		t.Errorf("world state moved to %x, expected %x", worldStateRoot(t), root)
+	}
+	if value := slotValue(t, contractAddress, "0x2"); value != 0 {
+		t.Errorf("slot 2 holds %d after the failed commit", value)
+	}
+	if _, err = vmstatehelperimplemtations.GetPreStateRoot(crypto.GetHashBytes(tx.Hash)); err != vmstatehelperimplemtations.ErrNoPreState {
+		t.Errorf("pre-state of the failed commit returned %v", err)
+	}
+
+	_, _, err = execute(51, contractAddress, storeAbi, "store", big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := slotValue(t, contractAddress, "0x2"); value != 3 {
+		t.Errorf("slot 2 holds %d after executing again, expected 3", value)
+	}
+	preStateRoot, err := vmstatehelperimplemtations.GetPreStateRoot(crypto.GetHashBytes(tx.Hash))
+	if err != nil || !bytes.Equal(preStateRoot.Bytes(), root) {
+		t.Errorf("executed again against %x (%v), expected %x", preStateRoot, err, root)
+	}
+}
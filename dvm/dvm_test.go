@@ -0,0 +1,137 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// The contracts below are hand assembled, their init code copies the runtime after it to memory and returns it
+const (
+	// Reverts with the balance of its caller: CALLER BALANCE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 REVERT
+	revertingCode = "600a600c600039600a6000f3" + "333160005260206000fd"
+
+	// Counts its uint256 argument down to zero so its cost depends on it: PUSH1 4 CALLDATALOAD JUMPDEST DUP1 ISZERO
+	// PUSH1 16 JUMPI PUSH1 1 SWAP1 SUB PUSH1 3 JUMP JUMPDEST STOP
+	loopCode = "6012600c60003960126000f3" + "6004355b8015601057600190036003565b00"
+
+	// Stores n in slot n - 1 and so on down to 1 in slot 0 for its uint256 argument n: PUSH1 4 CALLDATALOAD JUMPDEST DUP1
+	// ISZERO PUSH1 22 JUMPI PUSH1 1 SWAP1 SUB DUP1 PUSH1 1 ADD DUP2 SSTORE PUSH1 3 JUMP JUMPDEST STOP
+	storeCode = "6018600c60003960186000f3" + "6004355b801560165760019003806001018155600356" + "5b00"
+
+	// Returns the block information it ran with: NUMBER, TIMESTAMP, COINBASE and BLOCKHASH(NUMBER - 1)
+	contextCode = "6019600c60003960196000f3" + "43600052426020524160405260014303406060526080" + "6000f3"
+)
+
+var (
+	testFrom = "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	loopAbi  = hex.EncodeToString([]byte(`[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"loop","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`))
+	storeAbi = hex.EncodeToString([]byte(`[{"constant":false,"inputs":[{"name":"n","type":"uint256"}],"name":"store","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`))
+)
+
+// TestMain - Every test shares the Badger database opened in the working directory
+func TestMain(m *testing.M) {
+	code := m.Run()
+	os.RemoveAll("db")
+	os.RemoveAll("config")
+	os.Exit(code)
+}
+
+// testAbi - The hex encoded ABI of contract functions taking and returning nothing
+func testAbi(methods ...string) string {
+	functions := ""
+	for i, method := range methods {
+		if i > 0 {
+			functions += ","
+		}
+		functions += fmt.Sprintf(`{"constant":false,"inputs":[],"name":"%s","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}`, method)
+	}
+	return hex.EncodeToString([]byte("[" + functions + "]"))
+}
+
+// testHash - A transaction hash unique to the test
+func testHash(n int) string {
+	return fmt.Sprintf("%064x", n)
+}
+
+// setBalance - Sets the balance of a Dispatch account
+func setBalance(t *testing.T, address string, balance int64) {
+	err := services.GetDb().Update(func(txn *badger.Txn) error {
+		account := &commonTypes.Account{Address: address, Balance: big.NewInt(balance), Created: time.Now(), Updated: time.Now()}
+		return account.Persist(txn)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deploy - Deploys a contract and returns its address
+func deploy(t *testing.T, hash int, code string, abi string) string {
+	tx := &commonTypes.Transaction{
+		Hash: testHash(hash),
+		Type: commonTypes.TypeDeploySmartContract,
+		From: testFrom,
+		Code: code,
+		Abi:  abi,
+		Time: int64(hash),
+	}
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	result, err := GetDVMService().DeploySmartContract(txn, tx, testFrom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Commit(nil); err != nil {
+		t.Fatal(err)
+	}
+	result.StorageState.Persisted()
+	return crypto.EncodeNo0x(result.ContractAddress[:])
+}
+
+// execute - Executes a method of a contract and commits it, a failed execution still records its pre-state
+func execute(hash int, contractAddress string, abi string, method string, params ...interface{}) (*commonTypes.Transaction, *DVMResult, error) {
+	tx := &commonTypes.Transaction{
+		Hash:   testHash(hash),
+		Type:   commonTypes.TypeExecuteSmartContract,
+		From:   testFrom,
+		To:     contractAddress,
+		Abi:    abi,
+		Method: method,
+		Params: params,
+		Time:   int64(hash),
+	}
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	result, err := GetDVMService().ExecuteSmartContract(txn, tx, testFrom)
+	if commitErr := txn.Commit(nil); commitErr != nil {
+		return tx, result, commitErr
+	}
+	if result.StorageState != nil {
+		result.StorageState.Persisted()
+	}
+	return tx, result, err
+}
@@ -0,0 +1,135 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm/ethereum"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/vm"
+	"github.com/dispatchlabs/disgo/dvm/vmstatehelperimplemtations"
+)
+
+// Tracers
+const (
+	TracerStruct = "struct" // Opcode level struct log
+	TracerCall   = "call"   // Call tree with the hertz used by every frame
+)
+
+// TraceConfig - How a transaction is traced
+type TraceConfig struct {
+	Tracer        string
+	DisableMemory bool
+	DisableStack  bool
+}
+
+// TraceResult - The trace of a re-executed transaction
+type TraceResult struct {
+	TransactionHash string         `json:"transactionHash"`
+	Failed          bool           `json:"failed"`
+	HertzUsed       uint64         `json:"hertzUsed"`
+	ReturnValue     string         `json:"returnValue"`
+	Error           string         `json:"error,omitempty"`
+	StructLogs      []vm.StructLog `json:"structLogs,omitempty"`
+	Calls           *vm.CallFrame  `json:"calls,omitempty"`
+}
+
+// TraceTransaction - Re-executes a past contract transaction against the world state it ran against, nothing is committed.
+// The coinbase is the delegate that first received the transaction, as when it was executed
+func (dvm *DVMService) TraceTransaction(tx *commonTypes.Transaction, coinbase string, config *TraceConfig) (*TraceResult, error) {
+	utils.Debug(fmt.Sprintf("DVMServices-TraceTransaction: %s", tx))
+
+	root, err := vmstatehelperimplemtations.GetPreStateRoot(crypto.GetHashBytes(tx.Hash))
+	if err != nil {
+		return nil, err
+	}
+	stateHelper, err := vmstatehelperimplemtations.NewReadOnlyVMStateHelperAt(root, crypto.GetAddressBytes(tx.To))
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the balances the transaction ran against rather than the current ones
+	balances, err := vmstatehelperimplemtations.GetPreBalances(crypto.GetHashBytes(tx.Hash))
+	if err != nil && err != vmstatehelperimplemtations.ErrNoPreState {
+		return nil, err
+	}
+	stateHelper.EthStateDB.SetDispatchBalances(balances)
+
+	// Build the message the same way the transaction was executed
+	if tx.Type == commonTypes.TypeDeploySmartContract {
+		stateHelper.EthStateDB.SetNonce(crypto.GetAddressBytes(tx.From), uint64(tx.Time))
+	}
+	msg, err := newMessage(tx, uint64(tx.HertzLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick the tracer
+	var tracer vm.Tracer
+	var structLogger *vm.StructLogger
+	var callTracer *vm.CallTracer
+	switch config.Tracer {
+	case TracerCall:
+		callTracer = vm.NewCallTracer()
+		tracer = callTracer
+	case TracerStruct, "":
+		structLogger = vm.NewStructLogger(&vm.LogConfig{
+			DisableMemory:  config.DisableMemory,
+			DisableStack:   config.DisableStack,
+			DisableStorage: true,
+		})
+		tracer = structLogger
+	default:
+		return nil, fmt.Errorf("unknown tracer %s", config.Tracer)
+	}
+
+	stateHelper.EthStateDB.Prepare(crypto.GetHashBytes(tx.Hash), crypto.GetHashBytes(tx.Hash), 0)
+	vmenv := newEVM(
+		tx,
+		newContext(tx, coinbase, msg.From(), msg.GasPrice()),
+		stateHelper,
+		vm.Config{
+			Debug:  true,
+			Tracer: tracer,
+		},
+	)
+	ret, _, gas, failed, err := ethereum.ApplyMessage(vmenv, msg, stateHelper.GP)
+
+	result := &TraceResult{
+		TransactionHash: tx.Hash,
+		Failed:          failed || err != nil,
+		HertzUsed:       gas,
+		ReturnValue:     hex.EncodeToString(ret),
+	}
+	if err != nil {
+		result.Error = toHertzError(err).Error()
+	}
+	if structLogger != nil {
+		result.StructLogs = structLogger.StructLogs()
+		if structLogger.Error() != nil && result.Error == "" {
+			result.Error = structLogger.Error().Error()
+		}
+	}
+	if callTracer != nil {
+		result.Calls = callTracer.Result()
+	}
+	return result, nil
+}
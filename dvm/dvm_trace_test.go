@@ -0,0 +1,50 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package dvm
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestTraceRevertedTransaction - A reverted transaction is traced against the balances it ran against
+func TestTraceRevertedTransaction(t *testing.T) {
+	setBalance(t, testFrom, 100)
+	abi := testAbi("fail")
+	contractAddress := deploy(t, 1, revertingCode, abi)
+
+	tx, _, err := execute(2, contractAddress, abi, "fail")
+	if _, ok := err.(*RevertError); !ok {
+		t.Fatalf("executing fail returned %v, expected a revert", err)
+	}
+
+	// The trace must not see balances that changed after the transaction
+	setBalance(t, testFrom, 999)
+	result, err := GetDVMService().TraceTransaction(tx, testFrom, &TraceConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Failed {
+		t.Error("trace of a reverted transaction not failed")
+	}
+	if len(result.StructLogs) == 0 {
+		t.Error("trace of a reverted transaction has no struct logs")
+	}
+	if expected := fmt.Sprintf("%064x", 100); result.ReturnValue != expected {
+		t.Errorf("traced revert data %s, expected the balance of 100 the transaction ran against", result.ReturnValue)
+	}
+}
@@ -14,10 +14,8 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package bind generates Ethereum contract Go bindings.
-//
-// Detailed usage document and tutorial available on the go-ethereum Wiki page:
-// https://github.com/dispatchlabs/disgo/dvm/ethereum/wiki/Native-DApps:-Go-bindings-to-Ethereum-contracts
+// Package bind generates Go bindings of contracts that run on Dispatch delegates,
+// the generated code calls delegates through a DispatchBackend such as sdk.Backend.
 package bind
 
 import (
@@ -117,11 +115,20 @@ func Bind(types []string, abis []string, bytecodes []string, pkg string, lang La
 			// Append the event to the accumulator list
 			events[original.Name] = &tmplEvent{Original: original, Normalized: normalized}
 		}
+		// Name anonymous constructor inputs so deploy functions have parameters
+		constructor := evmABI.Constructor
+		constructor.Inputs = make([]abi.Argument, len(evmABI.Constructor.Inputs))
+		copy(constructor.Inputs, evmABI.Constructor.Inputs)
+		for j, input := range constructor.Inputs {
+			if input.Name == "" {
+				constructor.Inputs[j].Name = fmt.Sprintf("arg%d", j)
+			}
+		}
 		contracts[types[i]] = &tmplContract{
 			Type:        capitalise(types[i]),
 			InputABI:    strings.Replace(strippedABI, "\"", "\\\"", -1),
 			InputBin:    strings.TrimSpace(bytecodes[i]),
-			Constructor: evmABI.Constructor,
+			Constructor: constructor,
 			Calls:       calls,
 			Transacts:   transacts,
 			Events:      events,
@@ -201,6 +208,10 @@ func arrayBindingGo(inner string, arraySizes []string) string {
 // mapped will use an upscaled type (e.g. *big.Int).
 func bindTypeGo(kind abi.Type) string {
 	stringKind := kind.String()
+	// Tuples unpack into structs built at runtime, they and arrays of them are left untyped
+	if strings.HasPrefix(stringKind, "(") {
+		return "interface{}"
+	}
 	innerLen, innerMapping := bindUnnestedTypeGo(stringKind)
 	return arrayBindingGo(wrapArray(stringKind, innerLen, innerMapping))
 }
@@ -212,7 +223,7 @@ func bindUnnestedTypeGo(stringKind string) (int, string) {
 
 	switch {
 	case strings.HasPrefix(stringKind, "address"):
-		return len("address"), "common.Address"
+		return len("address"), "crypto.AddressBytes"
 
 	case strings.HasPrefix(stringKind, "bytes"):
 		parts := regexp.MustCompile(`bytes([0-9]*)`).FindStringSubmatch(stringKind)
@@ -320,7 +331,7 @@ var bindTopicType = map[Lang]func(kind abi.Type) string{
 func bindTopicTypeGo(kind abi.Type) string {
 	bound := bindTypeGo(kind)
 	if bound == "string" || bound == "[]byte" {
-		bound = "common.Hash"
+		bound = "crypto.HashBytes"
 	}
 	return bound
 }
@@ -0,0 +1,61 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package bind
+
+import (
+	"context"
+	"time"
+
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+// DispatchBackend - What bound contracts need from a Dispatch delegate, sdk.Backend implements it over HTTP
+type DispatchBackend interface {
+	// SendTransaction submits a signed deploy or execute smart contract transaction
+	SendTransaction(ctx context.Context, transaction *commonTypes.Transaction) error
+
+	// WaitForReceipt blocks until the transaction has executed, an error is returned with the receipt when it did not succeed
+	WaitForReceipt(ctx context.Context, hash string) (*commonTypes.Receipt, error)
+
+	// CallContract runs a read-only method of the contract at address, the outputs are JSON values
+	CallContract(ctx context.Context, address string, call *commonTypes.ContractCall) (*commonTypes.ContractCallResult, error)
+
+	// FilterLogs returns the event logs matching the filter, empty positional topics match any value and a zero To is now
+	FilterLogs(ctx context.Context, filter *commonTypes.LogFilter) ([]*commonTypes.EventLog, error)
+}
+
+// TransactOpts - Who signs and pays for deploy and execute transactions
+type TransactOpts struct {
+	From       string          // Address of the account sending the transaction
+	PrivateKey string          // Private key of From, signs the transaction
+	Value      int64           // Tokens sent along, the method or constructor must be payable
+	Hertz      int64           // Hertz limit, the default limit when zero
+	Context    context.Context // Bounds sending and waiting for the receipt, optional
+}
+
+// CallOpts - Options of read-only calls
+type CallOpts struct {
+	From    string          // Address calling the method, msg.sender, optional
+	Context context.Context // Bounds the call, optional
+}
+
+// FilterOpts - The time range event logs are filtered in
+type FilterOpts struct {
+	Start   time.Time       // The beginning of time when zero
+	End     time.Time       // Now when zero
+	Context context.Context // Bounds the query, optional
+}
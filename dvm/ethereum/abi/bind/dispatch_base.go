@@ -0,0 +1,279 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package bind
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/helper"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/commons/utils"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/abi"
+	"github.com/pkg/errors"
+)
+
+// BoundContract - The generic wrapper generated bindings call through, it converts between the Go values of the
+// bindings and the JSON values delegates take
+type BoundContract struct {
+	address string // Hex, empty until deployed
+	abi     abi.ABI
+	backend DispatchBackend
+}
+
+// NewBoundContract - Binds the contract deployed at address, abiJSON is the contract's ABI
+func NewBoundContract(address string, abiJSON string, backend DispatchBackend) (*BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return &BoundContract{address: strings.TrimPrefix(address, "0x"), abi: parsed, backend: backend}, nil
+}
+
+// DeployContract - Deploys bytecode passing params to the constructor and waits for the receipt, the returned
+// contract is bound to the deployed address
+func DeployContract(opts *TransactOpts, abiJSON string, bytecode string, backend DispatchBackend, params ...interface{}) (*commonTypes.Receipt, *BoundContract, error) {
+	contract, err := NewBoundContract("", abiJSON, backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := toJsonParams(contract.abi.Constructor.Inputs, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	transaction, err := commonTypes.NewDeployContractTransactionWithValue(opts.PrivateKey, opts.From, strings.TrimPrefix(bytecode, "0x"), abiJSON, opts.Value, utils.ToMilliSeconds(time.Now()), values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := contract.send(opts, transaction)
+	if err != nil {
+		return receipt, nil, err
+	}
+	contract.address = receipt.ContractAddress
+	return receipt, contract, nil
+}
+
+// Address - The hex address of the contract
+func (this *BoundContract) Address() string {
+	return this.address
+}
+
+// Call - Runs the read-only method with params, the outputs are the Go values of the method's outputs
+func (this *BoundContract) Call(opts *CallOpts, method string, params ...interface{}) ([]interface{}, error) {
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	theMethod, ok := this.abi.Methods[method]
+	if !ok {
+		return nil, errors.Errorf("method %s is not in the ABI", method)
+	}
+	values, err := toJsonParams(theMethod.Inputs, params)
+	if err != nil {
+		return nil, err
+	}
+	result, err := this.backend.CallContract(ensureContext(opts.Context), this.address, &commonTypes.ContractCall{From: opts.From, Method: method, Params: values})
+	if err != nil {
+		return nil, err
+	}
+	return helper.ToAbiValues(theMethod.Outputs, result.Outputs)
+}
+
+// Transact - Executes the method with params and waits for the receipt
+func (this *BoundContract) Transact(opts *TransactOpts, method string, params ...interface{}) (*commonTypes.Receipt, error) {
+	theMethod, ok := this.abi.Methods[method]
+	if !ok {
+		return nil, errors.Errorf("method %s is not in the ABI", method)
+	}
+	values, err := toJsonParams(theMethod.Inputs, params)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := commonTypes.NewExecuteContractTransactionWithValue(opts.PrivateKey, opts.From, this.address, method, values, opts.Value, utils.ToMilliSeconds(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return this.send(opts, transaction)
+}
+
+// FilterLogs - Returns the logs of the event, query holds the accepted values of each indexed input in order, no
+// values accepts any
+func (this *BoundContract) FilterLogs(opts *FilterOpts, name string, query ...[]interface{}) ([]*commonTypes.EventLog, error) {
+	if opts == nil {
+		opts = new(FilterOpts)
+	}
+	event, ok := this.abi.Events[name]
+	if !ok {
+		return nil, errors.Errorf("event %s is not in the ABI", name)
+	}
+	topics, err := makeTopics(query...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delegates match one value per topic, topics with several values are matched here.
+	id := event.Id()
+	filter := &commonTypes.LogFilter{Address: this.address, Topics: []string{hex.EncodeToString(id[:])}}
+	for _, values := range topics {
+		if len(values) == 1 {
+			filter.Topics = append(filter.Topics, hex.EncodeToString(values[0][:]))
+		} else {
+			filter.Topics = append(filter.Topics, "")
+		}
+	}
+	if !opts.Start.IsZero() {
+		filter.From = utils.ToMilliSeconds(opts.Start)
+	}
+	if !opts.End.IsZero() {
+		filter.To = utils.ToMilliSeconds(opts.End)
+	}
+	eventLogs, err := this.backend.FilterLogs(ensureContext(opts.Context), filter)
+	if err != nil {
+		return nil, err
+	}
+	var result []*commonTypes.EventLog
+	for _, eventLog := range eventLogs {
+		if matchesTopics(eventLog, topics) {
+			result = append(result, eventLog)
+		}
+	}
+	return result, nil
+}
+
+// UnpackLog - Sets the fields of out, a pointer to the event's struct, from the data and topics of the log
+func (this *BoundContract) UnpackLog(out interface{}, name string, eventLog *commonTypes.EventLog) error {
+	event, ok := this.abi.Events[name]
+	if !ok {
+		return errors.Errorf("event %s is not in the ABI", name)
+	}
+	data, err := hex.DecodeString(eventLog.Data)
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		err = this.abi.Unpack(out, name, data)
+		if err != nil {
+			return err
+		}
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(eventLog.Topics) == 0 {
+		return errors.Errorf("log %d of transaction %s has no topics", eventLog.Index, eventLog.TransactionHash)
+	}
+	topics := make([]crypto.HashBytes, len(eventLog.Topics)-1)
+	for i, topic := range eventLog.Topics[1:] {
+		bytes, err := hex.DecodeString(topic)
+		if err != nil {
+			return err
+		}
+		copy(topics[i][:], bytes)
+	}
+	return parseTopics(out, indexed, topics)
+}
+
+// send - Submits the signed transaction and waits for its receipt
+func (this *BoundContract) send(opts *TransactOpts, transaction *commonTypes.Transaction) (*commonTypes.Receipt, error) {
+
+	// Hertz is not part of the hash, the signature stays valid.
+	transaction.Hertz = opts.Hertz
+	ctx := ensureContext(opts.Context)
+	err := this.backend.SendTransaction(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+	return this.backend.WaitForReceipt(ctx, transaction.Hash)
+}
+
+// toJsonParams - Checks params against the arguments and converts them into the JSON values delegates take
+func toJsonParams(arguments abi.Arguments, params []interface{}) ([]interface{}, error) {
+	values, err := helper.ToAbiValues(arguments, params)
+	if err != nil {
+		return nil, err
+	}
+	values = helper.ToJsonValues(arguments, values)
+	for i, value := range values {
+		values[i] = toDecimalStrings(value)
+	}
+	return values, nil
+}
+
+// toDecimalStrings - Integers become decimal strings, JSON numbers lose digits past 2^53
+func toDecimalStrings(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case []interface{}:
+		for i := range v {
+			v[i] = toDecimalStrings(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = toDecimalStrings(v[key])
+		}
+		return v
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflectValue.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflectValue.Uint(), 10)
+	}
+	return value
+}
+
+// matchesTopics - Whether each topic of the log after the event id is one of the accepted values
+func matchesTopics(eventLog *commonTypes.EventLog, topics [][]crypto.HashBytes) bool {
+	for i, values := range topics {
+		if len(values) < 2 {
+			continue
+		}
+		if i+1 >= len(eventLog.Topics) {
+			return false
+		}
+		found := false
+		for _, value := range values {
+			if hex.EncodeToString(value[:]) == eventLog.Topics[i+1] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureContext - A background context when ctx is not set
+func ensureContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
@@ -0,0 +1,208 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package bind
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	commonTypes "github.com/dispatchlabs/disgo/commons/types"
+)
+
+var (
+	testPrivateKey = "0f86ea981203b26b5b8244c8f661e30e5104555068a4bd168d3e3015db9bb25a"
+	testFrom       = "3ed25f42484d517cdfc72cafb7ebc9e8baa52c2c"
+	testContract   = "d5765c93699c96327753230ac3d78edb3b34236b"
+	testTokenABI   = `[
+		{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+		{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},
+		{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+		{"inputs":[{"name":"supply","type":"uint256"}],"type":"constructor"}
+	]`
+)
+
+// testBackend - Records what bound contracts send and answers with canned results
+type testBackend struct {
+	transactions []*commonTypes.Transaction
+	calls        []*commonTypes.ContractCall
+	filter       *commonTypes.LogFilter
+	receipt      *commonTypes.Receipt
+	callResult   *commonTypes.ContractCallResult
+	logs         []*commonTypes.EventLog
+}
+
+func (this *testBackend) SendTransaction(ctx context.Context, transaction *commonTypes.Transaction) error {
+	this.transactions = append(this.transactions, transaction)
+	return nil
+}
+
+func (this *testBackend) WaitForReceipt(ctx context.Context, hash string) (*commonTypes.Receipt, error) {
+	return this.receipt, nil
+}
+
+func (this *testBackend) CallContract(ctx context.Context, address string, call *commonTypes.ContractCall) (*commonTypes.ContractCallResult, error) {
+	this.calls = append(this.calls, call)
+	return this.callResult, nil
+}
+
+func (this *testBackend) FilterLogs(ctx context.Context, filter *commonTypes.LogFilter) ([]*commonTypes.EventLog, error) {
+	this.filter = filter
+	return this.logs, nil
+}
+
+func testAddress(value string) crypto.AddressBytes {
+	var address crypto.AddressBytes
+	bytes, _ := hex.DecodeString(value)
+	copy(address[:], bytes)
+	return address
+}
+
+// TestBindDispatch
+func TestBindDispatch(t *testing.T) {
+	code, err := Bind([]string{"token"}, []string{testTokenABI}, []string{"6060604052"}, "token", LangGo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "token.go", code, 0)
+	if err != nil {
+		t.Fatalf("generated binding does not parse: %v\n%s", err, code)
+	}
+	config := types.Config{Importer: importer.For("source", nil)}
+	_, err = config.Check("token", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("generated binding does not type check: %v\n%s", err, code)
+	}
+	for _, expected := range []string{
+		"func DeployToken(opts *bind.TransactOpts, backend bind.DispatchBackend, supply *big.Int) (*commonTypes.Receipt, *Token, error)",
+		"func NewToken(address string, backend bind.DispatchBackend) (*Token, error)",
+		"func (_Token *TokenCaller) BalanceOf(opts *bind.CallOpts, owner crypto.AddressBytes) (*big.Int, error)",
+		"func (_Token *TokenTransactor) Transfer(opts *bind.TransactOpts, to crypto.AddressBytes, value *big.Int) (*commonTypes.Receipt, error)",
+		"func (_Token *TokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []crypto.AddressBytes, to []crypto.AddressBytes) (*TokenTransferIterator, error)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("generated binding is missing %s\n%s", expected, code)
+		}
+	}
+}
+
+// TestBoundContractTransact
+func TestBoundContractTransact(t *testing.T) {
+	backend := &testBackend{receipt: &commonTypes.Receipt{Status: commonTypes.StatusOk}}
+	contract, err := NewBoundContract("0x"+testContract, testTokenABI, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := new(big.Int).SetString("100000000000000000000", 10)
+	receipt, err := contract.Transact(&TransactOpts{From: testFrom, PrivateKey: testPrivateKey, Hertz: 50000}, "transfer", testAddress(testFrom), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt != backend.receipt || len(backend.transactions) != 1 {
+		t.Fatalf("expected one transaction and its receipt")
+	}
+
+	// Integers are sent as decimal strings so no digits are lost.
+	transaction := backend.transactions[0]
+	if transaction.To != testContract || transaction.Method != "transfer" || transaction.Hertz != 50000 {
+		t.Errorf("unexpected transaction %s", transaction.String())
+	}
+	if fmt.Sprint(transaction.Params) != fmt.Sprintf("[%s 100000000000000000000]", testFrom) {
+		t.Errorf("unexpected params %v", transaction.Params)
+	}
+	hash, err := transaction.NewHash()
+	if err != nil || hash != transaction.Hash {
+		t.Errorf("setting hertz changed the hash")
+	}
+
+	_, err = contract.Transact(&TransactOpts{From: testFrom, PrivateKey: testPrivateKey}, "transfer", testAddress(testFrom))
+	if err == nil {
+		t.Errorf("expected an error for a missing argument")
+	}
+}
+
+// TestBoundContractCall
+func TestBoundContractCall(t *testing.T) {
+	backend := &testBackend{callResult: &commonTypes.ContractCallResult{Outputs: []interface{}{json.Number("100000000000000000000")}}}
+	contract, err := NewBoundContract(testContract, testTokenABI, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := contract.Call(&CallOpts{From: testFrom}, "balanceOf", testAddress(testFrom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, ok := out[0].(*big.Int)
+	if !ok || balance.String() != "100000000000000000000" {
+		t.Errorf("unexpected outputs %v", out)
+	}
+	if backend.calls[0].From != testFrom || backend.calls[0].Method != "balanceOf" {
+		t.Errorf("unexpected call %s", backend.calls[0].String())
+	}
+}
+
+// TestBoundContractFilterLogs
+func TestBoundContractFilterLogs(t *testing.T) {
+	contract, err := NewBoundContract(testContract, testTokenABI, &testBackend{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := contract.abi.Events["Transfer"].Id()
+	alice, bob, carol := strings.Repeat("a", 40), strings.Repeat("b", 40), strings.Repeat("c", 40)
+	toTopic := func(address string) string { return strings.Repeat("0", 24) + address }
+	backend := &testBackend{logs: []*commonTypes.EventLog{
+		{ContractAddress: testContract, Topics: []string{hex.EncodeToString(id[:]), toTopic(alice), toTopic(carol)}, Data: fmt.Sprintf("%064x", 42)},
+		{ContractAddress: testContract, Topics: []string{hex.EncodeToString(id[:]), toTopic(carol), toTopic(carol)}, Data: fmt.Sprintf("%064x", 7)},
+	}}
+	contract.backend = backend
+
+	// One value per topic is filtered by the delegate, several values here.
+	eventLogs, err := contract.FilterLogs(nil, "Transfer", []interface{}{testAddress(alice), testAddress(bob)}, []interface{}{testAddress(carol)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.filter.Topics) != 3 || backend.filter.Topics[1] != "" || backend.filter.Topics[2] != toTopic(carol) {
+		t.Errorf("unexpected filter topics %v", backend.filter.Topics)
+	}
+	if len(eventLogs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(eventLogs))
+	}
+
+	event := new(struct {
+		From  crypto.AddressBytes
+		To    crypto.AddressBytes
+		Value *big.Int
+	})
+	err = contract.UnpackLog(event, "Transfer", eventLogs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.From != testAddress(alice) || event.To != testAddress(carol) || event.Value.Int64() != 42 {
+		t.Errorf("unexpected event %+v", event)
+	}
+}
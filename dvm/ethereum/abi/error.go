@@ -53,7 +53,9 @@ func sliceTypeCheck(t Type, val reflect.Value) error {
 			return sliceTypeCheck(*t.Elem, val.Index(0))
 		}
 	} else if t.Elem.T == ArrayTy {
-		return sliceTypeCheck(*t.Elem, val.Index(0))
+		if val.Len() > 0 {
+			return sliceTypeCheck(*t.Elem, val.Index(0))
+		}
 	}
 
 	if elemKind := val.Type().Elem().Kind(); elemKind != t.Elem.Kind {
@@ -70,6 +72,9 @@ func typeCheck(t Type, value reflect.Value) error {
 	if t.T == SliceTy || t.T == ArrayTy {
 		return sliceTypeCheck(t, value)
 	}
+	if t.T == TupleTy && value.Kind() != reflect.Struct {
+		return typeErr(t.stringKind, value.Type())
+	}
 
 	// Check base type validity. Element types will be checked later on.
 	if t.Kind != value.Kind() {
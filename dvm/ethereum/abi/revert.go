@@ -0,0 +1,92 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dispatchlabs/disgo/dvm/ethereum/crypto"
+)
+
+var (
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector  = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	errNotRevert = errors.New("abi: revert data is not an Error(string) or Panic(uint256)")
+)
+
+// Error represents a custom error declared in a contract, raised with `revert MyError(...)`.
+type Error struct {
+	Name   string
+	Inputs Arguments
+}
+
+// Sig returns the error's signature, e.g. `InsufficientBalance(uint256,uint256)`
+func (e Error) Sig() string {
+	types := make([]string, len(e.Inputs))
+	for i, input := range e.Inputs {
+		types[i] = input.Type.String()
+	}
+	return fmt.Sprintf("%v(%v)", e.Name, strings.Join(types, ","))
+}
+
+// Id returns the four byte selector the error is reverted with
+func (e Error) Id() []byte {
+	return crypto.Keccak256([]byte(e.Sig()))[:4]
+}
+
+// ErrorById looks up the custom error whose selector matches the first four bytes of data
+func (abi *ABI) ErrorById(data []byte) (*Error, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: revert data is too short (%d bytes) for an error selector", len(data))
+	}
+	for _, e := range abi.Errors {
+		if bytes.Equal(e.Id(), data[:4]) {
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("abi: no error with id %x", data[:4])
+}
+
+// UnpackRevert decodes the standard revert payloads, the reason of `revert("...")` and `require(..., "...")`
+// and the code of a compiler inserted Panic(uint256)
+func UnpackRevert(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", errNotRevert
+	}
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		stringTy, _ := NewType("string")
+		values, err := Arguments{{Type: stringTy}}.UnpackValues(data[4:])
+		if err != nil {
+			return "", err
+		}
+		return values[0].(string), nil
+	case bytes.Equal(data[:4], panicSelector):
+		uintTy, _ := NewType("uint256")
+		values, err := Arguments{{Type: uintTy}}.UnpackValues(data[4:])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("panic code 0x%x", values[0].(*big.Int)), nil
+	}
+	return "", errNotRevert
+}
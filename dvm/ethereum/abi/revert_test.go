@@ -0,0 +1,104 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestUnpackRevert(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+		err    bool
+	}{
+		{"", "", true},
+		{"08c379a1", "", true},
+		{"08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000", "revert reason", false},
+		{"4e487b710000000000000000000000000000000000000000000000000000000000000011", "panic code 0x11", false},
+	}
+	for i, test := range tests {
+		data, err := hex.DecodeString(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := UnpackRevert(data)
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error, got %q", i, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if got != test.expect {
+			t.Errorf("test %d: expected %q, got %q", i, test.expect, got)
+		}
+	}
+}
+
+const customErrorJSON = `[
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"function","name":"withdraw","constant":false,"inputs":[{"name":"amount","type":"uint256"}],"outputs":[]}
+]`
+
+func TestErrorById(t *testing.T) {
+	abi, err := JSON(strings.NewReader(customErrorJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	abiError, ok := abi.Errors["InsufficientBalance"]
+	if !ok {
+		t.Fatal("expected InsufficientBalance in Errors")
+	}
+	if abiError.Sig() != "InsufficientBalance(uint256,uint256)" {
+		t.Errorf("unexpected signature %s", abiError.Sig())
+	}
+	if hex.EncodeToString(abiError.Id()) != "cf479181" {
+		t.Errorf("unexpected id %x", abiError.Id())
+	}
+
+	packed, err := abiError.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(abiError.Id(), packed...)
+	found, err := abi.ErrorById(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Name != "InsufficientBalance" {
+		t.Errorf("expected InsufficientBalance, got %s", found.Name)
+	}
+	values, err := found.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0].(*big.Int).Int64() != 1 || values[1].(*big.Int).Int64() != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	if _, err := abi.ErrorById([]byte{0x01, 0x02, 0x03, 0x04}); err == nil {
+		t.Error("expected an error for an unknown selector")
+	}
+	if _, err := UnpackRevert(data); err == nil {
+		t.Error("expected a custom error not to unpack as a revert reason")
+	}
+}
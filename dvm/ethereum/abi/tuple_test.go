@@ -0,0 +1,143 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+)
+
+const tupleJSON = `[
+	{"type":"function","name":"setPerson","inputs":[{"name":"person","type":"tuple","components":[{"name":"age","type":"uint256"},{"name":"name","type":"string"}]}],"outputs":[]},
+	{"type":"function","name":"setOwners","inputs":[{"name":"owners","type":"tuple[]","components":[{"name":"wallet","type":"address"},{"name":"salt","type":"bytes32"}]}],"outputs":[]},
+	{"type":"function","name":"setNames","inputs":[{"name":"names","type":"string[]"}],"outputs":[]}
+]`
+
+func word(hexValue string) string {
+	return strings.Repeat("0", 64-len(hexValue)) + hexValue
+}
+
+func TestTupleSignatures(t *testing.T) {
+	abi, err := JSON(strings.NewReader(tupleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		method string
+		sig    string
+	}{
+		{"setPerson", "setPerson((uint256,string))"},
+		{"setOwners", "setOwners((address,bytes32)[])"},
+		{"setNames", "setNames(string[])"},
+	}
+	for _, test := range tests {
+		if sig := abi.Methods[test.method].Sig(); sig != test.sig {
+			t.Errorf("%s: expected signature %s, got %s", test.method, test.sig, sig)
+		}
+	}
+	person := abi.Methods["setPerson"].Inputs[0].Type
+	if person.T != TupleTy || len(person.TupleElems) != 2 || !reflect.DeepEqual(person.TupleRawNames, []string{"age", "name"}) {
+		t.Errorf("unexpected tuple type %+v", person)
+	}
+}
+
+func TestTuplePackUnpack(t *testing.T) {
+	abi, err := JSON(strings.NewReader(tupleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (uint256,string) is dynamic so it is referenced by an offset
+	personType := abi.Methods["setPerson"].Inputs[0].Type
+	person := reflect.New(personType.Type).Elem()
+	person.Field(0).Set(reflect.ValueOf(big.NewInt(1)))
+	person.Field(1).SetString("hi")
+	expected := word("20") + word("01") + word("40") + word("02") + "6869" + strings.Repeat("0", 60)
+	packed, err := abi.Methods["setPerson"].Inputs.Pack(person.Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(packed) != expected {
+		t.Errorf("setPerson: expected %s, got %x", expected, packed)
+	}
+	values, err := abi.Methods["setPerson"].Inputs.UnpackValues(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values[0], person.Interface()) {
+		t.Errorf("setPerson: expected %v, got %v", person.Interface(), values[0])
+	}
+
+	// string[] elements are offsets relative to the start of the slice contents
+	names := []string{"a", "bc"}
+	expected = word("20") + word("02") + word("40") + word("80") + word("01") + "61" + strings.Repeat("0", 62) + word("02") + "6263" + strings.Repeat("0", 60)
+	packed, err = abi.Methods["setNames"].Inputs.Pack(names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(packed) != expected {
+		t.Errorf("setNames: expected %s, got %x", expected, packed)
+	}
+	values, err = abi.Methods["setNames"].Inputs.UnpackValues(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values[0], names) {
+		t.Errorf("setNames: expected %v, got %v", names, values[0])
+	}
+
+	// (address,bytes32)[] elements are static tuples packed in place
+	ownersType := abi.Methods["setOwners"].Inputs[0].Type
+	owners := reflect.MakeSlice(ownersType.Type, 2, 2)
+	for i := 0; i < 2; i++ {
+		var address crypto.AddressBytes
+		var salt [32]byte
+		address[19] = byte(i + 1)
+		salt[0] = byte(i + 10)
+		owners.Index(i).Field(0).Set(reflect.ValueOf(address))
+		owners.Index(i).Field(1).Set(reflect.ValueOf(salt))
+	}
+	packed, err = abi.Methods["setOwners"].Inputs.Pack(owners.Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packed) != 32*6 {
+		t.Fatalf("setOwners: expected %d bytes, got %d", 32*6, len(packed))
+	}
+	if !bytes.Equal(packed[64:96], append(make([]byte, 31), 1)) || packed[160] != 11 {
+		t.Errorf("setOwners: unexpected encoding %x", packed)
+	}
+	values, err = abi.Methods["setOwners"].Inputs.UnpackValues(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values[0], owners.Interface()) {
+		t.Errorf("setOwners: expected %v, got %v", owners.Interface(), values[0])
+	}
+}
+
+func TestTupleWithoutComponents(t *testing.T) {
+	if _, err := NewType("tuple"); err == nil {
+		t.Error("expected an error for a tuple without components")
+	}
+}
@@ -40,6 +40,7 @@ const (
 	HashTy
 	FixedPointTy
 	FunctionTy
+	TupleTy
 )
 
 // Type is the reflection of the supported argument type
@@ -52,6 +53,10 @@ type Type struct {
 	T    byte // Our own type checking
 
 	stringKind string // holds the unparsed string for deriving signatures
+
+	// Tuple relative fields
+	TupleElems    []*Type  // Type information of all tuple fields
+	TupleRawNames []string // Raw field name of all tuple fields
 }
 
 var (
@@ -61,6 +66,12 @@ var (
 
 // NewType creates a new reflection type of abi type given in t.
 func NewType(t string) (typ Type, err error) {
+	return NewTypeWithComponents(t, nil)
+}
+
+// NewTypeWithComponents creates a new reflection type of abi type given in t, components are the fields of a tuple
+// type and are ignored by every other type.
+func NewTypeWithComponents(t string, components []ArgumentMarshaling) (typ Type, err error) {
 	// check that array brackets are equal if they exist
 	if strings.Count(t, "[") != strings.Count(t, "]") {
 		return Type{}, fmt.Errorf("invalid arg type in abi")
@@ -73,7 +84,7 @@ func NewType(t string) (typ Type, err error) {
 	if strings.Count(t, "[") != 0 {
 		i := strings.LastIndex(t, "[")
 		// recursively embed the type
-		embeddedType, err := NewType(t[:i])
+		embeddedType, err := NewTypeWithComponents(t[:i], components)
 		if err != nil {
 			return Type{}, err
 		}
@@ -102,6 +113,9 @@ func NewType(t string) (typ Type, err error) {
 		} else {
 			return Type{}, fmt.Errorf("invalid formatting of array type")
 		}
+		if embeddedType.T == TupleTy {
+			typ.stringKind = embeddedType.stringKind + sliced
+		}
 		return typ, err
 	}
 	// parse the type and size of the abi-type.
@@ -160,6 +174,42 @@ func NewType(t string) (typ Type, err error) {
 		typ.T = FunctionTy
 		typ.Size = 24
 		typ.Type = reflect.ArrayOf(24, reflect.TypeOf(byte(0)))
+	case "tuple":
+		if len(components) == 0 {
+			return Type{}, fmt.Errorf("abi: tuple type %s has no components", t)
+		}
+		var (
+			fields     []reflect.StructField
+			elems      []*Type
+			names      []string
+			expression []string
+			used       = make(map[string]bool)
+		)
+		for i, component := range components {
+			elem, err := NewTypeWithComponents(component.Type, component.Components)
+			if err != nil {
+				return Type{}, err
+			}
+			fieldName := toFieldName(component.Name, i)
+			for used[fieldName] {
+				fieldName += "_"
+			}
+			used[fieldName] = true
+			fields = append(fields, reflect.StructField{
+				Name: fieldName,
+				Type: elem.Type,
+				Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, component.Name)),
+			})
+			elems = append(elems, &elem)
+			names = append(names, component.Name)
+			expression = append(expression, elem.stringKind)
+		}
+		typ.Kind = reflect.Struct
+		typ.Type = reflect.StructOf(fields)
+		typ.TupleElems = elems
+		typ.TupleRawNames = names
+		typ.T = TupleTy
+		typ.stringKind = "(" + strings.Join(expression, ",") + ")"
 	default:
 		return Type{}, fmt.Errorf("unsupported arg type: %s", t)
 	}
@@ -180,21 +230,60 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 		return nil, err
 	}
 
-	if t.T == SliceTy || t.T == ArrayTy {
-		var packed []byte
+	switch t.T {
+	case SliceTy, ArrayTy:
+		var ret []byte
 
+		if t.requiresLengthPrefix() {
+			// append length
+			ret = append(ret, packNum(reflect.ValueOf(v.Len()))...)
+		}
+
+		// calculate offset if any
+		offset := 0
+		offsetReq := isDynamicType(*t.Elem)
+		if offsetReq {
+			offset = getTypeSize(*t.Elem) * v.Len()
+		}
+		var tail []byte
 		for i := 0; i < v.Len(); i++ {
 			val, err := t.Elem.pack(v.Index(i))
 			if err != nil {
 				return nil, err
 			}
-			packed = append(packed, val...)
+			if !offsetReq {
+				ret = append(ret, val...)
+				continue
+			}
+			ret = append(ret, packNum(reflect.ValueOf(offset))...)
+			offset += len(val)
+			tail = append(tail, val...)
+		}
+		return append(ret, tail...), nil
+	case TupleTy:
+		// the fields of the struct are the tuple fields in order
+		if v.NumField() != len(t.TupleElems) {
+			return nil, fmt.Errorf("abi: cannot use struct with %d fields as tuple %s", v.NumField(), t.stringKind)
 		}
-		if t.T == SliceTy {
-			return packBytesSlice(packed, v.Len()), nil
-		} else if t.T == ArrayTy {
-			return packed, nil
+		offset := 0
+		for _, elem := range t.TupleElems {
+			offset += getTypeSize(*elem)
+		}
+		var ret, tail []byte
+		for i, elem := range t.TupleElems {
+			val, err := elem.pack(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			if isDynamicType(*elem) {
+				ret = append(ret, packNum(reflect.ValueOf(offset))...)
+				tail = append(tail, val...)
+				offset += len(val)
+			} else {
+				ret = append(ret, val...)
+			}
 		}
+		return append(ret, tail...), nil
 	}
 	return packElement(t, v), nil
 }
@@ -204,3 +293,72 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 func (t Type) requiresLengthPrefix() bool {
 	return t.T == StringTy || t.T == BytesTy || t.T == SliceTy
 }
+
+// isDynamicType returns true if the type is dynamic.
+// The following types are called “dynamic”:
+// * bytes
+// * string
+// * T[] for any T
+// * T[k] for any dynamic T and any k >= 0
+// * (T1,...,Tk) if Ti is dynamic for some 1 <= i <= k
+func isDynamicType(t Type) bool {
+	if t.T == TupleTy {
+		for _, elem := range t.TupleElems {
+			if isDynamicType(*elem) {
+				return true
+			}
+		}
+		return false
+	}
+	return t.T == StringTy || t.T == BytesTy || t.T == SliceTy || (t.T == ArrayTy && isDynamicType(*t.Elem))
+}
+
+// getTypeSize returns the size that this type needs to occupy.
+// We distinguish static and dynamic types. Static types are encoded in-place
+// and dynamic types are encoded at a separately allocated location after the
+// current block.
+// So for a static variable, the size returned represents the size that the
+// variable actually occupies.
+// For a dynamic variable, the returned size is fixed 32 bytes, which is used
+// to store the location reference for actual value storage.
+func getTypeSize(t Type) int {
+	if t.T == ArrayTy && !isDynamicType(*t.Elem) {
+		// Recursively calculate type size if it is a nested array
+		if t.Elem.T == ArrayTy || t.Elem.T == TupleTy {
+			return t.Size * getTypeSize(*t.Elem)
+		}
+		return t.Size * 32
+	} else if t.T == TupleTy && !isDynamicType(t) {
+		total := 0
+		for _, elem := range t.TupleElems {
+			total += getTypeSize(*elem)
+		}
+		return total
+	}
+	return 32
+}
+
+// toFieldName returns the exported struct field name of the tuple component name, FieldN when the name is empty or
+// can't be a field name
+func toFieldName(name string, index int) string {
+	var fieldName []rune
+	upper := true
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return fmt.Sprintf("Field%d", index)
+		}
+		if upper {
+			r = []rune(strings.ToUpper(string(r)))[0]
+			upper = false
+		}
+		fieldName = append(fieldName, r)
+	}
+	if len(fieldName) == 0 || (fieldName[0] >= '0' && fieldName[0] <= '9') {
+		return fmt.Sprintf("Field%d", index)
+	}
+	return string(fieldName)
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blake2b
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+// TestF runs the compression function the way BLAKE2b-512 hashes a single
+// short block, using the EIP-152 test vectors for "abc".
+func TestF(t *testing.T) {
+	tests := []struct {
+		rounds uint32
+		final  bool
+		want   string
+	}{
+		{0, true, "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b"},
+		{12, true, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{12, false, "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735"},
+	}
+	for i, test := range tests {
+		// Parameter block for an unkeyed 64 byte digest
+		h := iv
+		h[0] ^= 0x01010040
+
+		var m [16]uint64
+		m[0] = 0x636261 // "abc"
+
+		F(&h, m, [2]uint64{3, 0}, test.final, test.rounds)
+
+		out := make([]byte, 64)
+		for j := 0; j < 8; j++ {
+			binary.LittleEndian.PutUint64(out[j*8:], h[j])
+		}
+		if got := hex.EncodeToString(out); got != test.want {
+			t.Errorf("test %d: got %s, want %s", i, got, test.want)
+		}
+	}
+}
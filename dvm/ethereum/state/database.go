@@ -76,10 +76,22 @@ type Trie interface {
 // intermediate trie-node memory pool between the low level storage layer and the
 // high level trie abstraction.
 func NewDatabase(db ethdb.Database) Database {
+	return NewDatabaseWithCache(db, 0, 0)
+}
+
+// NewDatabaseWithCache creates a backing store for state that also caches up to
+// cleanNodes trie nodes and codes contract codes read from disk. Zero sizes
+// disable the caches.
+func NewDatabaseWithCache(db ethdb.Database, cleanNodes int, codes int) Database {
 	csc, _ := lru.New(codeSizeCacheSize)
+	var codeCache *lru.Cache
+	if codes > 0 {
+		codeCache, _ = lru.New(codes)
+	}
 	return &cachingDB{
-		db:            trie.NewDatabase(db),
+		db:            trie.NewDatabaseWithCache(db, cleanNodes),
 		codeSizeCache: csc,
+		codeCache:     codeCache,
 	}
 }
 
@@ -88,6 +100,7 @@ type cachingDB struct {
 	mu            sync.Mutex
 	pastTries     []*trie.SecureTrie
 	codeSizeCache *lru.Cache
+	codeCache     *lru.Cache // Contract codes by code hash, nil when disabled
 }
 
 // OpenTrie opens the main account trie.
@@ -138,9 +151,17 @@ func (db *cachingDB) CopyTrie(t Trie) Trie {
 
 // ContractCode retrieves a particular contract's code.
 func (db *cachingDB) ContractCode(addrHash, codeHash crypto.HashBytes) ([]byte, error) {
+	if db.codeCache != nil {
+		if cached, ok := db.codeCache.Get(codeHash); ok {
+			return cached.([]byte), nil
+		}
+	}
 	code, err := db.db.Node(codeHash)
 	if err == nil {
 		db.codeSizeCache.Add(codeHash, len(code))
+		if db.codeCache != nil && len(code) > 0 {
+			db.codeCache.Add(codeHash, code)
+		}
 	}
 	return code, err
 }
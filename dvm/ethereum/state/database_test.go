@@ -0,0 +1,185 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/badgerwrapper"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/ethdb"
+)
+
+// countingDatabase - A disk that counts reads
+type countingDatabase struct {
+	ethdb.Database
+	reads int64
+}
+
+// memDatabase - MemDatabase with the Dump ethdb.Database requires
+type memDatabase struct {
+	*ethdb.MemDatabase
+}
+
+func (db memDatabase) Dump() {}
+
+func newCountingDatabase() *countingDatabase {
+	return &countingDatabase{Database: memDatabase{ethdb.NewMemDatabase()}}
+}
+
+func (db *countingDatabase) Get(key []byte) ([]byte, error) {
+	atomic.AddInt64(&db.reads, 1)
+	return db.Database.Get(key)
+}
+
+var (
+	testToken = crypto.GetAddressBytes("d5765c93699c96327753230ac3d78edb3b34236b")
+	testCode  = []byte("6060604052600436106049576000357c0100000000000000000000000000000000000000000000000000000000900463")
+)
+
+func testSlot(i int) crypto.HashBytes {
+	return crypto.BytesToHash(big.NewInt(int64(i)).Bytes())
+}
+
+// newTokenState - Commits a token contract with holders balances and returns the root of the world state
+func newTokenState(t testing.TB, db Database, holders int) crypto.HashBytes {
+	state, err := New(crypto.HashBytes{}, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.SetCode(testToken, testCode)
+	for i := 0; i < holders; i++ {
+		state.SetState(testToken, testSlot(i), crypto.BytesToHash(big.NewInt(1000).Bytes()))
+	}
+	return commitState(t, state)
+}
+
+func commitState(t testing.TB, state *StateDB) crypto.HashBytes {
+	root, err := state.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = state.Database().TrieDB().Commit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// transfer - What a token transfer does to the world state, two balances read and written
+func transfer(state *StateDB, from, to int) {
+	fromBalance := state.GetState(testToken, testSlot(from)).Big()
+	toBalance := state.GetState(testToken, testSlot(to)).Big()
+	state.SetState(testToken, testSlot(from), crypto.BytesToHash(fromBalance.Sub(fromBalance, big.NewInt(1)).Bytes()))
+	state.SetState(testToken, testSlot(to), crypto.BytesToHash(toBalance.Add(toBalance, big.NewInt(1)).Bytes()))
+}
+
+// TestDatabaseWithCache
+func TestDatabaseWithCache(t *testing.T) {
+	disk := newCountingDatabase()
+	shared := NewDatabaseWithCache(disk, 10000, 10)
+	root := newTokenState(t, shared, 100)
+
+	// Committed nodes and code moved to the caches, nothing is read from disk.
+	atomic.StoreInt64(&disk.reads, 0)
+	state, err := New(root, shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if state.GetState(testToken, testSlot(i)).Big().Int64() != 1000 {
+			t.Fatalf("unexpected balance of holder %d", i)
+		}
+	}
+	if string(state.GetCode(testToken)) != string(testCode) {
+		t.Fatalf("unexpected code")
+	}
+	if reads := atomic.LoadInt64(&disk.reads); reads != 0 {
+		t.Errorf("expected no disk reads, got %d", reads)
+	}
+
+	// A new root is read from the caches too, the previous root stays readable.
+	transfer(state, 0, 1)
+	newRoot := commitState(t, state)
+	atomic.StoreInt64(&disk.reads, 0)
+	for _, expected := range []struct {
+		root     crypto.HashBytes
+		balances [2]int64
+	}{{newRoot, [2]int64{999, 1001}}, {root, [2]int64{1000, 1000}}} {
+		state, err = New(expected.root, shared)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, balance := range expected.balances {
+			if actual := state.GetState(testToken, testSlot(i)).Big().Int64(); actual != balance {
+				t.Errorf("expected balance %d of holder %d, got %d", balance, i, actual)
+			}
+		}
+	}
+	if reads := atomic.LoadInt64(&disk.reads); reads != 0 {
+		t.Errorf("expected no disk reads, got %d", reads)
+	}
+
+	// Without caches every state reads from disk.
+	state, err = New(newRoot, NewDatabase(disk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.GetState(testToken, testSlot(1)).Big().Int64() != 1001 || atomic.LoadInt64(&disk.reads) == 0 {
+		t.Errorf("expected the uncached database to read from disk")
+	}
+}
+
+func benchmarkTokenTransfers(b *testing.B, holders int, newDatabase func(ethdb.Database) Database) {
+	badgerDatabase, _ := badgerwrapper.NewBadgerDatabase()
+	disk := &countingDatabase{Database: badgerDatabase}
+	root := newTokenState(b, NewDatabase(disk), holders)
+	shared := newDatabase(disk)
+	atomic.StoreInt64(&disk.reads, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		db := shared
+		if db == nil {
+			db = NewDatabase(disk)
+		}
+		state, err := New(root, db)
+		if err != nil {
+			b.Fatal(err)
+		}
+		transfer(state, i%holders, (i*7+1)%holders)
+		root = commitState(b, state)
+	}
+	b.ReportMetric(float64(atomic.LoadInt64(&disk.reads))/float64(b.N), "reads/op")
+}
+
+// BenchmarkTokenTransfers - Transfers on Badger with a fresh database per transfer, as every execution had before,
+// against one shared database
+func BenchmarkTokenTransfers(b *testing.B) {
+	for _, holders := range []int{100, 10000} {
+		b.Run(fmt.Sprintf("cold/holders=%d", holders), func(b *testing.B) {
+			benchmarkTokenTransfers(b, holders, func(ethdb.Database) Database { return nil })
+		})
+		b.Run(fmt.Sprintf("shared/holders=%d", holders), func(b *testing.B) {
+			benchmarkTokenTransfers(b, holders, func(disk ethdb.Database) Database {
+				return NewDatabaseWithCache(disk, 100000, 1000)
+			})
+		})
+	}
+}
@@ -132,6 +132,13 @@ type (
 		prev      bool
 		prevDirty bool
 	}
+	accessListAddAccountChange struct {
+		address *crypto.AddressBytes
+	}
+	accessListAddSlotChange struct {
+		address *crypto.AddressBytes
+		slot    *crypto.HashBytes
+	}
 )
 
 func (ch createObjectChange) revert(s *StateDB) {
@@ -233,3 +240,28 @@ func (ch addPreimageChange) revert(s *StateDB) {
 func (ch addPreimageChange) dirtied() *crypto.AddressBytes {
 	return nil
 }
+
+func (ch accessListAddAccountChange) revert(s *StateDB) {
+	/*
+		One important invariant here, is that whenever a (addr, slot) is added, if the
+		addr is not already present, the add causes two journal entries:
+		- one for the address,
+		- one for the (address,slot)
+		Therefore, when unrolling the change, we can always blindly delete the
+		(addr) at this point, since no storage adds can remain when come upon
+		a single (addr) change.
+	*/
+	s.accessList.DeleteAddress(*ch.address)
+}
+
+func (ch accessListAddAccountChange) dirtied() *crypto.AddressBytes {
+	return nil
+}
+
+func (ch accessListAddSlotChange) revert(s *StateDB) {
+	s.accessList.DeleteSlot(*ch.address, *ch.slot)
+}
+
+func (ch accessListAddSlotChange) dirtied() *crypto.AddressBytes {
+	return nil
+}
@@ -98,6 +98,7 @@ type stateObject struct {
 
 	cachedStorage Storage // Storage entry cache to avoid duplicate reads
 	dirtyStorage  Storage // Storage entries that need to be flushed to disk
+	originStorage Storage // Storage entries as of the start of the transaction
 
 	// Cache flags.
 	// When an object is marked suicided it will be delete from the trie
@@ -140,6 +141,7 @@ func newStateObject(db *StateDB, address crypto.AddressBytes, data types.Account
 		account:       data,
 		cachedStorage: make(Storage),
 		dirtyStorage:  make(Storage),
+		originStorage: make(Storage),
 	}
 
 	return result
@@ -215,6 +217,27 @@ func (s *stateObject) GetState(db Database, key crypto.HashBytes) crypto.HashByt
 		return value
 	}
 	// Load from DB in case it is missing.
+	value = s.loadState(db, key)
+	if (value != crypto.HashBytes{}) {
+		s.cachedStorage[key] = value
+	}
+	return value
+}
+
+// GetCommittedState returns the value a slot held before the current transaction.
+func (s *stateObject) GetCommittedState(db Database, key crypto.HashBytes) crypto.HashBytes {
+	value, exists := s.originStorage[key]
+	if exists {
+		return value
+	}
+	value = s.loadState(db, key)
+	s.originStorage[key] = value
+	return value
+}
+
+// loadState reads a slot from the storage trie, the trie is only written between transactions.
+func (s *stateObject) loadState(db Database, key crypto.HashBytes) crypto.HashBytes {
+	var value crypto.HashBytes
 	enc, err := s.getTrie(db).TryGet(key[:])
 	if err != nil {
 		s.setError(err)
@@ -227,9 +250,6 @@ func (s *stateObject) GetState(db Database, key crypto.HashBytes) crypto.HashByt
 		}
 		value.SetBytes(content)
 	}
-	if (value != crypto.HashBytes{}) {
-		s.cachedStorage[key] = value
-	}
 	return value
 }
 
@@ -265,6 +285,7 @@ func (s *stateObject) updateTrie(db Database) Trie {
 	tr := s.getTrie(db)
 	for key, value := range s.dirtyStorage {
 		delete(s.dirtyStorage, key)
+		s.originStorage[key] = value
 		if (value == crypto.HashBytes{}) {
 			s.setError(tr.TryDelete(key[:]))
 			continue
@@ -377,6 +398,7 @@ func (s *stateObject) deepCopy(db *StateDB) *stateObject {
 	stateObject.code = s.code
 	stateObject.dirtyStorage = s.dirtyStorage.Copy()
 	stateObject.cachedStorage = s.dirtyStorage.Copy()
+	stateObject.originStorage = s.originStorage.Copy()
 	stateObject.suicided = s.suicided
 	stateObject.dirtyCode = s.dirtyCode
 	stateObject.deleted = s.deleted
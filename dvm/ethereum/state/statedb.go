@@ -19,6 +19,7 @@ package state
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"sort"
@@ -79,6 +80,13 @@ type StateDB struct {
 
 	preimages map[crypto.HashBytes][]byte
 
+	// DISPATCH - Balances of the Dispatch accounts the state transition started from, by address. A nil balance means
+	// there was no account.
+	dispatchBalances map[string]*big.Int
+
+	// Per-transaction access list
+	accessList *accessList
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -101,6 +109,8 @@ func New(root crypto.HashBytes, db Database) (*StateDB, error) {
 		stateObjectsDirty: make(map[crypto.AddressBytes]struct{}),
 		logs:              make(map[crypto.HashBytes][]*types.Log),
 		preimages:         make(map[crypto.HashBytes][]byte),
+		dispatchBalances:  make(map[string]*big.Int),
+		accessList:        newAccessList(),
 		journal:           newJournal(),
 	}, nil
 }
@@ -130,6 +140,7 @@ func (self *StateDB) Reset(root crypto.HashBytes) error {
 	self.trie = tr
 	self.StateObjects = make(map[crypto.AddressBytes]*stateObject)
 	self.stateObjectsDirty = make(map[crypto.AddressBytes]struct{})
+	self.dispatchBalances = make(map[string]*big.Int)
 	self.thash = crypto.HashBytes{}
 	self.bhash = crypto.HashBytes{}
 	self.txIndex = 0
@@ -191,6 +202,18 @@ func (self *StateDB) AddRefund(gas uint64) {
 	self.refund += gas
 }
 
+// SubRefund removes gas from the refund counter.
+// This method will panic if the refund counter goes below zero
+func (self *StateDB) SubRefund(gas uint64) {
+	utils.Debug(fmt.Sprintf("StateDB-SubRefund: %v", gas))
+
+	self.journal.append(refundChange{prev: self.refund})
+	if gas > self.refund {
+		panic(fmt.Sprintf("Refund counter below zero (gas: %d > refund: %d)", gas, self.refund))
+	}
+	self.refund -= gas
+}
+
 // Exist reports whether the given account address exists in the state.
 // Notably this also returns true for suicided accounts.
 func (self *StateDB) Exist(addr crypto.AddressBytes) bool {
@@ -286,6 +309,18 @@ func (self *StateDB) GetState(addr crypto.AddressBytes, bhash crypto.HashBytes)
 	return crypto.HashBytes{}
 }
 
+// GetCommittedState retrieves a value from the given account's committed storage trie,
+// that is the value the slot held before the current transaction.
+func (self *StateDB) GetCommittedState(addr crypto.AddressBytes, bhash crypto.HashBytes) crypto.HashBytes {
+	utils.Debug(fmt.Sprintf("StateDB-GetCommittedState: %s", crypto.EncodeNo0x(addr[:])))
+
+	stateObject := self.getStateObject(addr)
+	if stateObject != nil {
+		return stateObject.GetCommittedState(self.db, bhash)
+	}
+	return crypto.HashBytes{}
+}
+
 // Database retrieves the low level database supporting the lower level trie ops.
 func (self *StateDB) Database() Database {
 	return self.db
@@ -304,6 +339,36 @@ func (self *StateDB) StorageTrie(addr crypto.AddressBytes) Trie {
 	return cpy.updateTrie(self.db)
 }
 
+// GetStorageRoot returns the root of the committed storage trie of an account.
+func (self *StateDB) GetStorageRoot(addr crypto.AddressBytes) crypto.HashBytes {
+	stateObject := self.getStateObject(addr)
+	if stateObject == nil {
+		return crypto.HashBytes{}
+	}
+	return stateObject.account.Root
+}
+
+// GetStorageProof returns the Merkle proof for a slot of the storage trie of an account,
+// the encoded nodes are ordered from the root down to the slot.
+func (self *StateDB) GetStorageProof(addr crypto.AddressBytes, key crypto.HashBytes) ([][]byte, error) {
+	var proof proofList
+	storageTrie := self.StorageTrie(addr)
+	if storageTrie == nil {
+		return proof, errors.New("storage trie for requested address does not exist")
+	}
+	hash := crypto.NewHash(key[:])
+	err := storageTrie.Prove(hash[:], 0, &proof)
+	return proof, err
+}
+
+// proofList collects the nodes of a proof in the order they are written.
+type proofList [][]byte
+
+func (n *proofList) Put(key []byte, value []byte) error {
+	*n = append(*n, value)
+	return nil
+}
+
 func (self *StateDB) HasSuicided(addr crypto.AddressBytes) bool {
 	utils.Debug(fmt.Sprintf("StateDB-HasSuicided: %s", crypto.EncodeNo0x(addr[:])))
 
@@ -453,6 +518,10 @@ func (self *StateDB) getStateObject(addr crypto.AddressBytes) (stateObject *stat
 		log.Error("Failed to decode state object", "addr", addr, "err", err)
 		return nil
 	}
+	// DISPATCH - The Dispatch account is the source of truth for balances
+	if balance := self.dispatchBalance(hex.EncodeToString(addr[:])); balance != nil {
+		data.Balance = new(big.Int).Set(balance)
+	}
 	// Insert into the live set.
 	obj := newStateObject(self, addr, data)
 	self.setStateObject(obj)
@@ -506,6 +575,12 @@ func (self *StateDB) createObject(addr crypto.AddressBytes) (newobj, prev *state
 		if accountFromBadgerErr == nil {
 			account = *accountFromBadger
 		}
+		// The balance may come from the balances the state transition started from rather than Badger
+		if balance := self.dispatchBalance(addressAsString); balance != nil {
+			account.Balance = new(big.Int).Set(balance)
+		} else {
+			account.Balance = common.Big0
+		}
 	}
 
 	newobj = newStateObject(self, addr, account)
@@ -549,7 +624,9 @@ func (db *StateDB) ForEachStorage(addr crypto.AddressBytes, cb func(key, value c
 
 	// When iterating over the storage check the cache first
 	for h, value := range so.cachedStorage {
-		cb(h, value)
+		if !cb(h, value) {
+			return
+		}
 	}
 
 	it := trie.NewIterator(so.getTrie(db.db).NodeIterator(nil))
@@ -557,11 +634,67 @@ func (db *StateDB) ForEachStorage(addr crypto.AddressBytes, cb func(key, value c
 		// ignore cached values
 		key := crypto.BytesToHash(db.trie.GetKey(it.Key))
 		if _, ok := so.cachedStorage[key]; !ok {
-			cb(key, crypto.BytesToHash(it.Value))
+			// The trie holds the RLP encoding of the value
+			_, content, _, err := rlp.Split(it.Value)
+			if err != nil {
+				db.setError(err)
+				return
+			}
+			if !cb(key, crypto.BytesToHash(content)) {
+				return
+			}
 		}
 	}
 }
 
+// BalanceChanges - Returns the balances, by address, that differ from the balances of the Dispatch accounts
+func (self *StateDB) BalanceChanges() map[string]*big.Int {
+	balances := make(map[string]*big.Int)
+	for addr, stateObject := range self.StateObjects {
+		// Emptied and suicided objects are deleted with a zero balance which is still their final balance
+		address := hex.EncodeToString(addr[:])
+		balance := self.dispatchBalance(address)
+		if balance == nil {
+			balance = common.Big0
+		}
+		if stateObject.account.Balance.Cmp(balance) != 0 {
+			balances[address] = new(big.Int).Set(stateObject.account.Balance)
+		}
+	}
+	return balances
+}
+
+// DispatchBalances - Returns the balances of the Dispatch accounts the state transition started from, by address
+func (self *StateDB) DispatchBalances() map[string]*big.Int {
+	balances := make(map[string]*big.Int, len(self.dispatchBalances))
+	for address, balance := range self.dispatchBalances {
+		balances[address] = balance
+	}
+	return balances
+}
+
+// SetDispatchBalances - Starts the state transition from the given balances instead of the Dispatch accounts, a trace
+// replays a transaction against the balances it originally ran against
+func (self *StateDB) SetDispatchBalances(balances map[string]*big.Int) {
+	for address, balance := range balances {
+		self.dispatchBalances[address] = balance
+	}
+}
+
+// dispatchBalance - Returns the balance of a Dispatch account, reading it from Badger only once so the balance stays
+// the one the state transition started from
+func (self *StateDB) dispatchBalance(address string) *big.Int {
+	if balance, ok := self.dispatchBalances[address]; ok {
+		return balance
+	}
+	var balance *big.Int
+	if account := getAccountByAddressFromBadger(address); account != nil && account.Balance != nil {
+		balance = new(big.Int).Set(account.Balance)
+	}
+	self.dispatchBalances[address] = balance
+	return balance
+}
+
 // Copy creates a deep, independent copy of the state.
 // Snapshots of the copied state cannot be applied to the copy.
 func (self *StateDB) Copy() *StateDB {
@@ -580,6 +713,8 @@ func (self *StateDB) Copy() *StateDB {
 		logs:              make(map[crypto.HashBytes][]*types.Log, len(self.logs)),
 		logSize:           self.logSize,
 		preimages:         make(map[crypto.HashBytes][]byte),
+		dispatchBalances:  self.DispatchBalances(),
+		accessList:        self.accessList.Copy(),
 		journal:           newJournal(),
 	}
 	// Copy the dirty states, logs, and preimages
@@ -779,3 +914,55 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (root crypto.HashBytes, err er
 	log.Debug("Trie cache stats after commit", "misses", trie.CacheMisses(), "unloads", trie.CacheUnloads())
 	return root, err
 }
+
+// PrepareAccessList handles the preparatory steps for executing a state transition with
+// regards to EIP-2929: the sender, the destination and the precompiles are warm from the start.
+//
+// This method should only be called if Berlin is applicable.
+func (self *StateDB) PrepareAccessList(sender crypto.AddressBytes, dst *crypto.AddressBytes, precompiles []crypto.AddressBytes) {
+	// Clear out any leftover from previous executions
+	self.accessList = newAccessList()
+
+	self.AddAddressToAccessList(sender)
+	if dst != nil {
+		self.AddAddressToAccessList(*dst)
+	}
+	for _, addr := range precompiles {
+		self.AddAddressToAccessList(addr)
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (self *StateDB) AddAddressToAccessList(addr crypto.AddressBytes) {
+	if self.accessList.AddAddress(addr) {
+		self.journal.append(accessListAddAccountChange{&addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (self *StateDB) AddSlotToAccessList(addr crypto.AddressBytes, slot crypto.HashBytes) {
+	addrMod, slotMod := self.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		self.journal.append(accessListAddAccountChange{&addr})
+	}
+	if slotMod {
+		self.journal.append(accessListAddSlotChange{
+			address: &addr,
+			slot:    &slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (self *StateDB) AddressInAccessList(addr crypto.AddressBytes) bool {
+	return self.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (self *StateDB) SlotInAccessList(addr crypto.AddressBytes, slot crypto.HashBytes) (addressPresent bool, slotPresent bool) {
+	return self.accessList.Contains(addr, slot)
+}
@@ -0,0 +1,80 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package state
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/services"
+	"github.com/dispatchlabs/disgo/commons/types"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/ethdb"
+)
+
+// persistAccount - Saves a Dispatch account, the source of truth for balances
+func persistAccount(t *testing.T, address string, balance int64) {
+	txn := services.NewTxn(true)
+	defer txn.Discard()
+	account := &types.Account{Address: address, Balance: big.NewInt(balance), Created: time.Now(), Updated: time.Now()}
+	err := account.Persist(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = txn.Commit(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestBalanceChanges
+func TestBalanceChanges(t *testing.T) {
+	paid := "9b3bd2fcf5b9bfe5a0a6f1dc1ca6c8ae4ebf4c01"
+	read := "6a9a6e45ea0d7e0bcb5ac5b4b0e5e8c4c73b7d02"
+	created := "0e4c3b56bd1a0f2f8e4e27e9c3bcf8be1d77f103"
+	emptied := "47a0c4d7e6ed2b8f65f5f4c5bde54bd6a8e9a404"
+	persistAccount(t, paid, 100)
+	persistAccount(t, read, 50)
+	persistAccount(t, emptied, 30)
+
+	state, err := New(crypto.HashBytes{}, NewDatabase(memDatabase{ethdb.NewMemDatabase()}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.AddBalance(crypto.GetAddressBytes(paid), big.NewInt(20))
+	state.AddBalance(crypto.GetAddressBytes(read), big.NewInt(0))
+	if balance := state.GetBalance(crypto.GetAddressBytes(read)); balance.Int64() != 50 {
+		t.Fatalf("state reading balance %d, expected the account's 50", balance.Int64())
+	}
+	state.AddBalance(crypto.GetAddressBytes(created), big.NewInt(5))
+	state.SubBalance(crypto.GetAddressBytes(emptied), big.NewInt(30))
+
+	balances := state.BalanceChanges()
+	expected := map[string]int64{paid: 120, created: 5, emptied: 0}
+	if len(balances) != len(expected) {
+		t.Errorf("BalanceChanges returning %d balances, expected %d: %v", len(balances), len(expected), balances)
+	}
+	for address, balance := range expected {
+		if balances[address] == nil || balances[address].Int64() != balance {
+			t.Errorf("BalanceChanges returning %v for %s, expected %d", balances[address], address, balance)
+		}
+	}
+	if _, ok := balances[read]; ok {
+		t.Errorf("BalanceChanges returning the unchanged balance of %s", read)
+	}
+}
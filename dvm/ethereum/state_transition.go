@@ -78,7 +78,7 @@ type Message interface {
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation, homestead bool) (uint64, error) {
+func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, shanghai bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation && homestead {
@@ -96,16 +96,28 @@ func IntrinsicGas(data []byte, contractCreation, homestead bool) (uint64, error)
 			}
 		}
 		// Make sure we don't exceed uint64 for all data combinations
-		if (math.MaxUint64-gas)/params.TxDataNonZeroGas < nz {
+		nonZeroGas := params.TxDataNonZeroGas
+		if istanbul {
+			nonZeroGas = params.TxDataNonZeroGasEIP2028
+		}
+		if (math.MaxUint64-gas)/nonZeroGas < nz {
 			return 0, vm.ErrOutOfGas
 		}
-		gas += nz * params.TxDataNonZeroGas
+		gas += nz * nonZeroGas
 
 		z := uint64(len(data)) - nz
 		if (math.MaxUint64-gas)/params.TxDataZeroGas < z {
 			return 0, vm.ErrOutOfGas
 		}
 		gas += z * params.TxDataZeroGas
+
+		if contractCreation && shanghai {
+			lenWords := (uint64(len(data)) + 31) / 32
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < lenWords {
+				return 0, vm.ErrOutOfGas
+			}
+			gas += lenWords * params.InitCodeWordGas
+		}
 	}
 	return gas, nil
 }
@@ -188,7 +200,7 @@ func (st *StateTransition) TransitionDb() (ret []byte, contractAddress crypto.Ad
 	}
 	msg := st.msg
 	sender := vm.AccountRef(msg.From())
-	homestead := st.evm.ChainConfig().IsHomestead(st.evm.BlockNumber)
+	rules := st.evm.ChainConfig().Rules(st.evm.BlockNumber)
 	contractCreation := msg.To() == nil
 
 	utils.Debug("AddrRaw   ", "From", ": ", fmt.Sprintf("%v", msg.From().Bytes()))
@@ -203,13 +215,25 @@ func (st *StateTransition) TransitionDb() (ret []byte, contractAddress crypto.Ad
 	}
 
 	// Pay intrinsic gas
-	gas, err := IntrinsicGas(st.data, contractCreation, homestead)
+	gas, err := IntrinsicGas(st.data, contractCreation, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai)
 	if err != nil {
 		return nil, crypto.AddressBytes{}, 0, false, err
 	}
 	if err = st.useGas(gas); err != nil {
 		return nil, crypto.AddressBytes{}, 0, false, err
 	}
+	// Check whether the init code size has been exceeded.
+	if rules.IsShanghai && contractCreation && len(st.data) > params.MaxInitCodeSize {
+		return nil, crypto.AddressBytes{}, 0, false, vm.ErrMaxInitCodeSizeExceeded
+	}
+	// Warm the sender, the recipient and the precompiles (EIP-2929), and the
+	// coinbase too from shanghai on (EIP-3651).
+	if rules.IsBerlin {
+		st.state.PrepareAccessList(msg.From(), msg.To(), append(vm.ActivePrecompiles(rules), vm.ActiveDispatchPrecompiles(st.evm)...))
+	}
+	if rules.IsShanghai {
+		st.state.AddAddressToAccessList(st.evm.Coinbase)
+	}
 
 	var (
 		evm = st.evm
@@ -245,15 +269,21 @@ func (st *StateTransition) TransitionDb() (ret []byte, contractAddress crypto.Ad
 		// 	vmerr = nil
 		// }
 	}
-	st.refundGas()
+	if rules.IsLondon {
+		// After EIP-3529: refunds are capped to gasUsed / 5
+		st.refundGas(params.RefundQuotientEIP3529)
+	} else {
+		// Before EIP-3529: refunds were capped to gasUsed / 2
+		st.refundGas(params.RefundQuotient)
+	}
 	st.state.AddBalance(st.evm.Coinbase, new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice))
 
 	return ret, contractAddress, st.gasUsed(), vmerr != nil, err
 }
 
-func (st *StateTransition) refundGas() {
-	// Apply refund counter, capped to half of the used gas.
-	refund := st.gasUsed() / 2
+func (st *StateTransition) refundGas(refundQuotient uint64) {
+	// Apply refund counter, capped to a refund quotient
+	refund := st.gasUsed() / refundQuotient
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
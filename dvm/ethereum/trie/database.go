@@ -27,6 +27,7 @@ import (
 	"github.com/dispatchlabs/disgo/dvm/ethereum/ethdb"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/log"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/rlp"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 // var (
@@ -63,6 +64,7 @@ type DatabaseReader interface {
 // periodically flush a couple tries to disk, garbage collecting the remainder.
 type Database struct {
 	diskdb ethdb.Database // Persistent storage for matured trie nodes
+	cleans *lru.Cache     // Encoded clean nodes by hash, nil when disabled
 
 	nodes  map[crypto.HashBytes]*cachedNode // Data and references relationships of a node
 	oldest crypto.HashBytes                 // Oldest tracked node, flush-list head
@@ -264,8 +266,19 @@ func expandNode(hash hashNode, n node, cachegen uint16) node {
 // NewDatabase creates a new trie database to store ephemeral trie content before
 // its written out to disk or garbage collected.
 func NewDatabase(diskdb ethdb.Database) *Database {
+	return NewDatabaseWithCache(diskdb, 0)
+}
+
+// NewDatabaseWithCache creates a new trie database that also keeps up to cleanNodes
+// nodes read from or written to disk in memory, zero disables the clean cache.
+func NewDatabaseWithCache(diskdb ethdb.Database, cleanNodes int) *Database {
+	var cleans *lru.Cache
+	if cleanNodes > 0 {
+		cleans, _ = lru.New(cleanNodes)
+	}
 	return &Database{
 		diskdb:    diskdb,
+		cleans:    cleans,
 		nodes:     map[crypto.HashBytes]*cachedNode{{}: {}},
 		preimages: make(map[crypto.HashBytes][]byte),
 	}
@@ -341,8 +354,7 @@ func (db *Database) node(hash crypto.HashBytes, cachegen uint16) node {
 	if node != nil {
 		return node.obj(hash, cachegen)
 	}
-	// Content unavailable in memory, attempt to retrieve from disk
-	enc, err := db.diskdb.Get(hash[:])
+	enc, err := db.cleanOrDisk(hash)
 	if err != nil || enc == nil {
 		return nil
 	}
@@ -360,8 +372,23 @@ func (db *Database) Node(hash crypto.HashBytes) ([]byte, error) {
 	if node != nil {
 		return node.rlp(), nil
 	}
+	return db.cleanOrDisk(hash)
+}
+
+// cleanOrDisk retrieves an encoded node that is not dirty from the clean cache,
+// or from disk caching it if it's found there.
+func (db *Database) cleanOrDisk(hash crypto.HashBytes) ([]byte, error) {
+	if db.cleans != nil {
+		if enc, ok := db.cleans.Get(hash); ok {
+			return enc.([]byte), nil
+		}
+	}
 	// Content unavailable in memory, attempt to retrieve from disk
-	return db.diskdb.Get(hash[:])
+	enc, err := db.diskdb.Get(hash[:])
+	if err == nil && enc != nil && db.cleans != nil {
+		db.cleans.Add(hash, enc)
+	}
+	return enc, err
 }
 
 // preimage retrieves a cached trie node pre-image from memory. If it cannot be
@@ -671,6 +698,40 @@ func (db *Database) Commit(node crypto.HashBytes, report bool) error {
 	return nil
 }
 
+// CommitTo writes all the preimages and the trie nodes reachable from node into
+// the batch without removing them from memory. It lets the trie be persisted in
+// the same transaction as other data, Uncache must only be called once that
+// transaction is durable so readers never miss a node.
+func (db *Database) CommitTo(node crypto.HashBytes, batch ethdb.Batch) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	for hash, preimage := range db.preimages {
+		if err := batch.Put(db.secureKey(hash[:]), preimage); err != nil {
+			log.Error("Failed to commit preimage from trie database", "err", err)
+			return err
+		}
+	}
+	if err := db.commit(node, batch); err != nil {
+		log.Error("Failed to commit trie from trie database", "err", err)
+		return err
+	}
+	return batch.Write()
+}
+
+// Uncache is the post-processing step of CommitTo, it drops the preimages and
+// moves the trie nodes reachable from node to the clean cache once the batch
+// they were written to is persisted.
+func (db *Database) Uncache(node crypto.HashBytes) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.preimages = make(map[crypto.HashBytes][]byte)
+	db.preimagesSize = 0
+
+	db.uncache(node)
+}
+
 // commit is the private locked version of Commit.
 func (db *Database) commit(hash crypto.HashBytes, batch ethdb.Batch) error {
 	// If the node does not exist, it's a previously committed node
@@ -718,10 +779,14 @@ func (db *Database) uncache(hash crypto.HashBytes) {
 		db.nodes[node.flushPrev].flushNext = node.flushNext
 		db.nodes[node.flushNext].flushPrev = node.flushPrev
 	}
-	// Uncache the node's subtries and remove the node itself too
+	// Uncache the node's subtries and remove the node itself too, moving it to
+	// the clean cache
 	for _, child := range node.childs() {
 		db.uncache(child)
 	}
+	if db.cleans != nil {
+		db.cleans.Add(hash, node.rlp())
+	}
 	delete(db.nodes, hash)
 	db.nodesSize -= common.StorageSize(common.HashLength + int(node.size))
 }
@@ -17,8 +17,10 @@
 package vm
 
 import (
+	"bytes"
 	"math/big"
 	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
 )
 
 // destinations stores one map per contract (keyed by hash of code).
@@ -87,3 +89,159 @@ func codeBitmap(code []byte) bitvec {
 	}
 	return bits
 }
+
+// solidityPreamble is the code Solidity starts a contract with, it stores the
+// free memory pointer.
+var solidityPreamble = []byte{byte(PUSH1), 0x80, byte(PUSH1), 0x40, byte(MSTORE)}
+
+// invalidOpCode is the designated invalid instruction compilers end code with.
+const invalidOpCode OpCode = 0xfe
+
+// Instruction is an opcode at a position in code.
+type Instruction struct {
+	PC uint64
+	Op OpCode
+}
+
+// CodeAnalysis is the result of AnalyseCode.
+type CodeAnalysis struct {
+	JumpDests    int           // JUMPDEST instructions in the code segments
+	Unsupported  []Instruction // reachable opcodes a later instruction set defines but the analysed one does not
+	InvalidJumps []Instruction // static jumps to a position that is not a JUMPDEST
+	Truncated    bool          // the code ends inside the data of a PUSH
+}
+
+// AnalyseCode walks the code segments of code and reports the instructions an
+// interpreter with cfg could not execute under the chain rules at block num.
+//
+// Only code that can run is checked: the start of the code, every JUMPDEST and
+// the code after a RETURN padded with STOP or INVALID, where compilers put the
+// runtime code a constructor returns, and unreachable code starting like the
+// code Solidity generates, where it embeds a child contract, up to the next
+// instruction that halts.
+// Data such as contract metadata is therefore not reported, and the metadata
+// of a child contract embedded in the code is skipped so a JUMPDEST byte in it
+// does not make the data after it reachable. A static jump is a
+// PUSH directly followed by a JUMP or JUMPI, its destination is relative to the
+// runtime code it is part of.
+func AnalyseCode(code []byte, cfg Config, config *params.ChainConfig, num *big.Int) *CodeAnalysis {
+	jumpTable := cfg.JumpTable
+	if !jumpTable[STOP].valid {
+		jumpTable = InstructionSet(config, num)
+	}
+
+	var (
+		analysis  = &CodeAnalysis{}
+		bits      = codeBitmap(code)
+		size      = uint64(len(code))
+		reachable = true
+		base      uint64 // start of the code static jumps are relative to
+		returned  bool   // whether the last instruction that ran was a RETURN, padding aside
+		pushed    bool   // whether the previous instruction pushed a destination
+		dest      uint64
+		metadata  = metadataRanges(code)
+	)
+	for pc := uint64(0); pc < size; {
+		for len(metadata) > 0 && metadata[0][1] <= pc {
+			metadata = metadata[1:]
+		}
+		if len(metadata) > 0 && metadata[0][0] <= pc {
+			// Not code, nor is what follows it until the next JUMPDEST.
+			pc = metadata[0][1]
+			reachable, returned, pushed = false, false, false
+			continue
+		}
+		op := OpCode(code[pc])
+		switch {
+		case op == JUMPDEST:
+			analysis.JumpDests++
+			reachable = true
+		case returned && op != STOP && op != invalidOpCode:
+			// Runtime code starts after the RETURN of the constructor.
+			base = pc
+			reachable = true
+		case !reachable && bytes.HasPrefix(code[pc:], solidityPreamble):
+			// So does the code of a child contract embedded to create it.
+			base = pc
+			reachable = true
+		}
+		if op != STOP && op != invalidOpCode {
+			returned = false
+		}
+		next := pc + 1
+		if op >= PUSH1 && op <= PUSH32 {
+			next += uint64(op - PUSH1 + 1)
+		}
+
+		if reachable {
+			operation := jumpTable[op]
+			switch {
+			case !operation.valid && ShanghaiInstructionSet[op].valid:
+				analysis.Unsupported = append(analysis.Unsupported, Instruction{PC: pc, Op: op})
+				reachable = false
+			case (op == JUMP || op == JUMPI) && pushed:
+				if target := base + dest; target < base || target >= size || OpCode(code[target]) != JUMPDEST || !bits.codeSegment(target) {
+					analysis.InvalidJumps = append(analysis.InvalidJumps, Instruction{PC: pc, Op: op})
+				}
+				reachable = op == JUMPI
+			case !operation.valid || operation.halts || operation.reverts || op == JUMP:
+				reachable = false
+				returned = op == RETURN
+			}
+		}
+
+		pushed = false
+		switch {
+		case op == PUSH0:
+			pushed, dest = true, 0
+		case op >= PUSH1 && op <= PUSH8 && next <= size:
+			pushed, dest = true, new(big.Int).SetBytes(code[pc+1:next]).Uint64()
+		}
+		if next > size {
+			analysis.Truncated = true
+		}
+		pc = next
+	}
+	return analysis
+}
+
+// metadataKeys are the keys the CBOR encoded metadata of Solidity starts with.
+var metadataKeys = []string{"bzzr0", "bzzr1", "ipfs", "solc", "experimental"}
+
+// maxMetadataSize bounds the length of the metadata searched for.
+const maxMetadataSize = 256
+
+// metadataRanges returns the start and end of every CBOR encoded metadata blob
+// Solidity appends to code, including the ones of child contracts a contract
+// embeds to create them. A blob is a map starting with one of metadataKeys and
+// followed by its length in two bytes.
+func metadataRanges(code []byte) [][2]uint64 {
+	ranges := make([][2]uint64, 0)
+	size := len(code)
+	for start := 0; start+2 < size; start++ {
+		if code[start] < 0xa1 || code[start] > 0xa5 || code[start+1] < 0x61 || code[start+1] > 0x77 {
+			continue
+		}
+		keyEnd := start + 2 + int(code[start+1]-0x60)
+		if keyEnd > size || !isMetadataKey(string(code[start+2:keyEnd])) {
+			continue
+		}
+		for end := keyEnd; end+2 <= size && end-start <= maxMetadataSize; end++ {
+			if int(code[end])<<8|int(code[end+1]) == end-start {
+				ranges = append(ranges, [2]uint64{uint64(start), uint64(end + 2)})
+				start = end + 1
+				break
+			}
+		}
+	}
+	return ranges
+}
+
+func isMetadataKey(key string) bool {
+	for _, metadataKey := range metadataKeys {
+		if key == metadataKey {
+			return true
+		}
+	}
+	return false
+}
@@ -16,7 +16,13 @@
 
 package vm
 
-import "testing"
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
+)
 
 func TestJumpDestAnalysis(t *testing.T) {
 	tests := []struct {
@@ -51,3 +57,92 @@ func TestJumpDestAnalysis(t *testing.T) {
 	}
 
 }
+
+// testMetadata is the metadata solc 0.4 appends to code, with data in its swarm hash.
+func testMetadata(data ...byte) []byte {
+	hash := make([]byte, 32)
+	copy(hash, data)
+	metadata := append([]byte{0xa1, 0x65, 'b', 'z', 'z', 'r', '0', 0x58, 0x20}, hash...)
+	return append(metadata, 0x00, 0x29)
+}
+
+// withChild is code that halts followed by an embedded child contract, a
+// JUMPDEST and STOP, and the metadata of the child with data in its hash.
+func withChild(data ...byte) []byte {
+	return append([]byte{byte(STOP), byte(JUMPDEST), byte(STOP)}, testMetadata(data...)...)
+}
+
+func TestMetadataRanges(t *testing.T) {
+	metadata := testMetadata(byte(JUMPDEST))
+	tests := []struct {
+		code     []byte
+		expected [][2]uint64
+	}{
+		{metadata, [][2]uint64{{0, 43}}},
+		{withChild(), [][2]uint64{{3, 46}}},
+		{append(append([]byte{byte(STOP)}, metadata...), metadata...), [][2]uint64{{1, 44}, {44, 87}}},
+		// A LOG1 that is not followed by a metadata key, and metadata with the wrong length
+		{[]byte{byte(LOG1), byte(PUSH1), 0x00, 0x00, 0x03}, [][2]uint64{}},
+		{metadata[:len(metadata)-1], [][2]uint64{}},
+	}
+	for i, test := range tests {
+		if ranges := metadataRanges(test.code); !reflect.DeepEqual(ranges, test.expected) {
+			t.Errorf("test %d: expected metadata at %v, got %v", i, test.expected, ranges)
+		}
+	}
+}
+
+func TestAnalyseCode(t *testing.T) {
+	tests := []struct {
+		code        []byte
+		jumpTable   [256]operation
+		unsupported []uint64
+		invalid     []uint64
+		jumpDests   int
+		truncated   bool
+	}{
+		// PUSH0 is only supported from shanghai on
+		{[]byte{byte(PUSH0), byte(STOP)}, ConstantinopleInstructionSet, []uint64{0}, nil, 0, false},
+		{[]byte{byte(PUSH0), byte(STOP)}, ShanghaiInstructionSet, nil, nil, 0, false},
+		// Data after a halt is not code
+		{[]byte{byte(STOP), byte(PUSH0)}, ConstantinopleInstructionSet, nil, nil, 0, false},
+		{[]byte{byte(STOP), byte(JUMPDEST), byte(PUSH0)}, ConstantinopleInstructionSet, []uint64{2}, nil, 1, false},
+		// Static jumps
+		{[]byte{byte(PUSH1), 0x03, byte(JUMP), byte(JUMPDEST), byte(STOP)}, ConstantinopleInstructionSet, nil, nil, 1, false},
+		{[]byte{byte(PUSH1), 0x04, byte(JUMPI), byte(JUMPDEST), byte(STOP)}, ConstantinopleInstructionSet, nil, []uint64{2}, 1, false},
+		{[]byte{byte(PUSH1), 0x01, byte(JUMP), byte(JUMPDEST)}, ConstantinopleInstructionSet, nil, []uint64{2}, 1, false},
+		// Runtime code after the RETURN of a constructor jumps relative to its start
+		{[]byte{byte(RETURN), 0xfe, byte(PUSH1), 0x03, byte(JUMP), byte(JUMPDEST), byte(PUSH0)}, ConstantinopleInstructionSet, []uint64{6}, nil, 1, false},
+		{[]byte{byte(RETURN), byte(STOP), byte(PUSH1), 0x03, byte(JUMP), byte(JUMPDEST), byte(STOP)}, ConstantinopleInstructionSet, nil, nil, 1, false},
+		{[]byte{byte(PUSH2), 0x01}, ConstantinopleInstructionSet, nil, nil, 0, true},
+		// An embedded child contract jumps relative to its start
+		{[]byte{byte(STOP), byte(PUSH1), 0x80, byte(PUSH1), 0x40, byte(MSTORE), byte(PUSH1), 0x08, byte(JUMP), byte(JUMPDEST), byte(PUSH0)}, ConstantinopleInstructionSet, []uint64{10}, nil, 1, false},
+		{[]byte{byte(STOP), byte(PUSH1), 0x80, byte(PUSH1), 0x40, byte(MSTORE), byte(PUSH1), 0x09, byte(JUMP), byte(JUMPDEST), byte(STOP)}, ConstantinopleInstructionSet, nil, []uint64{8}, 1, false},
+		// The metadata of an embedded child contract is not code, even after a JUMPDEST byte in it
+		{withChild(byte(JUMPDEST), byte(PUSH0)), ConstantinopleInstructionSet, nil, nil, 1, false},
+		{append(withChild(byte(JUMPDEST), byte(PUSH0)), byte(PUSH0)), ConstantinopleInstructionSet, nil, nil, 1, false},
+		{append(withChild(byte(JUMPDEST), byte(PUSH0)), byte(JUMPDEST), byte(PUSH0)), ConstantinopleInstructionSet, []uint64{47}, nil, 2, false},
+	}
+	for i, test := range tests {
+		analysis := AnalyseCode(test.code, Config{JumpTable: test.jumpTable}, params.TestChainConfig, big.NewInt(0))
+		if len(analysis.Unsupported) != len(test.unsupported) {
+			t.Fatalf("test %d: expected unsupported %v, got %v", i, test.unsupported, analysis.Unsupported)
+		}
+		for j, pc := range test.unsupported {
+			if analysis.Unsupported[j].PC != pc {
+				t.Fatalf("test %d: expected unsupported %v, got %v", i, test.unsupported, analysis.Unsupported)
+			}
+		}
+		if len(analysis.InvalidJumps) != len(test.invalid) {
+			t.Fatalf("test %d: expected invalid jumps %v, got %v", i, test.invalid, analysis.InvalidJumps)
+		}
+		for j, pc := range test.invalid {
+			if analysis.InvalidJumps[j].PC != pc {
+				t.Fatalf("test %d: expected invalid jumps %v, got %v", i, test.invalid, analysis.InvalidJumps)
+			}
+		}
+		if analysis.JumpDests != test.jumpDests || analysis.Truncated != test.truncated {
+			t.Fatalf("test %d: expected %d jumpdests and truncated %v, got %d and %v", i, test.jumpDests, test.truncated, analysis.JumpDests, analysis.Truncated)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common"
+)
+
+// CallFrame is one call, create or delegated call in a call tree trace.
+type CallFrame struct {
+	Type      string       `json:"type"`
+	From      string       `json:"from"`
+	To        string       `json:"to,omitempty"`
+	Value     string       `json:"value,omitempty"`
+	Input     string       `json:"input,omitempty"`
+	Output    string       `json:"output,omitempty"`
+	HertzUsed uint64       `json:"hertzUsed"`
+	Error     string       `json:"error,omitempty"`
+	Calls     []*CallFrame `json:"calls,omitempty"`
+
+	gasIn uint64
+}
+
+// CallTracer is an EVM tracer that records the tree of calls a transaction makes
+// with the hertz used and the revert or error of every frame.
+type CallTracer struct {
+	root   *CallFrame
+	frames []*CallFrame
+}
+
+// NewCallTracer returns a new call tree tracer
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements the Tracer interface, it opens the outermost frame.
+func (t *CallTracer) CaptureStart(from crypto.AddressBytes, to crypto.AddressBytes, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.root = &CallFrame{
+		Type:  CALL.String(),
+		From:  hex.EncodeToString(from[:]),
+		To:    hex.EncodeToString(to[:]),
+		Input: hex.EncodeToString(input),
+		gasIn: gas,
+	}
+	if create {
+		t.root.Type = CREATE.String()
+	}
+	if value != nil && value.Sign() != 0 {
+		t.root.Value = value.String()
+	}
+	t.frames = []*CallFrame{t.root}
+	return nil
+}
+
+// CaptureState implements the Tracer interface. A step at a lower depth than the
+// innermost open frame means that frame returned, a call or create opens a new one.
+func (t *CallTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	for len(t.frames) > depth && len(t.frames) > 1 {
+		frame := t.frames[len(t.frames)-1]
+		t.frames = t.frames[:len(t.frames)-1]
+		if frame.gasIn > gas {
+			frame.HertzUsed = frame.gasIn - gas
+		}
+
+		// The caller's stack now holds the result of the call, zero when it failed
+		result := stack.peek()
+		if frame.Type == CREATE.String() || frame.Type == CREATE2.String() {
+			if result.Sign() != 0 {
+				address := common.BigToAddress(result)
+				frame.To = hex.EncodeToString(address[:])
+			}
+		}
+		if result.Sign() == 0 && frame.Error == "" {
+			frame.Error = "failed"
+		}
+	}
+	if op == REVERT && len(t.frames) == depth {
+		t.frames[len(t.frames)-1].Error = ErrExecutionReverted.Error()
+	}
+
+	var frame *CallFrame
+	switch op {
+	case CALL, CALLCODE:
+		frame = &CallFrame{To: toAddress(stack.Back(1)), Input: toInput(memory, stack.Back(3), stack.Back(4))}
+		if value := stack.Back(2); value.Sign() != 0 {
+			frame.Value = value.String()
+		}
+	case DELEGATECALL, STATICCALL:
+		frame = &CallFrame{To: toAddress(stack.Back(1)), Input: toInput(memory, stack.Back(2), stack.Back(3))}
+	case CREATE, CREATE2:
+		frame = &CallFrame{Input: toInput(memory, stack.Back(1), stack.Back(2))}
+		if value := stack.Back(0); value.Sign() != 0 {
+			frame.Value = value.String()
+		}
+	default:
+		return nil
+	}
+	address := contract.Address()
+	frame.Type = op.String()
+	frame.From = hex.EncodeToString(address[:])
+	frame.gasIn = gas
+	parent := t.frames[len(t.frames)-1]
+	parent.Calls = append(parent.Calls, frame)
+	t.frames = append(t.frames, frame)
+	return nil
+}
+
+// CaptureFault implements the Tracer interface, the error is recorded on the innermost frame.
+func (t *CallTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if t.root == nil || err == nil {
+		return nil
+	}
+	frame := t.frames[len(t.frames)-1]
+	if frame.Error == "" {
+		frame.Error = err.Error()
+	}
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface, it closes the outermost frame.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if t.root == nil {
+		return nil
+	}
+	t.root.Output = hex.EncodeToString(output)
+	t.root.HertzUsed = gasUsed
+	if err != nil {
+		t.root.Error = err.Error()
+	}
+	t.frames = t.frames[:1]
+	return nil
+}
+
+// Result returns the outermost frame of the call tree, nil if nothing ran.
+func (t *CallTracer) Result() *CallFrame {
+	return t.root
+}
+
+func toAddress(value *big.Int) string {
+	address := common.BigToAddress(value)
+	return hex.EncodeToString(address[:])
+}
+
+func toInput(memory *Memory, offset, size *big.Int) string {
+	if !offset.IsUint64() || !size.IsUint64() || offset.Uint64()+size.Uint64() > uint64(memory.Len()) {
+		return ""
+	}
+	return hex.EncodeToString(memory.Get(offset.Int64(), size.Int64()))
+}
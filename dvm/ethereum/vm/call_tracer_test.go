@@ -0,0 +1,71 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common"
+)
+
+func TestCallTracerRevertedCall(t *testing.T) {
+	var (
+		caller = common.BytesToAddress([]byte{0x01})
+		callee = common.BytesToAddress([]byte{0x02})
+		target = common.BytesToAddress([]byte{0x03})
+		tracer = NewCallTracer()
+		memory = NewMemory()
+	)
+	memory.Resize(32)
+	memory.Set(0, 4, []byte{0xde, 0xad, 0xbe, 0xef})
+	contract := NewContract(AccountRef(caller), AccountRef(callee), new(big.Int), 1000)
+
+	tracer.CaptureStart(caller, callee, false, nil, 1000, new(big.Int))
+
+	// CALL pops gas, address, value, input offset, input size, output offset and output size
+	stack := newstack()
+	stack.pushN(big.NewInt(0), big.NewInt(0), big.NewInt(4), big.NewInt(0), big.NewInt(5), target.Big(), big.NewInt(500))
+	tracer.CaptureState(nil, 0, CALL, 900, 600, memory, stack, contract, 1, nil)
+	tracer.CaptureState(nil, 0, PUSH1, 500, 3, NewMemory(), newstack(), contract, 2, nil)
+	tracer.CaptureState(nil, 2, REVERT, 497, 0, NewMemory(), newstack(), contract, 2, nil)
+
+	// Back in the caller with a failed call on the stack
+	stack = newstack()
+	stack.push(big.NewInt(0))
+	tracer.CaptureState(nil, 1, POP, 700, 2, memory, stack, contract, 1, nil)
+	tracer.CaptureEnd([]byte{0x01}, 400, 0, nil)
+
+	root := tracer.Result()
+	if root.HertzUsed != 400 || root.Output != "01" || root.Error != "" {
+		t.Fatalf("invalid root frame %+v", root)
+	}
+	if len(root.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(root.Calls))
+	}
+	call := root.Calls[0]
+	if call.Type != "CALL" || call.To != hex.EncodeToString(target[:]) || call.Value != "5" || call.Input != "deadbeef" {
+		t.Errorf("invalid call frame %+v", call)
+	}
+	if call.HertzUsed != 200 {
+		t.Errorf("expected 200 hertz used, got %d", call.HertzUsed)
+	}
+	if call.Error != ErrExecutionReverted.Error() {
+		t.Errorf("expected a reverted call, got %q", call.Error)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/crypto/secp256k1"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common/math"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
+)
+
+// DispatchReader gives the Dispatch precompiled contracts read access to the
+// platform state. Every delegate must return the same values for the same
+// transaction, as the results end up in consensus state.
+type DispatchReader interface {
+	GetBalance(address crypto.AddressBytes) *big.Int // Native token balance
+	GetName(address crypto.AddressBytes) string      // Name registered for the account
+	GetDelegates() []crypto.AddressBytes             // Delegate set at the transaction time, in ascending order
+	GetTime() int64                                  // Transaction time in milliseconds
+}
+
+// DispatchPrecompiledContract is a precompiled contract reading Dispatch
+// platform state through the EVM context.
+type DispatchPrecompiledContract interface {
+	RequiredGas(input []byte) uint64            // RequiredPrice calculates the contract gas use
+	Run(evm *EVM, input []byte) ([]byte, error) // Run runs the precompiled contract
+}
+
+// Reserved addresses of the Dispatch precompiled contracts.
+var (
+	DispatchBalanceAddress   = common.BytesToAddress([]byte{0xd1})
+	DispatchNameAddress      = common.BytesToAddress([]byte{0xd2})
+	DispatchVerifyAddress    = common.BytesToAddress([]byte{0xd3})
+	DispatchDelegatesAddress = common.BytesToAddress([]byte{0xd4})
+	DispatchTimeAddress      = common.BytesToAddress([]byte{0xd5})
+)
+
+// DispatchPrecompiledContracts contains the Dispatch precompiled contracts,
+// enabled when the EVM context carries a DispatchReader. Inputs and outputs
+// are ABI encoded so Solidity can use abi.encode and abi.decode with them.
+var DispatchPrecompiledContracts = map[crypto.AddressBytes]DispatchPrecompiledContract{
+	DispatchBalanceAddress:   &dispatchBalance{},
+	DispatchNameAddress:      &dispatchName{},
+	DispatchVerifyAddress:    &dispatchVerify{},
+	DispatchDelegatesAddress: &dispatchDelegates{},
+	DispatchTimeAddress:      &dispatchTime{},
+}
+
+var (
+	errDispatchInvalidAddress     = errors.New("invalid address input")
+	errDispatchInvalidInputLength = errors.New("invalid input length")
+)
+
+// RunDispatchPrecompiledContract runs and evaluates the output of a Dispatch precompiled contract.
+func RunDispatchPrecompiledContract(evm *EVM, p DispatchPrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
+	gas := p.RequiredGas(input)
+	if contract.UseGas(gas) {
+		return p.Run(evm, input)
+	}
+	return nil, ErrOutOfGas
+}
+
+// dispatchPrecompile returns the Dispatch precompiled contract at addr, nil
+// if there is none or the context does not enable them.
+func (evm *EVM) dispatchPrecompile(addr crypto.AddressBytes) DispatchPrecompiledContract {
+	if evm.Dispatch == nil {
+		return nil
+	}
+	return DispatchPrecompiledContracts[addr]
+}
+
+// ActiveDispatchPrecompiles returns the addresses of the Dispatch precompiles,
+// in ascending order, if the context enables them.
+func ActiveDispatchPrecompiles(evm *EVM) []crypto.AddressBytes {
+	if evm.Dispatch == nil {
+		return nil
+	}
+	return []crypto.AddressBytes{
+		DispatchBalanceAddress,
+		DispatchNameAddress,
+		DispatchVerifyAddress,
+		DispatchDelegatesAddress,
+		DispatchTimeAddress,
+	}
+}
+
+// toDispatchAddress reads an ABI encoded address, rejecting dirty upper bytes.
+func toDispatchAddress(input []byte) (crypto.AddressBytes, error) {
+	if len(input) != 32 {
+		return crypto.AddressBytes{}, errDispatchInvalidInputLength
+	}
+	if !allZero(input[:12]) {
+		return crypto.AddressBytes{}, errDispatchInvalidAddress
+	}
+	return common.BytesToAddress(input[12:]), nil
+}
+
+// dispatchBalance returns the native token balance of abi.encode(address).
+type dispatchBalance struct{}
+
+func (c *dispatchBalance) RequiredGas(input []byte) uint64 {
+	return params.DispatchBalanceGas
+}
+
+func (c *dispatchBalance) Run(evm *EVM, input []byte) ([]byte, error) {
+	address, err := toDispatchAddress(input)
+	if err != nil {
+		return nil, err
+	}
+	// Accounts this execution touched carry their pending balance
+	var balance *big.Int
+	if evm.StateDB.Exist(address) {
+		balance = evm.StateDB.GetBalance(address)
+	} else {
+		balance = evm.Dispatch.GetBalance(address)
+	}
+	return math.PaddedBigBytes(math.U256(new(big.Int).Set(balance)), 32), nil
+}
+
+// dispatchName returns abi.encode(string) of the name registered for abi.encode(address).
+type dispatchName struct{}
+
+func (c *dispatchName) RequiredGas(input []byte) uint64 {
+	return params.DispatchNameGas
+}
+
+func (c *dispatchName) Run(evm *EVM, input []byte) ([]byte, error) {
+	address, err := toDispatchAddress(input)
+	if err != nil {
+		return nil, err
+	}
+	name := []byte(evm.Dispatch.GetName(address))
+
+	output := make([]byte, 64, 64+toWordSize(uint64(len(name)))*32)
+	output[31] = 32 // offset of the string
+	copy(output[32:], math.PaddedBigBytes(big.NewInt(int64(len(name))), 32))
+	return append(output, common.RightPadBytes(name, int(toWordSize(uint64(len(name)))*32))...), nil
+}
+
+// dispatchVerify returns abi.encode(bool) of whether a Dispatch signature is
+// valid. The input is the 32 byte hash, abi.encode(address) of the signer and
+// the 65 byte signature, as produced by abi.encodePacked(hash, abi.encode(signer), signature).
+type dispatchVerify struct{}
+
+const dispatchVerifyInputLength = 32 + 32 + crypto.SignatureLength
+
+func (c *dispatchVerify) RequiredGas(input []byte) uint64 {
+	return params.DispatchVerifyGas
+}
+
+func (c *dispatchVerify) Run(evm *EVM, input []byte) ([]byte, error) {
+	if len(input) != dispatchVerifyInputLength {
+		return nil, errDispatchInvalidInputLength
+	}
+	address, err := toDispatchAddress(input[32:64])
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := secp256k1.RecoverPubkey(input[:32], input[64:])
+	if err != nil {
+		return false32Byte, nil
+	}
+	if !bytes.Equal(crypto.ToAddress(publicKey), address[:]) {
+		return false32Byte, nil
+	}
+	return true32Byte, nil
+}
+
+// dispatchDelegates returns abi.encode(address[]) of the delegate set at the transaction time.
+type dispatchDelegates struct{}
+
+func (c *dispatchDelegates) RequiredGas(input []byte) uint64 {
+	return params.DispatchDelegatesGas
+}
+
+func (c *dispatchDelegates) Run(evm *EVM, input []byte) ([]byte, error) {
+	delegates := evm.Dispatch.GetDelegates()
+
+	output := make([]byte, 64, 64+len(delegates)*32)
+	output[31] = 32 // offset of the array
+	copy(output[32:], math.PaddedBigBytes(big.NewInt(int64(len(delegates))), 32))
+	for _, delegate := range delegates {
+		output = append(output, common.LeftPadBytes(delegate[:], 32)...)
+	}
+	return output, nil
+}
+
+// dispatchTime returns abi.encode(uint256) of the transaction time in milliseconds.
+type dispatchTime struct{}
+
+func (c *dispatchTime) RequiredGas(input []byte) uint64 {
+	return params.DispatchTimeGas
+}
+
+func (c *dispatchTime) Run(evm *EVM, input []byte) ([]byte, error) {
+	return math.PaddedBigBytes(math.U256(big.NewInt(evm.Dispatch.GetTime())), 32), nil
+}
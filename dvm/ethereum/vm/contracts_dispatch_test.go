@@ -0,0 +1,117 @@
+/*
+ *    This file is part of DVM library.
+ *
+ *    The DVM library is free software: you can redistribute it and/or modify
+ *    it under the terms of the GNU General Public License as published by
+ *    the Free Software Foundation, either version 3 of the License, or
+ *    (at your option) any later version.
+ *
+ *    The DVM library is distributed in the hope that it will be useful,
+ *    but WITHOUT ANY WARRANTY; without even the implied warranty of
+ *    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ *    GNU General Public License for more details.
+ *
+ *    You should have received a copy of the GNU General Public License
+ *    along with the DVM library.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common"
+)
+
+type testDispatchReader struct{}
+
+func (testDispatchReader) GetBalance(address crypto.AddressBytes) *big.Int { return big.NewInt(1975) }
+func (testDispatchReader) GetName(address crypto.AddressBytes) string      { return "dispatch" }
+func (testDispatchReader) GetDelegates() []crypto.AddressBytes {
+	return []crypto.AddressBytes{common.HexToAddress("01"), common.HexToAddress("02")}
+}
+func (testDispatchReader) GetTime() int64 { return 1536000000000 }
+
+func runDispatchPrecompiled(t *testing.T, addr crypto.AddressBytes, input []byte) []byte {
+	evm := &EVM{Context: Context{Dispatch: testDispatchReader{}}, StateDB: NoopStateDB{}}
+	p := evm.dispatchPrecompile(addr)
+	if p == nil {
+		t.Fatalf("no dispatch precompile at %x", addr)
+	}
+	contract := NewContract(AccountRef(common.HexToAddress("1337")), nil, new(big.Int), p.RequiredGas(input))
+	res, err := RunDispatchPrecompiledContract(evm, p, input, contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestDispatchPrecompiledDisabled(t *testing.T) {
+	evm := &EVM{StateDB: NoopStateDB{}}
+	if evm.dispatchPrecompile(DispatchBalanceAddress) != nil || len(ActiveDispatchPrecompiles(evm)) != 0 {
+		t.Error("dispatch precompiles must be disabled without a reader")
+	}
+}
+
+func TestDispatchPrecompiledBalance(t *testing.T) {
+	res := runDispatchPrecompiled(t, DispatchBalanceAddress, common.LeftPadBytes([]byte{0xaa}, 32))
+	if common.Bytes2Hex(res) != common.Bytes2Hex(common.LeftPadBytes(big.NewInt(1975).Bytes(), 32)) {
+		t.Errorf("unexpected balance %x", res)
+	}
+
+	// Dirty upper bytes are not an address
+	evm := &EVM{Context: Context{Dispatch: testDispatchReader{}}, StateDB: NoopStateDB{}}
+	if _, err := new(dispatchBalance).Run(evm, common.RightPadBytes([]byte{0xaa}, 32)); err != errDispatchInvalidAddress {
+		t.Errorf("expected %v, got %v", errDispatchInvalidAddress, err)
+	}
+}
+
+func TestDispatchPrecompiledName(t *testing.T) {
+	res := runDispatchPrecompiled(t, DispatchNameAddress, common.LeftPadBytes([]byte{0xaa}, 32))
+	expected := "0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000008" +
+		"6469737061746368000000000000000000000000000000000000000000000000"
+	if common.Bytes2Hex(res) != expected {
+		t.Errorf("expected %s, got %x", expected, res)
+	}
+}
+
+func TestDispatchPrecompiledVerify(t *testing.T) {
+	publicKey, privateKey := crypto.GenerateKeyPair()
+	signer := crypto.ToAddress(publicKey)
+	hash := crypto.NewHash([]byte("dispatch"))
+	signature, err := crypto.NewSignature(privateKey, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := func(signer []byte) []byte {
+		in := append([]byte{}, hash[:]...)
+		in = append(in, common.LeftPadBytes(signer, 32)...)
+		return append(in, signature...)
+	}
+	if res := runDispatchPrecompiled(t, DispatchVerifyAddress, input(signer)); common.Bytes2Hex(res) != common.Bytes2Hex(true32Byte) {
+		t.Errorf("expected a valid signature, got %x", res)
+	}
+	if res := runDispatchPrecompiled(t, DispatchVerifyAddress, input([]byte{0xaa})); common.Bytes2Hex(res) != common.Bytes2Hex(false32Byte) {
+		t.Errorf("expected an invalid signature, got %x", res)
+	}
+}
+
+func TestDispatchPrecompiledDelegates(t *testing.T) {
+	res := runDispatchPrecompiled(t, DispatchDelegatesAddress, nil)
+	expected := "0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002"
+	if common.Bytes2Hex(res) != expected {
+		t.Errorf("expected %s, got %x", expected, res)
+	}
+}
+
+func TestDispatchPrecompiledTime(t *testing.T) {
+	res := runDispatchPrecompiled(t, DispatchTimeAddress, nil)
+	if new(big.Int).SetBytes(res).Int64() != 1536000000000 || len(res) != 32 {
+		t.Errorf("unexpected time %x", res)
+	}
+}
@@ -336,6 +336,26 @@ var bn256PairingTests = []precompiledTest{
 	},
 }
 
+// blake2FTests are the test and benchmark data for the blake2F precompiled
+// contract, taken from EIP 152.
+var blake2FTests = []precompiledTest{
+	{
+		input:    "0000000048c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b",
+		name:     "vector 4",
+	},
+	{
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001",
+		expected: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923",
+		name:     "vector 5",
+	},
+	{
+		input:    "0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000",
+		expected: "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735",
+		name:     "vector 6",
+	},
+}
+
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 	p := PrecompiledContractsByzantium[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
@@ -481,3 +501,38 @@ func BenchmarkPrecompiledBn256Pairing(bench *testing.B) {
 		benchmarkPrecompiled("08", test, bench)
 	}
 }
+
+// Tests the sample inputs from the blake2F EIP 152, which Istanbul adds.
+func TestPrecompiledBlake2F(t *testing.T) {
+	p := PrecompiledContractsIstanbul[common.HexToAddress("09")]
+	for _, test := range blake2FTests {
+		in := common.Hex2Bytes(test.input)
+		contract := NewContract(AccountRef(common.HexToAddress("1337")),
+			nil, new(big.Int), p.RequiredGas(in))
+		if res, err := RunPrecompiledContract(p, in, contract); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if common.Bytes2Hex(res) != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, common.Bytes2Hex(res))
+		}
+	}
+	if _, err := p.Run(make([]byte, blake2FInputLength-1)); err != errBlake2FInvalidInputLength {
+		t.Errorf("expected %v, got %v", errBlake2FInvalidInputLength, err)
+	}
+}
+
+// Tests the EIP 2565 repricing of modexp, which Berlin adds.
+func TestPrecompiledModExpEip2565(t *testing.T) {
+	p := PrecompiledContractsBerlin[common.HexToAddress("05")]
+	for _, test := range modexpTests {
+		if gas := p.RequiredGas(common.Hex2Bytes(test.input)); gas < 200 {
+			t.Errorf("%s: gas %d is below the EIP 2565 minimum", test.name, gas)
+		}
+	}
+	// A 64 byte base and modulus with a one byte exponent prices at 8^2 * 1 / 3, below the minimum
+	in := common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000040")
+	if gas := p.RequiredGas(in); gas != 200 {
+		t.Errorf("expected the minimum gas 200, got %d", gas)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+
+	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/common/math"
+	"github.com/dispatchlabs/disgo/dvm/ethereum/params"
+)
+
+// enable1884 applies EIP-1884 to the given jump table:
+// - Increase cost of BALANCE to 700 (gas table)
+// - Increase cost of EXTCODEHASH to 700 (gas table)
+// - Increase cost of SLOAD to 800 (gas table)
+// - Define SELFBALANCE, with cost GasFastStep (5)
+func enable1884(jt *[256]operation) {
+	jt[SELFBALANCE] = operation{
+		execute:       opSelfBalance,
+		gasCost:       constGasFunc(GasFastStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+func opSelfBalance(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	balance := interpreter.intPool.get().Set(interpreter.evm.StateDB.GetBalance(contract.Address()))
+	stack.push(balance)
+	return nil, nil
+}
+
+// enable1344 applies EIP-1344 (ChainID Opcode)
+// - Adds an opcode that returns the current chain’s EIP-155 unique identifier
+func enable1344(jt *[256]operation) {
+	jt[CHAINID] = operation{
+		execute:       opChainID,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+func opChainID(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	chainId := interpreter.intPool.get().Set(interpreter.evm.chainRules.ChainID)
+	stack.push(chainId)
+	return nil, nil
+}
+
+// enable2200 applies EIP-2200 (Rebalance net-metered SSTORE)
+func enable2200(jt *[256]operation) {
+	jt[SSTORE].gasCost = gasSStoreEIP2200
+}
+
+// gasSStoreEIP2200 implements the net gas metering of EIP-2200, which prices
+// an SSTORE by comparing the new value with the current and the committed one.
+func gasSStoreEIP2200(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	// If we fail the minimum gas availability invariant, fail (0)
+	if contract.Gas <= params.SstoreSentryGasEIP2200 {
+		return 0, ErrOutOfGas
+	}
+	// Gas sentry honoured, do the actual gas calculation based on the stored value
+	var (
+		y, x    = stack.Back(1), stack.Back(0)
+		slot    = crypto.BigToHash(x)
+		current = evm.StateDB.GetState(contract.Address(), slot)
+		value   = crypto.BigToHash(y)
+	)
+	if current == value { // noop (1)
+		return params.SloadGasEIP2200, nil
+	}
+	original := evm.StateDB.GetCommittedState(contract.Address(), slot)
+	if original == current {
+		if crypto.EmptyHash(original) { // create slot (2.1.1)
+			return params.SstoreSetGasEIP2200, nil
+		}
+		if crypto.EmptyHash(value) { // delete slot (2.1.2b)
+			evm.StateDB.AddRefund(params.SstoreClearsScheduleRefundEIP2200)
+		}
+		return params.SstoreResetGasEIP2200, nil // write existing slot (2.1.2)
+	}
+	if !crypto.EmptyHash(original) {
+		if crypto.EmptyHash(current) { // recreate slot (2.2.1.1)
+			evm.StateDB.SubRefund(params.SstoreClearsScheduleRefundEIP2200)
+		} else if crypto.EmptyHash(value) { // delete slot (2.2.1.2)
+			evm.StateDB.AddRefund(params.SstoreClearsScheduleRefundEIP2200)
+		}
+	}
+	if original == value {
+		if crypto.EmptyHash(original) { // reset to original inexistent slot (2.2.2.1)
+			evm.StateDB.AddRefund(params.SstoreSetGasEIP2200 - params.SloadGasEIP2200)
+		} else { // reset to original existing slot (2.2.2.2)
+			evm.StateDB.AddRefund(params.SstoreResetGasEIP2200 - params.SloadGasEIP2200)
+		}
+	}
+	return params.SloadGasEIP2200, nil // dirty update (2.2)
+}
+
+// enable2929 enables "EIP-2929: Gas cost increases for state access opcodes"
+// https://eips.ethereum.org/EIPS/eip-2929
+//
+// The warm prices come from params.GasTableBerlin, the wrappers below only
+// add the cold access surcharge.
+func enable2929(jt *[256]operation) {
+	jt[SSTORE].gasCost = gasSStoreEIP2929
+	jt[SLOAD].gasCost = gasSLoadEIP2929
+
+	jt[EXTCODECOPY].gasCost = makeGasAccountAccessEIP2929(gasExtCodeCopy, 0)
+	jt[EXTCODESIZE].gasCost = makeGasAccountAccessEIP2929(gasExtCodeSize, 0)
+	jt[EXTCODEHASH].gasCost = makeGasAccountAccessEIP2929(gasExtCodeHash, 0)
+	jt[BALANCE].gasCost = makeGasAccountAccessEIP2929(gasBalance, 0)
+
+	jt[CALL].gasCost = makeGasAccountAccessEIP2929(gasCall, 1)
+	jt[CALLCODE].gasCost = makeGasAccountAccessEIP2929(gasCallCode, 1)
+	jt[STATICCALL].gasCost = makeGasAccountAccessEIP2929(gasStaticCall, 1)
+	jt[DELEGATECALL].gasCost = makeGasAccountAccessEIP2929(gasDelegateCall, 1)
+	jt[SELFDESTRUCT].gasCost = gasSelfdestructEIP2929
+}
+
+// enable3529 enabled "EIP-3529: Reduction in refunds":
+// - Removes refunds for selfdestructs
+// - Reduces refunds for SSTORE
+// - Reduces max refunds to 20% gas
+func enable3529(jt *[256]operation) {
+	jt[SSTORE].gasCost = gasSStoreEIP3529
+	jt[SELFDESTRUCT].gasCost = gasSelfdestructEIP3529
+}
+
+// enable3198 applies EIP-3198 (BASEFEE Opcode)
+// - Adds an opcode that returns the current block's base fee.
+func enable3198(jt *[256]operation) {
+	jt[BASEFEE] = operation{
+		execute:       opBaseFee,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+// opBaseFee implements BASEFEE opcode. Networks without a fee market leave
+// the context base fee unset, which reads as zero.
+func opBaseFee(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	if interpreter.evm.BaseFee == nil {
+		stack.push(interpreter.intPool.getZero())
+		return nil, nil
+	}
+	stack.push(math.U256(interpreter.intPool.get().Set(interpreter.evm.BaseFee)))
+	return nil, nil
+}
+
+// enable3855 applies EIP-3855 (PUSH0 opcode)
+func enable3855(jt *[256]operation) {
+	jt[PUSH0] = operation{
+		execute:       opPush0,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+}
+
+// opPush0 implements the PUSH0 opcode
+func opPush0(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	stack.push(interpreter.intPool.getZero())
+	return nil, nil
+}
+
+// enable3860 enables "EIP-3860: Limit and meter initcode"
+// https://eips.ethereum.org/EIPS/eip-3860
+func enable3860(jt *[256]operation) {
+	jt[CREATE].gasCost = gasCreateEip3860
+	jt[CREATE2].gasCost = gasCreate2Eip3860
+}
+
+func gasCreateEip3860(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := gasCreate(gt, evm, contract, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	return addInitCodeWordGas(gas, stack.Back(2))
+}
+
+func gasCreate2Eip3860(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := gasCreate2(gt, evm, contract, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	return addInitCodeWordGas(gas, stack.Back(2))
+}
+
+// addInitCodeWordGas rejects initcode above params.MaxInitCodeSize and
+// charges params.InitCodeWordGas for every word of it.
+func addInitCodeWordGas(gas uint64, length *big.Int) (uint64, error) {
+	size, overflow := bigUint64(length)
+	if overflow || size > params.MaxInitCodeSize {
+		return 0, ErrMaxInitCodeSizeExceeded
+	}
+	// Since size <= params.MaxInitCodeSize, this multiplication cannot overflow
+	moreGas := params.InitCodeWordGas * toWordSize(size)
+	if gas, overflow = math.SafeAdd(gas, moreGas); overflow {
+		return 0, errGasUintOverflow
+	}
+	return gas, nil
+}
@@ -49,13 +49,13 @@ type (
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
+		precompiles := ActivePrecompiledContracts(evm.chainRules)
 		if p := precompiles[*contract.CodeAddr]; p != nil {
 			return RunPrecompiledContract(p, input, contract)
 		}
+		if p := evm.dispatchPrecompile(*contract.CodeAddr); p != nil {
+			return RunDispatchPrecompiledContract(evm, p, input, contract)
+		}
 	}
 	for _, interpreter := range evm.interpreters {
 		if interpreter.CanRun(contract.Code) {
@@ -94,6 +94,10 @@ type Context struct {
 	BlockNumber *big.Int            // Provides information for NUMBER
 	Time        *big.Int            // Provides information for TIME
 	Difficulty  *big.Int            // Provides information for DIFFICULTY
+	BaseFee     *big.Int            // Provides information for BASEFEE
+
+	// Dispatch enables the Dispatch precompiled contracts when set
+	Dispatch DispatchReader
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -108,6 +112,9 @@ type Context struct {
 type EVM struct {
 	// DISPATCH
 	StateQueryHelper vmstatehelpercontracts.VMStateQueryHelper
+	// Contracts created by a running contract, mapped to that contract. A creation reverted by a caller stays in it, its
+	// account has no code left in the state
+	Created map[crypto.AddressBytes]crypto.AddressBytes
 
 	// Context provides auxiliary blockchain related information
 	Context
@@ -196,11 +203,8 @@ func (evm *EVM) Call(caller ContractRef, addr crypto.AddressBytes, input []byte,
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if precompiles[addr] == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
+		precompiles := ActivePrecompiledContracts(evm.chainRules)
+		if precompiles[addr] == nil && evm.dispatchPrecompile(addr) == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
 				evm.vmConfig.Tracer.CaptureStart(caller.Address(), addr, false, input, gas, value)
@@ -371,6 +375,11 @@ func (evm *EVM) create(caller ContractRef, code []byte, gas uint64, value *big.I
 	// Ensure there's no existing contract already at the designated address
 	nonce := evm.StateDB.GetNonce(caller.Address())
 	evm.StateDB.SetNonce(caller.Address(), nonce+1)
+	// We add this to the access list _before_ taking a snapshot. Even if the creation fails,
+	// the access-list change should not be rolled back
+	if evm.chainRules.IsBerlin {
+		evm.StateDB.AddAddressToAccessList(address)
+	}
 
 	//this creates the contract address and creates an account in the EVM.StateDB
 	//I don't think this matters at all since we capture in receipt
@@ -415,6 +424,10 @@ func (evm *EVM) create(caller ContractRef, code []byte, gas uint64, value *big.I
 
 	// check whether the max code size has been exceeded
 	maxCodeSizeExceeded := evm.ChainConfig().IsEIP158(evm.BlockNumber) && len(ret) > params.MaxCodeSize
+	// Reject code starting with 0xEF if EIP-3541 is enabled.
+	if err == nil && !maxCodeSizeExceeded && len(ret) >= 1 && ret[0] == 0xEF && evm.chainRules.IsLondon {
+		err = ErrInvalidCode
+	}
 	// if the contract creation ran successfully and no errors were returned
 	// calculate the gas required to store the code. If the code could not
 	// be stored due to not enough gas set an error and let it be handled
@@ -441,6 +454,12 @@ func (evm *EVM) create(caller ContractRef, code []byte, gas uint64, value *big.I
 	if maxCodeSizeExceeded && err == nil {
 		err = errMaxCodeSizeExceeded
 	}
+	if err == nil && evm.depth > 0 {
+		if evm.Created == nil {
+			evm.Created = make(map[crypto.AddressBytes]crypto.AddressBytes)
+		}
+		evm.Created[address] = caller.Address()
+	}
 	if evm.vmConfig.Debug && evm.depth == 0 {
 		evm.vmConfig.Tracer.CaptureEnd(ret, gas-contract.Gas, time.Since(start), err)
 	}
@@ -557,12 +557,7 @@ func opExtCodeSize(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 	utils.Debug(fmt.Sprintf("EVMInterpreter-opExtCodeSize: callerAddress               -> %s", crypto2.Encode(callerAddress[:])))
 	utils.Debug(fmt.Sprintf("EVMInterpreter-opExtCodeSize: toBeExecutedContractAddress -> %s", crypto2.Encode(toBeExecutedContractAddress[:])))
 
-	var codeSize = uint64(interpreter.evm.StateDB.GetCodeSize(common.BigToAddress(slot)))
-	if codeSize == 0 && interpreter.evm.StateQueryHelper != nil {
-		codeSize = uint64(interpreter.evm.StateQueryHelper.GetCodeSize(contract.Address(), contract.Caller(), common.BigToAddress(slot)))
-	}
-
-	slot.SetUint64(codeSize)
+	slot.SetUint64(uint64(interpreter.evm.StateDB.GetCodeSize(toBeExecutedContractAddress)))
 
 	return nil, nil
 }
@@ -918,23 +913,11 @@ func opCall(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory
 	utils.Debug(fmt.Sprintf("EVMInterpreter-opCall: value                       -> %v", value))
 	utils.Debug(fmt.Sprintf("EVMInterpreter-opCall: args                        -> %v", args))
 
-	// DISPATCH - Swap StateDB conext
-	var prevEthDB = interpreter.evm.StateDB
-
-	var newStateQueryHelper = interpreter.evm.StateQueryHelper.NewEthStateLoader(toAddr)
-	interpreter.evm.StateDB = newStateQueryHelper.GetEthStateDB()
-
+	// DISPATCH - The callee runs against the same world state, its changes are reverted or committed with the caller's
 	if value.Sign() != 0 {
 		gas += params.CallStipend
 	}
 	ret, returnGas, err := interpreter.evm.Call(contract, toAddr, args, gas, value)
-
-	if interpreter.evm.StateDB != nil {
-		newStateQueryHelper.CommitState()
-	}
-
-	interpreter.evm.StateDB = prevEthDB
-
 	if err != nil {
 		stack.push(interpreter.intPool.getZero())
 	} else {
@@ -40,8 +40,10 @@ type StateDB interface {
 	GetCodeSize(crypto.AddressBytes) int
 
 	AddRefund(uint64)
+	SubRefund(uint64)
 	GetRefund() uint64
 
+	GetCommittedState(crypto.AddressBytes, crypto.HashBytes) crypto.HashBytes
 	GetState(crypto.AddressBytes, crypto.HashBytes) crypto.HashBytes
 	SetState(crypto.AddressBytes, crypto.HashBytes, crypto.HashBytes)
 
@@ -55,6 +57,16 @@ type StateDB interface {
 	// is defined according to EIP161 (balance = nonce = code = 0).
 	Empty(crypto.AddressBytes) bool
 
+	PrepareAccessList(sender crypto.AddressBytes, dest *crypto.AddressBytes, precompiles []crypto.AddressBytes)
+	AddressInAccessList(addr crypto.AddressBytes) bool
+	SlotInAccessList(addr crypto.AddressBytes, slot crypto.HashBytes) (addressOk bool, slotOk bool)
+	// AddAddressToAccessList adds the given address to the access list. This operation is safe to perform
+	// even if the feature/fork is not active yet
+	AddAddressToAccessList(addr crypto.AddressBytes)
+	// AddSlotToAccessList adds the given (address,slot) to the access list. This operation is safe to perform
+	// even if the feature/fork is not active yet
+	AddSlotToAccessList(addr crypto.AddressBytes, slot crypto.HashBytes)
+
 	RevertToSnapshot(int)
 	Snapshot() int
 
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"math/big"
 	"sync/atomic"
-	"time"
 
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/dvm/ethereum/common/math"
@@ -81,22 +80,36 @@ type EVMInterpreter struct {
 	returnData []byte // Last CALL's return data for subsequent reuse
 }
 
+// InstructionSet returns the jump table the interpreter runs under the chain
+// rules at block num.
+func InstructionSet(config *params.ChainConfig, num *big.Int) [256]operation {
+	switch {
+	case config.IsShanghai(num):
+		return ShanghaiInstructionSet
+	case config.IsLondon(num):
+		return LondonInstructionSet
+	case config.IsBerlin(num):
+		return BerlinInstructionSet
+	case config.IsIstanbul(num):
+		return IstanbulInstructionSet
+	case config.IsConstantinople(num):
+		return ConstantinopleInstructionSet
+	case config.IsByzantium(num):
+		return ByzantiumInstructionSet
+	case config.IsHomestead(num):
+		return homesteadInstructionSet
+	default:
+		return frontierInstructionSet
+	}
+}
+
 // NewEVMInterpreter returns a new instance of the Interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	// We use the STOP instruction whether to see
 	// the jump table was initialised. If it was not
 	// we'll set the default jump table.
 	if !cfg.JumpTable[STOP].valid {
-		switch {
-		case evm.ChainConfig().IsConstantinople(evm.BlockNumber):
-			cfg.JumpTable = ConstantinopleInstructionSet
-		case evm.ChainConfig().IsByzantium(evm.BlockNumber):
-			cfg.JumpTable = ByzantiumInstructionSet
-		case evm.ChainConfig().IsHomestead(evm.BlockNumber):
-			cfg.JumpTable = homesteadInstructionSet
-		default:
-			cfg.JumpTable = frontierInstructionSet
-		}
+		cfg.JumpTable = InstructionSet(evm.ChainConfig(), evm.BlockNumber)
 	}
 
 	return &EVMInterpreter{
@@ -242,8 +255,6 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte) (ret []byte, err
 		}
 
 		// execute the operation
-		in.evm.Time = big.NewInt(utils.ToNanoSeconds(time.Now()))
-
 		res, err := operation.execute(&pc, in, contract, mem, stack)
 		// verifyPool is a build flag. Pool verification makes sure the integrity
 		// of the integer pool by comparing values to a default value.
@@ -55,8 +55,50 @@ var (
 	homesteadInstructionSet      = newHomesteadInstructionSet()
 	ByzantiumInstructionSet      = newByzantiumInstructionSet()
 	ConstantinopleInstructionSet = newConstantinopleInstructionSet()
+	IstanbulInstructionSet       = newIstanbulInstructionSet()
+	BerlinInstructionSet         = newBerlinInstructionSet()
+	LondonInstructionSet         = newLondonInstructionSet()
+	ShanghaiInstructionSet       = newShanghaiInstructionSet()
 )
 
+// newShanghaiInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul, berlin, london and shanghai instructions.
+func newShanghaiInstructionSet() [256]operation {
+	instructionSet := newLondonInstructionSet()
+	enable3855(&instructionSet) // PUSH0 instruction
+	enable3860(&instructionSet) // Limit and meter initcode
+	return instructionSet
+}
+
+// newLondonInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul, berlin and london instructions.
+func newLondonInstructionSet() [256]operation {
+	instructionSet := newBerlinInstructionSet()
+	enable3529(&instructionSet) // EIP-3529: Reduction in refunds
+	enable3198(&instructionSet) // Base fee opcode https://eips.ethereum.org/EIPS/eip-3198
+	return instructionSet
+}
+
+// newBerlinInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul and berlin instructions.
+func newBerlinInstructionSet() [256]operation {
+	instructionSet := newIstanbulInstructionSet()
+	enable2929(&instructionSet) // Access lists for trie accesses https://eips.ethereum.org/EIPS/eip-2929
+	return instructionSet
+}
+
+// newIstanbulInstructionSet returns the frontier, homestead, byzantium,
+// constantinople and istanbul instructions. Petersburg only removed the
+// net gas metering of EIP-1283, which this tree never shipped, so it shares
+// the constantinople instructions.
+func newIstanbulInstructionSet() [256]operation {
+	instructionSet := newConstantinopleInstructionSet()
+	enable1344(&instructionSet) // ChainID opcode - https://eips.ethereum.org/EIPS/eip-1344
+	enable1884(&instructionSet) // Reprice reader opcodes - https://eips.ethereum.org/EIPS/eip-1884
+	enable2200(&instructionSet) // Net metered SSTORE - https://eips.ethereum.org/EIPS/eip-2200
+	return instructionSet
+}
+
 // NewConstantinopleInstructionSet returns the frontier, homestead
 // byzantium and contantinople instructions.
 func newConstantinopleInstructionSet() [256]operation {
@@ -56,7 +56,11 @@ func (NoopStateDB) GetCode(crypto.AddressBytes) []byte               { return ni
 func (NoopStateDB) SetCode(crypto.AddressBytes, []byte)              {}
 func (NoopStateDB) GetCodeSize(crypto.AddressBytes) int              { return 0 }
 func (NoopStateDB) AddRefund(uint64)                                 {}
+func (NoopStateDB) SubRefund(uint64)                                 {}
 func (NoopStateDB) GetRefund() uint64                                { return 0 }
+func (NoopStateDB) GetCommittedState(crypto.AddressBytes, crypto.HashBytes) crypto.HashBytes {
+	return crypto.HashBytes{}
+}
 func (NoopStateDB) GetState(crypto.AddressBytes, crypto.HashBytes) crypto.HashBytes {
 	return crypto.HashBytes{}
 }
@@ -65,9 +69,17 @@ func (NoopStateDB) Suicide(crypto.AddressBytes) bool
 func (NoopStateDB) HasSuicided(crypto.AddressBytes) bool                             { return false }
 func (NoopStateDB) Exist(crypto.AddressBytes) bool                                   { return false }
 func (NoopStateDB) Empty(crypto.AddressBytes) bool                                   { return false }
-func (NoopStateDB) RevertToSnapshot(int)                                             {}
-func (NoopStateDB) Snapshot() int                                                    { return 0 }
-func (NoopStateDB) AddLog(*types.Log)                                                {}
-func (NoopStateDB) AddPreimage(crypto.HashBytes, []byte)                             {}
+func (NoopStateDB) PrepareAccessList(crypto.AddressBytes, *crypto.AddressBytes, []crypto.AddressBytes) {
+}
+func (NoopStateDB) AddressInAccessList(crypto.AddressBytes) bool { return false }
+func (NoopStateDB) SlotInAccessList(crypto.AddressBytes, crypto.HashBytes) (bool, bool) {
+	return false, false
+}
+func (NoopStateDB) AddAddressToAccessList(crypto.AddressBytes)                {}
+func (NoopStateDB) AddSlotToAccessList(crypto.AddressBytes, crypto.HashBytes) {}
+func (NoopStateDB) RevertToSnapshot(int)                                      {}
+func (NoopStateDB) Snapshot() int                                             { return 0 }
+func (NoopStateDB) AddLog(*types.Log)                                         {}
+func (NoopStateDB) AddPreimage(crypto.HashBytes, []byte)                      {}
 func (NoopStateDB) ForEachStorage(crypto.AddressBytes, func(crypto.HashBytes, crypto.HashBytes) bool) {
 }
@@ -17,9 +17,12 @@
 package vmstatehelperimplemtations
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
 
+	"github.com/dgraph-io/badger"
 	"github.com/dispatchlabs/disgo/commons/crypto"
 	"github.com/dispatchlabs/disgo/commons/types"
 	"github.com/dispatchlabs/disgo/commons/utils"
@@ -30,27 +33,54 @@ import (
 	ethState "github.com/dispatchlabs/disgo/dvm/ethereum/state"
 	ethTypes "github.com/dispatchlabs/disgo/dvm/ethereum/types"
 	"github.com/dispatchlabs/disgo/dvm/vmstatehelpercontracts"
-	"encoding/hex"
+	"errors"
 )
 
 var (
 	// chainID            = big.NewInt(1)
-	GasLimit           = big.NewInt(1000000000000)
+	GasLimit           = big.NewInt(types.PageHertzLimit)
 	txMetaSuffix       = []byte{0x01}
 	ReceiptsPrefix     = []byte("receipts-")
+	PreStatePrefix     = []byte("PreState-")
+	PreBalancesPrefix  = []byte("PreBalances-")
 	headTxKey          = []byte("LastTx")
-	acctounStatePrefix = []byte("AccountState-")
+	worldStateKey      = []byte("WorldState")
 	MIPMapLevels       = []uint64{1000000, 500000, 100000, 50000, 1000}
 	IsDemo             = false
 
 	DefaultValue    = big.NewInt(0)
-	DefaultGas      = big.NewInt(1000000000000)
 	DefaultGasPrice = big.NewInt(0)
-	DefaultGasLimit = 1000000000000
 	DefaultDivvy    = int64(0)
+
+	ErrReadOnlyState = errors.New("read-only state can not be committed")
+	ErrNoPreState    = errors.New("no pre-state recorded for the transaction")
+
+	// Sizes of the trie node and contract code caches shared by every execution
+	TrieNodeCacheSize = 100000
+	CodeCacheSize     = 1000
 )
 
-// VMStateHelper - Helps load and save Smart Contract storage state
+var stateDatabaseInstance ethState.Database
+var stateDatabaseErr error
+var stateDatabaseOnce sync.Once
+
+// GetStateDatabase - The state database shared by every execution, its trie node and code caches stay warm across
+// transactions. Nodes and codes are keyed by hash so cached entries never go stale, committed nodes move from the dirty
+// nodes to the clean cache and the root of the world state is always read from Badger. It is only opened once, so an
+// error opening Badger is returned by every call.
+func GetStateDatabase() (ethState.Database, error) {
+	stateDatabaseOnce.Do(func() {
+		var badgerWrapper *badgerwrapper.BadgerDatabase
+		badgerWrapper, stateDatabaseErr = badgerwrapper.NewBadgerDatabase()
+		if stateDatabaseErr != nil {
+			return
+		}
+		stateDatabaseInstance = ethState.NewDatabaseWithCache(badgerWrapper, TrieNodeCacheSize, CodeCacheSize)
+	})
+	return stateDatabaseInstance, stateDatabaseErr
+}
+
+// VMStateHelper - Helps load and save the world state shared by all Smart Contracts
 type VMStateHelper struct {
 	db                   ethdb.Database       // Storage - like disk storage
 	EthStateDB           *ethState.StateDB    // Particia Merkle Trie
@@ -60,20 +90,40 @@ type VMStateHelper struct {
 	AllLogs              []*ethTypes.Log      // VM opcodes execetion logs
 	TotalUsedGas         *big.Int             // $$$ used to execute the opcodes and such
 	GP                   *ethereum.GasPool    // TODO: what is this ?
-	SmartContractAddress crypto.AddressBytes  // Smart Contract
+	SmartContractAddress crypto.AddressBytes  // Smart Contract the transaction is for
+	ReadOnly             bool                 // Never write the state, used by read-only calls
+	txn                  *badger.Txn          // Transaction the state is written through, nil to write to Badger
+	pending              bool                 // Trie nodes written through the transaction are still in memory
 
 	HashOfTrieRootNode crypto.HashBytes
+	PreStateRoot       crypto.HashBytes // Root of the world state before the transactions ran
+
+	// Contracts created by the contracts the transactions ran, mapped to the contract that created each
+	CreatedContracts map[crypto.AddressBytes]crypto.AddressBytes
 }
 
-// NewVMStateHelper - loads (if any) and returns the state for a Smart Contract
+// NewVMStateHelper - loads (if any) and returns the world state to run a Smart Contract against
 func NewVMStateHelper(smartContractAddress crypto.AddressBytes) (*VMStateHelper, error) {
+	badgerWrapper, err := badgerwrapper.NewBadgerDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return newVMStateHelper(badgerWrapper, nil, smartContractAddress)
+}
+
+// NewVMStateHelperWithTxn - loads the world state through a Badger transaction and commits it through the same one, so
+// the state only moves if the caller commits the transaction. Persisted must be called once it has, Discard otherwise.
+func NewVMStateHelperWithTxn(txn *badger.Txn, smartContractAddress crypto.AddressBytes) (*VMStateHelper, error) {
+	return newVMStateHelper(badgerwrapper.NewTxnDatabase(txn), txn, smartContractAddress)
+}
+
+func newVMStateHelper(db ethdb.Database, txn *badger.Txn, smartContractAddress crypto.AddressBytes) (*VMStateHelper, error) {
 	utils.Debug(fmt.Sprintf("NewVMStateHelper-CONTRACT: %s", crypto.Encode(smartContractAddress[:])))
 	// debug.PrintStack()
 
-	badgerWrapper, _ := badgerwrapper.NewBadgerDatabase()
-
 	vmStateHelper := &VMStateHelper{
-		db:                   badgerWrapper,                                   //
+		db:                   db,                                              //
+		txn:                  txn,                                             //
 		EthStateDB:           nil,                                             // will be set in `initState`
 		TxIndex:              0,                                               // TODO: is it used ?
 		TotalUsedGas:         big.NewInt(0),                                   // TODO: is it used ?
@@ -88,8 +138,103 @@ func NewVMStateHelper(smartContractAddress crypto.AddressBytes) (*VMStateHelper,
 	return vmStateHelper, nil
 }
 
-// Commit - Writes all the changes to the actual storage (aka Badger)
+// NewReadOnlyVMStateHelper - loads the world state for a Smart Contract, changes to it are never committed
+func NewReadOnlyVMStateHelper(smartContractAddress crypto.AddressBytes) (*VMStateHelper, error) {
+	vmStateHelper, err := NewVMStateHelper(smartContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	vmStateHelper.ReadOnly = true
+	return vmStateHelper, nil
+}
+
+// NewReadOnlyVMStateHelperAt - loads the world state as it was at a past root, changes to it are never committed
+func NewReadOnlyVMStateHelperAt(root crypto.HashBytes, smartContractAddress crypto.AddressBytes) (*VMStateHelper, error) {
+	badgerWrapper, err := badgerwrapper.NewBadgerDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	vmStateHelper := &VMStateHelper{
+		db:                   badgerWrapper,
+		TotalUsedGas:         big.NewInt(0),
+		GP:                   new(ethereum.GasPool).AddGas(GasLimit.Uint64()),
+		SmartContractAddress: smartContractAddress,
+		ReadOnly:             true,
+		HashOfTrieRootNode:   root,
+		PreStateRoot:         root,
+	}
+
+	stateDatabase, err := GetStateDatabase()
+	if err != nil {
+		return nil, err
+	}
+	vmStateHelper.EthStateDB, err = ethState.New(root, stateDatabase)
+	if err != nil {
+		return nil, err
+	}
+	return vmStateHelper, nil
+}
+
+// GetPreStateRoot - Returns the root of the world state a transaction ran against
+func GetPreStateRoot(txHash crypto.HashBytes) (crypto.HashBytes, error) {
+	badgerWrapper, err := badgerwrapper.NewBadgerDatabase()
+	if err != nil {
+		return crypto.HashBytes{}, err
+	}
+	data, err := badgerWrapper.Get(append(PreStatePrefix, txHash.Bytes()...))
+	if err == badger.ErrKeyNotFound {
+		return crypto.HashBytes{}, ErrNoPreState
+	}
+	if err != nil {
+		return crypto.HashBytes{}, err
+	}
+	return crypto.BytesToHash(data), nil
+}
+
+// GetPreBalances - Returns the balances of the Dispatch accounts a transaction ran against, by address
+func GetPreBalances(txHash crypto.HashBytes) (map[string]*big.Int, error) {
+	badgerWrapper, err := badgerwrapper.NewBadgerDatabase()
+	if err != nil {
+		return nil, err
+	}
+	data, err := badgerWrapper.Get(append(PreBalancesPrefix, txHash.Bytes()...))
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrNoPreState
+	}
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]*big.Int)
+	if err := json.Unmarshal(data, &balances); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// RecordPreState - Stores the root of the world state and the balances a transaction ran against so it can be traced
+// later, whether the transaction succeeded or failed
+func (stateHelper *VMStateHelper) RecordPreState(tx *types.Transaction) error {
+	if stateHelper.ReadOnly {
+		return ErrReadOnlyState
+	}
+	hash := crypto.GetHashBytes(tx.Hash).Bytes()
+	err := stateHelper.db.Put(append(PreStatePrefix, hash...), stateHelper.PreStateRoot.Bytes())
+	if err != nil {
+		return err
+	}
+	balances, err := json.Marshal(stateHelper.EthStateDB.DispatchBalances())
+	if err != nil {
+		return err
+	}
+	return stateHelper.db.Put(append(PreBalancesPrefix, hash...), balances)
+}
+
+// Commit - Writes all the changes to the actual storage (aka Badger), or to the transaction the state was loaded through
 func (stateHelper *VMStateHelper) Commit() (crypto.HashBytes, error) {
+	if stateHelper.ReadOnly {
+		return crypto.HashBytes{}, ErrReadOnlyState
+	}
 	utils.Debug(fmt.Sprintf("VMStateHelper-Commit-CONTRACT    : %s", crypto.Encode(stateHelper.SmartContractAddress[:])))
 	utils.Debug(fmt.Sprintf("VMStateHelper-Commit-TrieRootNode: %s", crypto.Encode(stateHelper.HashOfTrieRootNode[:])))
 
@@ -101,23 +246,32 @@ func (stateHelper *VMStateHelper) Commit() (crypto.HashBytes, error) {
 		return crypto.HashBytes{}, err
 	}
 
-	// Write all changes to the Physical DB to persist the state
-	err = stateHelper.EthStateDB.Database().TrieDB().Commit(stateHelper.HashOfTrieRootNode, true)
+	// Write all changes to the Physical DB to persist the state, the trie keeps the nodes until they are durable
+	err = stateHelper.EthStateDB.Database().TrieDB().CommitTo(stateHelper.HashOfTrieRootNode, stateHelper.db.NewBatch())
 	if err != nil {
 		utils.Error(fmt.Sprintf("VMStateHelper-Commit: %s", err))
 		return crypto.HashBytes{}, err
 	}
+	stateHelper.pending = true
+	if stateHelper.txn == nil {
+		stateHelper.Persisted()
+	}
 
-	// STORE STATE mapping as [FROM_TO]->[TrieRootHash] pair
-	smartContractAddress := hex.EncodeToString(stateHelper.SmartContractAddress[:])
-	var key = append(acctounStatePrefix, smartContractAddress...)
-	// key = append(key, stateHelper.To.Bytes()...)
-
-	utils.Debug(fmt.Sprintf("`acctounStatePrefix` is %v", crypto.Encode(acctounStatePrefix)))
-	utils.Debug(fmt.Sprintf("`smartContractAddress` is %v", crypto.Encode(stateHelper.SmartContractAddress.Bytes())))
+	// STORE the new root of the world state, every contract reads it from now on
+	err = stateHelper.db.Put(worldStateKey, stateHelper.HashOfTrieRootNode.Bytes())
+	if err != nil {
+		utils.Error(fmt.Sprintf("VMStateHelper-Commit: %s", err))
+		return crypto.HashBytes{}, err
+	}
 
-	var val = stateHelper.HashOfTrieRootNode.Bytes()
-	stateHelper.db.Put(key, val)
+	// STORE the root the transactions ran against so they can be traced later
+	for _, tx := range stateHelper.Transactions {
+		err = stateHelper.RecordPreState(tx)
+		if err != nil {
+			utils.Error(fmt.Sprintf("VMStateHelper-Commit: %s", err))
+			return crypto.HashBytes{}, err
+		}
+	}
 
 	// Save the THESE - need to see if needed
 	if err := stateHelper.writeHead(); err != nil {
@@ -137,6 +291,28 @@ func (stateHelper *VMStateHelper) Commit() (crypto.HashBytes, error) {
 	return stateHelper.HashOfTrieRootNode, nil
 }
 
+// Persisted - Drops the committed trie nodes from memory once the transaction they were written through has committed
+func (stateHelper *VMStateHelper) Persisted() {
+	if !stateHelper.pending {
+		return
+	}
+	stateHelper.pending = false
+	stateHelper.EthStateDB.Database().TrieDB().Uncache(stateHelper.HashOfTrieRootNode)
+}
+
+// Discard - Drops the committed trie nodes from memory when the transaction they were written through did not commit,
+// the world state is still read from the root before it
+func (stateHelper *VMStateHelper) Discard() {
+	if !stateHelper.pending {
+		return
+	}
+	stateHelper.pending = false
+	if stateHelper.HashOfTrieRootNode == stateHelper.PreStateRoot || stateHelper.HashOfTrieRootNode == (crypto.HashBytes{}) {
+		return
+	}
+	stateHelper.EthStateDB.Database().TrieDB().Dereference(stateHelper.HashOfTrieRootNode)
+}
+
 func (stateHelper *VMStateHelper) writeHead() error {
 	utils.Debug(fmt.Sprintf("VMStateHelper-writeHead: TX count %d", len(stateHelper.Transactions)))
 
@@ -203,26 +379,22 @@ func (stateHelper *VMStateHelper) initOrLoadState() error {
 
 	stateHelper.HashOfTrieRootNode = crypto.HashBytes{}
 
-	// READ STATE mapping as [FROM_TO]->[TrieRootHash] pair
-	smartContractAddress := hex.EncodeToString(stateHelper.SmartContractAddress[:])
-	var key = append(acctounStatePrefix, smartContractAddress...)
-	//var key = append(acctounStatePrefix, stateHelper.SmartContractAddress.Bytes()...)
-	// key = append(key, stateHelper.To.Bytes()...)
-
-	utils.Debug(fmt.Sprintf("`acctounStatePrefix` is %v", crypto.Encode(acctounStatePrefix)))
-	utils.Debug(fmt.Sprintf("`smartContractAddress` is %v", crypto.Encode(stateHelper.SmartContractAddress.Bytes())))
-	// utils.Debug(fmt.Sprintf("`to` is %v", crypto.Encode(stateHelper.To.Bytes())))
-
-	data, err := stateHelper.db.Get(key)
+	// READ the root of the world state, there is none before the first contract is deployed
+	data, err := stateHelper.db.Get(worldStateKey)
 	if err != nil {
 		// stateHelper.HashOfTrieRootNode = crypto.HashBytes{}
 	} else {
 		stateHelper.HashOfTrieRootNode = crypto.BytesToHash(data)
 	}
+	stateHelper.PreStateRoot = stateHelper.HashOfTrieRootNode
 
 	// use root to initialise the state
 	// stateHelper.EthStateDB, err = ethState.New(rootHash, ethState.NewNonCacheDatabase(stateHelper.db))
-	stateHelper.EthStateDB, err = ethState.New(stateHelper.HashOfTrieRootNode, ethState.NewDatabase(stateHelper.db))
+	stateDatabase, err := GetStateDatabase()
+	if err != nil {
+		return err
+	}
+	stateHelper.EthStateDB, err = ethState.New(stateHelper.HashOfTrieRootNode, stateDatabase)
 
 	return err
 }
@@ -230,38 +402,27 @@ func (stateHelper *VMStateHelper) initOrLoadState() error {
 // VMStateQueryHelper Interface
 // ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~ ~~~~
 
-// GetCode - Loads a new "VMStateHelper" then does what "StateDB.GetCodeSize()" does
+// GetCode - Does what "StateDB.GetCode()" does on the world state
 func (stateHelper *VMStateHelper) GetCode(smartContractAddress crypto.AddressBytes) []byte {
-	// NOTE: not used yet
-
-	return []byte{}
+	return stateHelper.EthStateDB.GetCode(smartContractAddress)
 }
 
-// GetCodeSize - Loads a new "VMStateHelper" then does what "StateDB.GetCodeSize()" does
+// GetCodeSize - Does what "StateDB.GetCodeSize()" does on the world state
 func (stateHelper *VMStateHelper) GetCodeSize(executingContractAddress crypto.AddressBytes, callerAddress crypto.AddressBytes, toBeExecutedContractAddress crypto.AddressBytes) int {
 	utils.Debug(fmt.Sprintf("VMStateHelper-GetCodeSize: executingContractAddress    -> %s", crypto.Encode(executingContractAddress[:])))
 	utils.Debug(fmt.Sprintf("VMStateHelper-GetCodeSize: callerAddress               -> %s", crypto.Encode(callerAddress[:])))
 	utils.Debug(fmt.Sprintf("VMStateHelper-GetCodeSize: toBeExecutedContractAddress -> %s", crypto.Encode(toBeExecutedContractAddress[:])))
 
-	stateHelper, err := NewVMStateHelper(toBeExecutedContractAddress)
-	if err == nil {
-		return stateHelper.EthStateDB.GetCodeSize(toBeExecutedContractAddress)
-	}
-
-	return 0
+	return stateHelper.EthStateDB.GetCodeSize(toBeExecutedContractAddress)
 }
 
+// NewEthStateLoader - Every contract lives in the world state so a callee shares the caller's state
 func (stateHelper *VMStateHelper) NewEthStateLoader(smartContractAddress crypto.AddressBytes) vmstatehelpercontracts.VMStateQueryHelper {
-	newStateHelper, err := NewVMStateHelper(smartContractAddress)
-	if err == nil {
-		return newStateHelper
-	}
-
-	return nil
+	return stateHelper
 }
 
+// CommitState - Does nothing, changes made by a callee are committed once with the whole transaction
 func (stateHelper *VMStateHelper) CommitState() {
-	stateHelper.Commit()
 }
 
 func (stateHelper *VMStateHelper) GetEthStateDB() *ethState.StateDB {
@@ -36,6 +36,7 @@ import (
 	"encoding/base64"
 	"math/big"
 	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/helper"
 )
 
 // WithHttp -
@@ -151,13 +152,34 @@ func (this *LocalAPIService) deployHandler(responseWriter http.ResponseWriter, r
 		return
 	}
 
-	response, err := sdk.DeploySmartContract(
-		*delegates[0],
-		types.GetAccount().PrivateKey,
-		disgover.GetDisGoverService().ThisNode.Address,
-		deploy.ByteCode,
-		hex.EncodeToString([]byte(deploy.Abi)),
-	)
+	var response string
+	if len(deploy.Artifact) > 0 {
+		var artifact *helper.Artifact
+		artifact, err = helper.ReadArtifact(deploy.Artifact, deploy.Contract, deploy.Libraries)
+		if err != nil {
+			utils.Error("unable to read the artifact", err)
+			services.Error(responseWriter, fmt.Sprintf(`{"status":"%s: %v"}`, types.StatusInvalidRequest, err), http.StatusBadRequest)
+			return
+		}
+		response, err = sdk.DeployArtifact(
+			*delegates[0],
+			types.GetAccount().PrivateKey,
+			disgover.GetDisGoverService().ThisNode.Address,
+			artifact,
+			deploy.Value,
+			deploy.Params...,
+		)
+	} else {
+		response, err = sdk.DeploySmartContractWithValue(
+			*delegates[0],
+			types.GetAccount().PrivateKey,
+			disgover.GetDisGoverService().ThisNode.Address,
+			deploy.ByteCode,
+			deploy.Abi,
+			deploy.Value,
+			deploy.Params...,
+		)
+	}
 
 	// Send Reply
 	if err != nil {
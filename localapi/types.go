@@ -1,15 +1,23 @@
 package localapi
 
+import "encoding/json"
+
 // Transfer -
 type Transfer struct {
 	To     string `json:"to"`
 	Amount int64  `json:"amount"`
 }
 
-// Deploy -
+// Deploy - Either byteCode and abi, or the solc, Truffle or Hardhat output of artifact with the contract named by contract,
+// value is the tokens sent to the constructor
 type Deploy struct {
-	ByteCode string `json:"byteCode"`
-	Abi      string `json:"abi"`
+	ByteCode  string            `json:"byteCode"`
+	Abi       string            `json:"abi"`
+	Params    []interface{}     `json:"params"`
+	Value     int64             `json:"value,omitempty"`
+	Artifact  json.RawMessage   `json:"artifact,omitempty"`
+	Contract  string            `json:"contract,omitempty"`
+	Libraries map[string]string `json:"libraries,omitempty"`
 }
 
 // Execute -
@@ -18,11 +18,22 @@
 package main
 
 import (
+	"os"
+
 	"github.com/dispatchlabs/disgo/bootstrap"
 	"github.com/dispatchlabs/disgo/commons/utils"
 )
 
 func main() {
+	// disgo bind and disgo deploy are tools that do not run a node
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "bind":
+			os.Exit(runBind(os.Args[2:]))
+		case "deploy":
+			os.Exit(runDeploy(os.Args[2:]))
+		}
+	}
 	utils.InitMainPackagePath()
 	utils.InitializeLogger()
 	server := bootstrap.NewServer()
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// ReceiptPollInterval - How often receipts are polled while waiting for a transaction to execute
+var ReceiptPollInterval = 500 * time.Millisecond
+
+// DefaultReceiptTimeout - How long Backend waits for a receipt when the context has no deadline
+var DefaultReceiptTimeout = time.Minute
+
+// Backend - Calls a delegate over HTTP for the contract bindings disgo bind generates, it implements bind.DispatchBackend
+type Backend struct {
+	DelegateNode types.Node
+}
+
+// NewBackend
+func NewBackend(delegateNode types.Node) *Backend {
+	return &Backend{DelegateNode: delegateNode}
+}
+
+// SendTransaction
+func (this *Backend) SendTransaction(ctx context.Context, transaction *types.Transaction) error {
+	_, err := SendTransaction(this.DelegateNode, transaction)
+	return err
+}
+
+// WaitForReceipt - Waits until ctx is done, DefaultReceiptTimeout when ctx has no deadline
+func (this *Backend) WaitForReceipt(ctx context.Context, hash string) (*types.Receipt, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultReceiptTimeout)
+		defer cancel()
+	}
+	return pollReceipt(this.DelegateNode, hash, ReceiptPollInterval, ctx.Done())
+}
+
+// CallContract
+func (this *Backend) CallContract(ctx context.Context, address string, call *types.ContractCall) (*types.ContractCallResult, error) {
+	return CallSmartContract(this.DelegateNode, call.From, address, call.Method, call.Params)
+}
+
+// FilterLogs
+func (this *Backend) FilterLogs(ctx context.Context, filter *types.LogFilter) ([]*types.EventLog, error) {
+	var from, to time.Time
+	if filter.From > 0 {
+		from = time.Unix(0, filter.From*int64(time.Millisecond))
+	}
+	if filter.To > 0 {
+		to = time.Unix(0, filter.To*int64(time.Millisecond))
+	}
+	return GetLogs(this.DelegateNode, filter.Address, filter.Topics, from, to)
+}
@@ -12,10 +12,14 @@ import (
 
 	"bytes"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dispatchlabs/disgo/commons/utils"
 	"github.com/dispatchlabs/disgo/commons/crypto"
+	"github.com/dispatchlabs/disgo/commons/helper"
 )
 
 // GetDelegates - Get the known delegates at this point in time
@@ -142,6 +146,106 @@ func GetAccount(delegateNode types.Node, address string) (*types.Account, error)
 	return account, nil
 }
 
+// GetBalanceAt - Get the balance of an account at a point in time
+func GetBalanceAt(delegateNode types.Node, address string, at time.Time) (*types.AccountBalance, error) {
+
+	// Get balance
+	httpResponse, err := http.Get(fmt.Sprintf("http://%s:%d/v1/accounts/%s/balance?at=%d", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, address, utils.ToMilliSeconds(at)))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status?
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal balance.
+	var accountBalance *types.AccountBalance
+	err = json.Unmarshal(jsonMap["data"], &accountBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountBalance, nil
+}
+
+// GetStatement - Get the balance deltas of an account between two points in time
+func GetStatement(delegateNode types.Node, address string, from time.Time, to time.Time) (*types.Statement, error) {
+
+	// Get statement
+	httpResponse, err := http.Get(fmt.Sprintf("http://%s:%d/v1/accounts/%s/statement?from=%d&to=%d", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, address, utils.ToMilliSeconds(from), utils.ToMilliSeconds(to)))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status?
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal statement.
+	var statement *types.Statement
+	err = json.Unmarshal(jsonMap["data"], &statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
 // PackageTx - Package a Transaction
 func PackageTx(to string, tokens int64, time int64 ) (*types.Transaction, error) {
 
@@ -189,14 +293,36 @@ func TransferTokens(delegateNode types.Node, privateKey string, from string, to
 	return transaction.Hash, nil
 }
 
-// DeploySmartContract - Deploy a smart contract, get the TX hash as result
-func DeploySmartContract(delegateNode types.Node, privateKey string, from string, code string, abi string) (string, error) {
+// DeploySmartContract - Deploy a smart contract passing params to its constructor, get the TX hash as result
+func DeploySmartContract(delegateNode types.Node, privateKey string, from string, code string, abi string, params ...interface{}) (string, error) {
+	return DeploySmartContractWithValue(delegateNode, privateKey, from, code, abi, 0, params...)
+}
+
+// DeploySmartContractWithValue - Deploy a smart contract sending it value tokens and passing params to its constructor, get the TX hash as result
+func DeploySmartContractWithValue(delegateNode types.Node, privateKey string, from string, code string, abi string, value int64, params ...interface{}) (string, error) {
 	// Create deploy smart contract transaction.
-	transaction, err := types.NewDeployContractTransaction(privateKey, from, code, abi, utils.ToMilliSeconds(time.Now()))
+	transaction, err := types.NewDeployContractTransactionWithValue(privateKey, from, code, abi, value, utils.ToMilliSeconds(time.Now()), params...)
+	if err != nil {
+		return "", err
+	}
+
+	return SendTransaction(delegateNode, transaction)
+}
+
+// DeployArtifact - Deploy a contract read from compiler output with helper.ReadArtifact, publishing its source and metadata,
+// get the TX hash as result
+func DeployArtifact(delegateNode types.Node, privateKey string, from string, artifact *helper.Artifact, value int64, params ...interface{}) (string, error) {
+	transaction, err := types.NewDeployContractTransactionWithSource(privateKey, from, artifact.Code, artifact.Abi, artifact.Source, artifact.Metadata, value, utils.ToMilliSeconds(time.Now()), params...)
 	if err != nil {
 		return "", err
 	}
 
+	return SendTransaction(delegateNode, transaction)
+}
+
+// SendTransaction - Post a signed transaction, get the TX hash as result
+func SendTransaction(delegateNode types.Node, transaction *types.Transaction) (string, error) {
+
 	// Post transaction.
 	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
 	if err != nil {
@@ -227,38 +353,127 @@ func DeploySmartContract(delegateNode types.Node, privateKey string, from string
 
 // ExecuteSmartContractTransaction - Execute a smart contract, get the TX hash as result
 func ExecuteSmartContractTransaction(delegateNode types.Node, privateKey string, from string, to string, method string, params []interface{}) (string, error) {
+	return ExecuteSmartContractTransactionWithValue(delegateNode, privateKey, from, to, method, params, 0)
+}
+
+// ExecuteSmartContractTransactionWithValue - Execute a payable smart contract method sending it value tokens, get the TX hash as result
+func ExecuteSmartContractTransactionWithValue(delegateNode types.Node, privateKey string, from string, to string, method string, params []interface{}, value int64) (string, error) {
 	// Create execute smart contract transaction.
-	transaction, err := types.NewExecuteContractTransaction(privateKey, from, to, method, params, utils.ToMilliSeconds(time.Now()))
+	transaction, err := types.NewExecuteContractTransactionWithValue(privateKey, from, to, method, params, value, utils.ToMilliSeconds(time.Now()))
 	if err != nil {
 		return "", err
 	}
 
+	return SendTransaction(delegateNode, transaction)
+}
+
+// CallSmartContract - Call a smart contract method without a transaction, nothing is persisted, get the decoded outputs as result
+func CallSmartContract(delegateNode types.Node, from string, to string, method string, params []interface{}) (*types.ContractCallResult, error) {
+	contractCall := &types.ContractCall{From: from, Method: method, Params: params}
+
+	// Post call.
+	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/contracts/%s/call", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, to), "application/json", bytes.NewBuffer([]byte(contractCall.String())))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status?
+	if response.Status == types.StatusContractReverted {
+		return nil, &ContractRevertError{Reason: response.HumanReadableStatus}
+	}
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal result, numbers are json.Number so uint256 outputs keep every digit.
+	var contractCallResult *types.ContractCallResult
+	decoder := json.NewDecoder(bytes.NewReader(jsonMap["data"]))
+	decoder.UseNumber()
+	err = decoder.Decode(&contractCallResult)
+	if err != nil {
+		return nil, err
+	}
+
+	return contractCallResult, nil
+}
+
+// EstimateHertz - Dry-run a deploy or execute smart contract transaction, get the least hertz it succeeds with as result
+func EstimateHertz(delegateNode types.Node, transaction *types.Transaction) (*types.HertzEstimate, error) {
+
 	// Post transaction.
-	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
+	httpResponse, err := http.Post(fmt.Sprintf("http://%s:%d/v1/transactions/estimate", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port), "application/json", bytes.NewBuffer([]byte(transaction.String())))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer httpResponse.Body.Close()
 
 	// Read body.
 	body, err := ioutil.ReadAll(httpResponse.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Unmarshal response.
 	var response *types.Response
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Status?
-	if response.Status != types.StatusPending {
-		return "", errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	if response.Status == types.StatusContractReverted {
+		return nil, &ContractRevertError{Reason: response.HumanReadableStatus}
+	}
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
 	}
 
-	return transaction.Hash, nil
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal result.
+	var hertzEstimate *types.HertzEstimate
+	err = json.Unmarshal(jsonMap["data"], &hertzEstimate)
+	if err != nil {
+		return nil, err
+	}
+
+	return hertzEstimate, nil
 }
 
 // GetTransaction
@@ -323,6 +538,107 @@ func GetReceipt(delegateNode types.Node, hash string) (*types.Receipt, error) {
 	return &transaction.Receipt, nil
 }
 
+// WaitForReceipt - Poll the receipt of a transaction until it is no longer pending, an error is returned with the
+// receipt when the transaction did not succeed, a ContractRevertError when it reverted
+func WaitForReceipt(delegateNode types.Node, hash string, timeout time.Duration) (*types.Receipt, error) {
+	done := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(done) })
+	defer timer.Stop()
+	return pollReceipt(delegateNode, hash, ReceiptPollInterval, done)
+}
+
+// pollReceipt - Poll the receipt of a transaction every interval until it is no longer pending or done is closed
+func pollReceipt(delegateNode types.Node, hash string, interval time.Duration, done <-chan struct{}) (*types.Receipt, error) {
+	var lastErr error
+	for {
+		// Not found until the transaction reaches the delegate.
+		receipt, err := GetReceipt(delegateNode, hash)
+		if err != nil {
+			lastErr = err
+		} else if receipt.Status != "" && receipt.Status != types.StatusPending {
+			if receipt.Status == types.StatusOk {
+				return receipt, nil
+			}
+			if err := ToReceiptError(hash, receipt); err != nil {
+				return receipt, err
+			}
+			return receipt, errors.New(fmt.Sprintf("%s: %s [hash=%s]", receipt.Status, receipt.HumanReadableStatus, hash))
+		}
+		select {
+		case <-done:
+			if lastErr != nil {
+				return nil, errors.Errorf("timed out waiting for the receipt of %s: %v", hash, lastErr)
+			}
+			return nil, errors.Errorf("timed out waiting for the receipt of %s", hash)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// GetLogs - Get the contract event logs matching address and positional topics between from and to, an empty topic
+// matches any value, zero times are the beginning of time and now
+func GetLogs(delegateNode types.Node, address string, topics []string, from time.Time, to time.Time) ([]*types.EventLog, error) {
+	query := url.Values{}
+	if address != "" {
+		query.Set("address", address)
+	}
+	if len(topics) > 0 {
+		query.Set("topics", strings.Join(topics, ","))
+	}
+	if !from.IsZero() {
+		query.Set("from", strconv.FormatInt(utils.ToMilliSeconds(from), 10))
+	}
+	if !to.IsZero() {
+		query.Set("to", strconv.FormatInt(utils.ToMilliSeconds(to), 10))
+	}
+
+	// Get logs.
+	httpResponse, err := http.Get(fmt.Sprintf("http://%s:%d/v1/logs?%s", delegateNode.HttpEndpoint.Host, delegateNode.HttpEndpoint.Port, query.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Read body.
+	body, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal response.
+	var response *types.Response
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status?
+	if response.Status != types.StatusOk {
+		return nil, errors.New(fmt.Sprintf("%s: %s", response.Status, response.HumanReadableStatus))
+	}
+
+	// Unmarshal to RawMessage.
+	var jsonMap map[string]json.RawMessage
+	err = json.Unmarshal(body, &jsonMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data?
+	if jsonMap["data"] == nil {
+		return nil, errors.Errorf("'data' is missing from response")
+	}
+
+	// Unmarshal logs.
+	var eventLogs []*types.EventLog
+	err = json.Unmarshal(jsonMap["data"], &eventLogs)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventLogs, nil
+}
+
 // GetTransactions - Get details about sent transactions for a node
 func GetTransactions(delegateNode types.Node, pageOptions ...string) ([]types.Transaction, error) {
 	page := "1"
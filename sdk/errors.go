@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/dispatchlabs/disgo/commons/types"
+)
+
+// ContractRevertError - A contract reverted, Reason is the decoded revert reason
+type ContractRevertError struct {
+	TransactionHash string
+	Reason          string
+}
+
+// Error
+func (this *ContractRevertError) Error() string {
+	if this.TransactionHash == "" {
+		return fmt.Sprintf("%s: %s", types.StatusContractReverted, this.Reason)
+	}
+	return fmt.Sprintf("%s: %s [hash=%s]", types.StatusContractReverted, this.Reason, this.TransactionHash)
+}
+
+// ToReceiptError - Returns a ContractRevertError if the receipt's transaction reverted
+func ToReceiptError(hash string, receipt *types.Receipt) error {
+	if receipt == nil || receipt.Status != types.StatusContractReverted {
+		return nil
+	}
+	return &ContractRevertError{TransactionHash: hash, Reason: receipt.HumanReadableStatus}
+}